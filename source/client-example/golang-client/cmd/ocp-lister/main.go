@@ -1,6 +1,8 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"fmt"
 	"os"
 
@@ -15,6 +17,23 @@ import (
 )
 
 func main() {
+	var assumeYes bool
+	flag.BoolVar(&assumeYes, "yes", false, "Confirm destructive operations without prompting when input is not a terminal")
+	flag.BoolVar(&assumeYes, "force", false, "Alias for --yes")
+	limit := flag.Int64("limit", 0, "Maximum number of items per page of list output (default: no paging)")
+	continueToken := flag.String("continue", "", "Continue token printed by a previous paged list, to resume it")
+	insecure := flag.Bool("insecure", false, "Skip TLS certificate verification (same as OCP_INSECURE=true)")
+	deployDryRun := flag.Bool("dry-run", false, "Print the manifest a model deploy would create, without creating it")
+	flag.Parse()
+	if *limit < 0 {
+		fmt.Fprintln(os.Stderr, "Error: --limit must not be negative")
+		os.Exit(1)
+	}
+	auth.SetInsecure(*insecure)
+	menu.SetAssumeYes(assumeYes)
+	menu.SetPaging(*limit, *continueToken)
+	models.SetDryRun(*deployDryRun)
+
 	// Load authentication configuration from environment variables
 	authConfig, err := auth.LoadFromEnv()
 	if err != nil {
@@ -29,9 +48,20 @@ func main() {
 		authConfig.Server,
 		authConfig.Username,
 		authConfig.Password,
+		authConfig.OAuthRetries,
+		authConfig.TLSConfig(),
 	)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+		switch {
+		case errors.Is(err, client.ErrAuthFailed):
+			fmt.Fprintln(os.Stderr, "Error: wrong username or password")
+		case errors.Is(err, client.ErrCertificateUntrusted):
+			fmt.Fprintf(os.Stderr, "Error: the certificate of server %s is not trusted: %v\n", authConfig.Server, err)
+		case errors.Is(err, client.ErrServerUnreachable):
+			fmt.Fprintf(os.Stderr, "Error: can't reach server %s: %v\n", authConfig.Server, err)
+		default:
+			fmt.Fprintf(os.Stderr, "Error creating client: %v\n", err)
+		}
 		os.Exit(1)
 	}
 
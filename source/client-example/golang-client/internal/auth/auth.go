@@ -2,27 +2,87 @@ package auth
 
 import (
 	"fmt"
+	"net/url"
 	"os"
+	"strconv"
 	"strings"
+	"sync"
+
+	"github.com/bryon/ocp-lister/internal/client"
+)
+
+// Environment variables holding the OpenShift credentials
+const (
+	UsernameEnvVar = "OCP_USERNAME"
+	PasswordEnvVar = "OCP_PASSWORD"
+
+	// Deprecated fallbacks. USER in particular is set by almost every shell to the
+	// local OS username, which silently produces wrong-credential logins.
+	legacyUsernameEnvVar = "USER"
+	legacyPasswordEnvVar = "PASSWORD"
+)
+
+// OAuthRetriesEnvVar sets how many times a failed OAuth token request is retried
+const OAuthRetriesEnvVar = "OAUTH_RETRIES"
+
+// DefaultOAuthRetries is the number of OAuth token retries when OAUTH_RETRIES is unset
+const DefaultOAuthRetries = 2
+
+// Environment variables controlling TLS certificate verification
+const (
+	// InsecureEnvVar set to true disables certificate verification, like the --insecure flag
+	InsecureEnvVar = "OCP_INSECURE"
+	// CAFileEnvVar names a PEM bundle of CAs to trust instead of the kubeconfig CA data or system roots
+	CAFileEnvVar = "OCP_CA_FILE"
 )
 
+// insecureFlag is set by the --insecure flag and applies to every Config loaded afterwards
+var insecureFlag bool
+
+// SetInsecure disables certificate verification for every Config loaded afterwards, as the
+// --insecure flag does. It has the same effect as OCP_INSECURE=true.
+func SetInsecure(insecure bool) {
+	insecureFlag = insecure
+}
+
+// deprecationWarnings ensures each deprecated-variable warning is printed once,
+// since LoadFromEnv is called for every dynamic client that is created
+var deprecationWarnings sync.Map
+
 // Config holds authentication configuration
 type Config struct {
 	Username string
 	Password string
 	Server   string
+
+	// OAuthRetries is how many times token acquisition is retried after a network
+	// or server error. Authentication failures are never retried.
+	OAuthRetries int
+
+	// Insecure disables TLS certificate verification. It is only set explicitly, by
+	// --insecure or OCP_INSECURE=true.
+	Insecure bool
+	// CAFile is a PEM bundle of CAs to trust, from OCP_CA_FILE
+	CAFile string
+}
+
+// TLSConfig returns the certificate verification settings for the client package
+func (c *Config) TLSConfig() client.TLSConfig {
+	return client.TLSConfig{Insecure: c.Insecure, CAFile: c.CAFile}
 }
 
-// LoadFromEnv loads authentication configuration from environment variables
+// LoadFromEnv loads authentication configuration from environment variables.
+// Credentials are read from OCP_USERNAME and OCP_PASSWORD; the legacy USER and
+// PASSWORD variables are still honored as fallbacks but print a deprecation warning.
 func LoadFromEnv() (*Config, error) {
-	username := os.Getenv("USER")
+	username := getEnvWithFallback(UsernameEnvVar, legacyUsernameEnvVar)
 	if username == "" {
-		return nil, fmt.Errorf("USER environment variable is required")
+		return nil, fmt.Errorf("%s environment variable is required", UsernameEnvVar)
 	}
 
-	password := os.Getenv("PASSWORD")
+	password := getEnvWithFallback(PasswordEnvVar, legacyPasswordEnvVar)
 	if password == "" {
-		return nil, fmt.Errorf("PASSWORD environment variable is required")
+		return nil, fmt.Errorf("%s environment variable is required", PasswordEnvVar)
 	}
 
 	server := os.Getenv("SERVER")
@@ -30,14 +90,95 @@ func LoadFromEnv() (*Config, error) {
 		return nil, fmt.Errorf("SERVER environment variable is required")
 	}
 
-	// Ensure server URL doesn't have trailing slash
-	server = strings.TrimSuffix(server, "/")
+	server, err := NormalizeServerURL(server)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SERVER environment variable: %w", err)
+	}
+
+	oauthRetries := DefaultOAuthRetries
+	if raw := os.Getenv(OAuthRetriesEnvVar); raw != "" {
+		oauthRetries, err = strconv.Atoi(raw)
+		if err != nil || oauthRetries < 0 {
+			return nil, fmt.Errorf("invalid %s environment variable %q: must be a non-negative integer", OAuthRetriesEnvVar, raw)
+		}
+	}
+
+	insecure := insecureFlag
+	if raw := os.Getenv(InsecureEnvVar); raw != "" {
+		envInsecure, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s environment variable %q: must be true or false", InsecureEnvVar, raw)
+		}
+		insecure = insecure || envInsecure
+	}
+
+	caFile := os.Getenv(CAFileEnvVar)
+	if caFile != "" && insecure {
+		return nil, fmt.Errorf("%s cannot be combined with insecure mode: certificates are either verified against it or not at all", CAFileEnvVar)
+	}
 
 	return &Config{
-		Username: username,
-		Password: password,
-		Server:   server,
+		Username:     username,
+		Password:     password,
+		Server:       server,
+		OAuthRetries: oauthRetries,
+		Insecure:     insecure,
+		CAFile:       caFile,
 	}, nil
 }
 
+// getEnvWithFallback returns the value of name, or of the deprecated legacy
+// variable if name is unset, warning once when the legacy variable is used
+func getEnvWithFallback(name, legacy string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+
+	value := os.Getenv(legacy)
+	if value != "" {
+		if _, warned := deprecationWarnings.LoadOrStore(legacy, true); !warned {
+			fmt.Fprintf(os.Stderr, "Warning: %s is deprecated for credentials, set %s instead\n", legacy, name)
+		}
+	}
+	return value
+}
+
+// NormalizeServerURL validates an API server URL and returns it in canonical form
+// (scheme://host[:port] with no trailing slash).
+// A value without a scheme defaults to https, but must then include a port
+// (e.g. api.example.com:6443) since a bare hostname is almost always a typo
+// for the API server address.
+func NormalizeServerURL(raw string) (string, error) {
+	value := strings.TrimSpace(raw)
+	if value == "" {
+		return "", fmt.Errorf("server URL is empty")
+	}
+
+	hasScheme := strings.Contains(value, "://")
+	if !hasScheme {
+		value = "https://" + value
+	}
+
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return "", fmt.Errorf("malformed server URL %q: %w", raw, err)
+	}
+
+	if parsed.Scheme != "https" && parsed.Scheme != "http" {
+		return "", fmt.Errorf("server URL %q must use https or http, got scheme %q", raw, parsed.Scheme)
+	}
+	if parsed.Hostname() == "" {
+		return "", fmt.Errorf("server URL %q is missing a host", raw)
+	}
+	if !hasScheme && parsed.Port() == "" {
+		return "", fmt.Errorf("server URL %q has no scheme or port; use the API address, e.g. https://%s:6443", raw, parsed.Hostname())
+	}
+	if parsed.Path != "" && parsed.Path != "/" {
+		return "", fmt.Errorf("server URL %q must not include a path", raw)
+	}
+	if parsed.RawQuery != "" || parsed.Fragment != "" {
+		return "", fmt.Errorf("server URL %q must not include a query or fragment", raw)
+	}
 
+	return fmt.Sprintf("%s://%s", parsed.Scheme, parsed.Host), nil
+}
@@ -0,0 +1,164 @@
+package auth
+
+import "testing"
+
+func TestNormalizeServerURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		// Valid inputs
+		{"https with port", "https://api.sno.example.com:6443", "https://api.sno.example.com:6443", false},
+		{"trailing slash trimmed", "https://api.sno.example.com:6443/", "https://api.sno.example.com:6443", false},
+		{"https without port", "https://api.sno.example.com", "https://api.sno.example.com", false},
+		{"http allowed", "http://localhost:8080", "http://localhost:8080", false},
+		{"surrounding whitespace", "  https://api.sno.example.com:6443  ", "https://api.sno.example.com:6443", false},
+
+		// Scheme-less inputs default to https
+		{"scheme-less with port", "api.sno.example.com:6443", "https://api.sno.example.com:6443", false},
+		{"scheme-less ip with port", "10.0.0.1:6443", "https://10.0.0.1:6443", false},
+
+		// Malformed inputs
+		{"empty", "", "", true},
+		{"bare hostname", "api.sno.example.com", "", true},
+		{"unsupported scheme", "ftp://api.sno.example.com:6443", "", true},
+		{"missing host", "https://:6443", "", true},
+		{"includes path", "https://console.example.com/dashboards", "", true},
+		{"includes query", "https://api.sno.example.com:6443?x=1", "", true},
+		{"invalid port", "https://api.sno.example.com:port", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeServerURL(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NormalizeServerURL(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeServerURL(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadFromEnv_NormalizesServer(t *testing.T) {
+	t.Setenv("OCP_USERNAME", "developer")
+	t.Setenv("OCP_PASSWORD", "secret")
+	t.Setenv("SERVER", "api.sno.example.com:6443")
+
+	config, err := LoadFromEnv()
+	if err != nil {
+		t.Fatalf("LoadFromEnv() error = %v", err)
+	}
+	if config.Server != "https://api.sno.example.com:6443" {
+		t.Errorf("Expected normalized server, got %q", config.Server)
+	}
+}
+
+func TestLoadFromEnv_RejectsMalformedServer(t *testing.T) {
+	t.Setenv("OCP_USERNAME", "developer")
+	t.Setenv("OCP_PASSWORD", "secret")
+	t.Setenv("SERVER", "api.sno.example.com")
+
+	if _, err := LoadFromEnv(); err == nil {
+		t.Error("Expected an error for a bare hostname SERVER")
+	}
+}
+
+func TestLoadFromEnv_PrefersOCPCredentials(t *testing.T) {
+	t.Setenv("USER", "shell-user")
+	t.Setenv("PASSWORD", "legacy-secret")
+	t.Setenv("OCP_USERNAME", "developer")
+	t.Setenv("OCP_PASSWORD", "secret")
+	t.Setenv("SERVER", "https://api.sno.example.com:6443")
+
+	config, err := LoadFromEnv()
+	if err != nil {
+		t.Fatalf("LoadFromEnv() error = %v", err)
+	}
+	if config.Username != "developer" {
+		t.Errorf("Expected OCP_USERNAME to take precedence, got username %q", config.Username)
+	}
+	if config.Password != "secret" {
+		t.Errorf("Expected OCP_PASSWORD to take precedence, got password %q", config.Password)
+	}
+}
+
+func TestLoadFromEnv_LegacyFallback(t *testing.T) {
+	t.Setenv("OCP_USERNAME", "")
+	t.Setenv("OCP_PASSWORD", "")
+	t.Setenv("USER", "legacy-user")
+	t.Setenv("PASSWORD", "legacy-secret")
+	t.Setenv("SERVER", "https://api.sno.example.com:6443")
+
+	config, err := LoadFromEnv()
+	if err != nil {
+		t.Fatalf("LoadFromEnv() error = %v", err)
+	}
+	if config.Username != "legacy-user" || config.Password != "legacy-secret" {
+		t.Errorf("Expected legacy USER/PASSWORD fallback, got %q/%q", config.Username, config.Password)
+	}
+}
+
+func TestLoadFromEnv_OAuthRetries(t *testing.T) {
+	t.Setenv("OCP_USERNAME", "developer")
+	t.Setenv("OCP_PASSWORD", "secret")
+	t.Setenv("SERVER", "https://api.sno.example.com:6443")
+
+	config, err := LoadFromEnv()
+	if err != nil {
+		t.Fatalf("LoadFromEnv() error = %v", err)
+	}
+	if config.OAuthRetries != DefaultOAuthRetries {
+		t.Errorf("Expected default of %d retries, got %d", DefaultOAuthRetries, config.OAuthRetries)
+	}
+
+	t.Setenv("OAUTH_RETRIES", "0")
+	config, err = LoadFromEnv()
+	if err != nil {
+		t.Fatalf("LoadFromEnv() error = %v", err)
+	}
+	if config.OAuthRetries != 0 {
+		t.Errorf("Expected 0 retries, got %d", config.OAuthRetries)
+	}
+
+	for _, value := range []string{"-1", "many"} {
+		t.Setenv("OAUTH_RETRIES", value)
+		if _, err := LoadFromEnv(); err == nil {
+			t.Errorf("Expected an error for OAUTH_RETRIES=%q", value)
+		}
+	}
+}
+
+func TestLoadFromEnv_InsecureIsOptIn(t *testing.T) {
+	t.Setenv("OCP_USERNAME", "developer")
+	t.Setenv("OCP_PASSWORD", "secret")
+	t.Setenv("SERVER", "https://api.sno.example.com:6443")
+	t.Cleanup(func() { SetInsecure(false) })
+
+	config, err := LoadFromEnv()
+	if err != nil {
+		t.Fatalf("LoadFromEnv() error = %v", err)
+	}
+	if config.Insecure {
+		t.Error("Expected certificate verification by default")
+	}
+
+	t.Setenv(InsecureEnvVar, "true")
+	if config, err = LoadFromEnv(); err != nil || !config.Insecure {
+		t.Errorf("Expected OCP_INSECURE=true to disable verification, got %+v, %v", config, err)
+	}
+
+	t.Setenv(InsecureEnvVar, "")
+	SetInsecure(true)
+	if config, err = LoadFromEnv(); err != nil || !config.Insecure {
+		t.Errorf("Expected --insecure to disable verification, got %+v, %v", config, err)
+	}
+
+	t.Setenv(CAFileEnvVar, "/etc/ocp/ca.crt")
+	if _, err := LoadFromEnv(); err == nil {
+		t.Error("Expected OCP_CA_FILE to be rejected in insecure mode")
+	}
+}
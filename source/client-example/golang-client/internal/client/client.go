@@ -2,8 +2,10 @@ package client
 
 import (
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -11,6 +13,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
@@ -25,12 +28,115 @@ type tokenResponse struct {
 	RefreshToken string `json:"refresh_token"`
 }
 
-// getOAuthToken obtains an OAuth token from OpenShift using username/password
-// Uses the challenge-response flow similar to oc login
-func getOAuthToken(server, username, password string) (string, error) {
-	// Create HTTP client that accepts insecure certificates
+// Errors returned by OAuth token acquisition, for callers to tell the user what went wrong
+var (
+	// ErrAuthFailed means the server rejected the username or password
+	ErrAuthFailed = errors.New("authentication failed: the username or password was rejected")
+	// ErrServerUnreachable means the OAuth server could not be reached
+	ErrServerUnreachable = errors.New("cannot reach the OpenShift OAuth server")
+	// ErrCertificateUntrusted means the OAuth server's TLS certificate failed verification
+	ErrCertificateUntrusted = errors.New("the OpenShift OAuth server's certificate is not trusted")
+)
+
+// TLSConfig controls how the API and OAuth servers' certificates are verified.
+// Verification is on unless Insecure is set.
+type TLSConfig struct {
+	// Insecure disables certificate verification. It cannot be combined with CAFile.
+	Insecure bool
+	// CAFile is a PEM bundle of CAs to trust, replacing the kubeconfig CA data and
+	// the system roots. Empty uses the kubeconfig CA data, or else the system roots.
+	CAFile string
+}
+
+// httpTLSConfig returns the crypto/tls configuration for OAuth requests
+func (t TLSConfig) httpTLSConfig() (*tls.Config, error) {
+	if t.Insecure {
+		return &tls.Config{InsecureSkipVerify: true}, nil
+	}
+	if t.CAFile == "" {
+		return &tls.Config{}, nil
+	}
+	pem, err := os.ReadFile(t.CAFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA file: %w", err)
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("CA file %s contains no PEM certificates", t.CAFile)
+	}
+	return &tls.Config{RootCAs: roots}, nil
+}
+
+// apply sets the certificate verification of a REST config. Insecure mode clears any CA
+// data, which client-go refuses to combine with it.
+func (t TLSConfig) apply(config *rest.TLSClientConfig) {
+	switch {
+	case t.Insecure:
+		config.Insecure = true
+		config.CAFile = ""
+		config.CAData = nil
+	case t.CAFile != "":
+		config.CAFile = t.CAFile
+		config.CAData = nil
+	}
+}
+
+// unreachableError wraps a failed OAuth request as ErrCertificateUntrusted when the server's
+// certificate was rejected, and as ErrServerUnreachable otherwise
+func unreachableError(action string, err error) error {
+	var verifyErr *tls.CertificateVerificationError
+	var authorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	if errors.As(err, &verifyErr) || errors.As(err, &authorityErr) || errors.As(err, &hostnameErr) {
+		return fmt.Errorf("%w: failed to %s: %v (set OCP_CA_FILE to the cluster CA, or use --insecure)", ErrCertificateUntrusted, action, err)
+	}
+	return fmt.Errorf("%w: failed to %s: %v", ErrServerUnreachable, action, err)
+}
+
+// defaultOAuthBackoff is the delay before the first OAuth retry; it doubles on each retry
+const defaultOAuthBackoff = 500 * time.Millisecond
+
+// retryPolicy bounds the retries of OAuth token acquisition
+type retryPolicy struct {
+	retries int           // Retries after the first attempt
+	backoff time.Duration // Delay before the first retry, doubled for each one after
+}
+
+// tokenStatusError is an unexpected HTTP status from the token endpoint
+type tokenStatusError struct {
+	status int
+	body   string
+}
+
+func (e *tokenStatusError) Error() string {
+	return fmt.Sprintf("token request failed with status %d: %s", e.status, e.body)
+}
+
+// retryable reports whether a failed token attempt may succeed if repeated:
+// network failures, server errors and rate limiting are retried, authentication failures are not
+func retryable(err error) bool {
+	if errors.Is(err, ErrServerUnreachable) {
+		return true
+	}
+	var statusErr *tokenStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.status >= 500 || statusErr.status == http.StatusTooManyRequests
+	}
+	return false
+}
+
+// getOAuthToken obtains an OAuth token from OpenShift using username/password,
+// retrying network and server failures with exponential backoff as allowed by policy.
+// Returns an error wrapping ErrAuthFailed, ErrCertificateUntrusted or ErrServerUnreachable
+// when those are the cause.
+func getOAuthToken(server, username, password string, tlsConfig TLSConfig, policy retryPolicy) (string, error) {
+	// Create HTTP client that verifies certificates as configured
+	clientTLS, err := tlsConfig.httpTLSConfig()
+	if err != nil {
+		return "", err
+	}
 	tr := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		TLSClientConfig: clientTLS,
 	}
 	client := &http.Client{
 		Transport: tr,
@@ -40,37 +146,133 @@ func getOAuthToken(server, username, password string) (string, error) {
 		},
 	}
 
-	// Step 1: Request authorization with challenge
-	authURL := fmt.Sprintf("%s/oauth/authorize?client_id=openshift-challenging-client&response_type=token", strings.TrimSuffix(server, "/"))
-	req, err := http.NewRequest("GET", authURL, nil)
+	backoff := policy.backoff
+	for attempt := 0; ; attempt++ {
+		token, err := requestOAuthToken(client, server, username, password)
+		if err == nil {
+			return token, nil
+		}
+		if !retryable(err) || attempt >= policy.retries {
+			if attempt > 0 {
+				return "", fmt.Errorf("%w (after %d attempts)", err, attempt+1)
+			}
+			return "", err
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// maxOAuthRedirects bounds the challenge redirect chain followed by followChallenge
+const maxOAuthRedirects = 5
+
+// followChallenge requests authURL with the challenge headers and follows the 302/303 redirect
+// chain, returning the access_token from the fragment of whichever hop carries it.
+// Only hops to an OAuth authorize endpoint on one of trustedHosts are followed, and never from
+// https to http, so the credentials are not sent anywhere else; a redirect elsewhere, such as an
+// external identity provider's login page, ends the chain. An empty token with a nil error means
+// the chain ended without one and the caller should fall back to the token endpoint.
+func followChallenge(client *http.Client, authURL, auth string, trustedHosts map[string]bool) (string, error) {
+	next, err := url.Parse(authURL)
 	if err != nil {
 		return "", fmt.Errorf("failed to create auth request: %w", err)
 	}
+	scheme := next.Scheme
 
-	// Use basic auth
-	auth := base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
-	req.Header.Set("Authorization", "Basic "+auth)
-	req.Header.Set("X-CSRF-Token", "1")
+	for hop := 0; hop <= maxOAuthRedirects; hop++ {
+		req, err := http.NewRequest("GET", next.String(), nil)
+		if err != nil {
+			return "", fmt.Errorf("failed to create auth request: %w", err)
+		}
+		req.Header.Set("Authorization", "Basic "+auth)
+		req.Header.Set("X-CSRF-Token", "1")
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return "", unreachableError("request authorization", err)
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != http.StatusFound && resp.StatusCode != http.StatusSeeOther {
+			return "", nil
+		}
+		location, err := resp.Location()
+		if err != nil {
+			return "", nil
+		}
+
+		// Parse the token from the redirect URL fragment
+		if location.Fragment != "" {
+			values, _ := url.ParseQuery(location.Fragment)
+			if token := values.Get("access_token"); token != "" {
+				return token, nil
+			}
+		}
+
+		if location.Scheme != "https" && location.Scheme != scheme {
+			return "", nil
+		}
+		if !trustedHosts[location.Host] || location.Path != "/oauth/authorize" {
+			return "", nil
+		}
+		scheme = location.Scheme
+		next = location
+	}
+
+	return "", nil
+}
+
+// oauthMetadata is the subset of the OAuth authorization server metadata used to find the issuer
+type oauthMetadata struct {
+	Issuer string `json:"issuer"`
+}
 
-	resp, err := client.Do(req)
+// discoverOAuthIssuer returns the host of the cluster OAuth server advertised by the API server's
+// well-known metadata endpoint, or "" when it cannot be discovered
+func discoverOAuthIssuer(client *http.Client, server string) string {
+	resp, err := client.Get(strings.TrimSuffix(server, "/") + "/.well-known/oauth-authorization-server")
 	if err != nil {
-		return "", fmt.Errorf("failed to request authorization: %w", err)
+		return ""
 	}
 	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return ""
+	}
 
-	// Check for redirect with token in fragment
-	if resp.StatusCode == http.StatusFound || resp.StatusCode == http.StatusSeeOther {
-		location := resp.Header.Get("Location")
-		if location != "" {
-			// Parse the token from the redirect URL fragment
-			parsedURL, err := url.Parse(location)
-			if err == nil && parsedURL.Fragment != "" {
-				values, _ := url.ParseQuery(parsedURL.Fragment)
-				if token := values.Get("access_token"); token != "" {
-					return token, nil
-				}
-			}
-		}
+	var metadata oauthMetadata
+	if err := json.NewDecoder(resp.Body).Decode(&metadata); err != nil {
+		return ""
+	}
+	issuer, err := url.Parse(metadata.Issuer)
+	if err != nil || (issuer.Scheme != "https" && issuer.Scheme != "http") {
+		return ""
+	}
+	return issuer.Host
+}
+
+// requestOAuthToken makes a single attempt to obtain a token.
+// Uses the challenge-response flow similar to oc login, falling back to the token endpoint.
+func requestOAuthToken(client *http.Client, server, username, password string) (string, error) {
+	// Step 1: Request authorization with challenge, following the redirect chain
+	authURL := fmt.Sprintf("%s/oauth/authorize?client_id=openshift-challenging-client&response_type=token", strings.TrimSuffix(server, "/"))
+
+	// Use basic auth
+	auth := base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+
+	trustedHosts := map[string]bool{}
+	if u, err := url.Parse(server); err == nil {
+		trustedHosts[u.Host] = true
+	}
+	if issuer := discoverOAuthIssuer(client, server); issuer != "" {
+		trustedHosts[issuer] = true
+	}
+
+	token, err := followChallenge(client, authURL, auth, trustedHosts)
+	if err != nil {
+		return "", err
+	}
+	if token != "" {
+		return token, nil
 	}
 
 	// If challenge-response didn't work, try direct token endpoint
@@ -92,13 +294,16 @@ func getOAuthToken(server, username, password string) (string, error) {
 
 	resp2, err := client.Do(req2)
 	if err != nil {
-		return "", fmt.Errorf("failed to request token: %w", err)
+		return "", unreachableError("request token", err)
 	}
 	defer resp2.Body.Close()
 
+	if resp2.StatusCode == http.StatusUnauthorized {
+		return "", ErrAuthFailed
+	}
 	if resp2.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp2.Body)
-		return "", fmt.Errorf("token request failed with status %d: %s", resp2.StatusCode, string(body))
+		return "", &tokenStatusError{status: resp2.StatusCode, body: string(body)}
 	}
 
 	// Parse the response
@@ -114,8 +319,9 @@ func getOAuthToken(server, username, password string) (string, error) {
 	return tokenResp.AccessToken, nil
 }
 
-// tryKubeconfig attempts to load config from kubeconfig file
-func tryKubeconfig() (*rest.Config, error) {
+// tryKubeconfig attempts to load config from kubeconfig file, verifying certificates with
+// the kubeconfig's CA data unless tlsConfig says otherwise
+func tryKubeconfig(tlsConfig TLSConfig) (*rest.Config, error) {
 	// Try KUBECONFIG environment variable first
 	kubeconfig := os.Getenv("KUBECONFIG")
 	if kubeconfig == "" {
@@ -138,20 +344,21 @@ func tryKubeconfig() (*rest.Config, error) {
 		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
 	}
 
-	// Set insecure if needed
-	config.TLSClientConfig.Insecure = true
+	tlsConfig.apply(&config.TLSClientConfig)
 
 	return config, nil
 }
 
 // CreateClient creates a Kubernetes client using username/password authentication
-// First tries to use kubeconfig if available, otherwise falls back to OAuth token
-func CreateClient(server, username, password string) (*kubernetes.Clientset, error) {
+// First tries to use kubeconfig if available, otherwise falls back to OAuth token,
+// retrying token acquisition up to oauthRetries times after network or server errors.
+// Certificates are verified as set by tlsConfig.
+func CreateClient(server, username, password string, oauthRetries int, tlsConfig TLSConfig) (*kubernetes.Clientset, error) {
 	var config *rest.Config
 	var err error
 
 	// First, try to use kubeconfig (preferred method, works with oc login)
-	config, err = tryKubeconfig()
+	config, err = tryKubeconfig(tlsConfig)
 	if err == nil {
 		// Successfully loaded from kubeconfig
 		clientset, err := kubernetes.NewForConfig(config)
@@ -167,7 +374,7 @@ func CreateClient(server, username, password string) (*kubernetes.Clientset, err
 	}
 
 	// Get an OAuth token using username/password
-	token, err := getOAuthToken(server, username, password)
+	token, err := getOAuthToken(server, username, password, tlsConfig, retryPolicy{retries: oauthRetries, backoff: defaultOAuthBackoff})
 	if err != nil {
 		return nil, fmt.Errorf("failed to obtain OAuth token: %w", err)
 	}
@@ -178,10 +385,8 @@ func CreateClient(server, username, password string) (*kubernetes.Clientset, err
 	config = &rest.Config{
 		Host:        server,
 		BearerToken: token,
-		TLSClientConfig: rest.TLSClientConfig{
-			Insecure: true, // Accept unsigned/self-signed certificates
-		},
 	}
+	tlsConfig.apply(&config.TLSClientConfig)
 
 	// Create the clientset
 	clientset, err := kubernetes.NewForConfig(config)
@@ -194,12 +399,12 @@ func CreateClient(server, username, password string) (*kubernetes.Clientset, err
 
 // GetRESTConfig returns the REST config used by the client
 // This is a helper to create dynamic clients for OpenShift-specific resources
-func GetRESTConfig(server, username, password string) (*rest.Config, error) {
+func GetRESTConfig(server, username, password string, oauthRetries int, tlsConfig TLSConfig) (*rest.Config, error) {
 	var config *rest.Config
 	var err error
 
 	// First, try to use kubeconfig (preferred method, works with oc login)
-	config, err = tryKubeconfig()
+	config, err = tryKubeconfig(tlsConfig)
 	if err == nil {
 		return config, nil
 	}
@@ -210,7 +415,7 @@ func GetRESTConfig(server, username, password string) (*rest.Config, error) {
 	}
 
 	// Get an OAuth token using username/password
-	token, err := getOAuthToken(server, username, password)
+	token, err := getOAuthToken(server, username, password, tlsConfig, retryPolicy{retries: oauthRetries, backoff: defaultOAuthBackoff})
 	if err != nil {
 		return nil, fmt.Errorf("failed to obtain OAuth token: %w", err)
 	}
@@ -219,10 +424,8 @@ func GetRESTConfig(server, username, password string) (*rest.Config, error) {
 	config = &rest.Config{
 		Host:        server,
 		BearerToken: token,
-		TLSClientConfig: rest.TLSClientConfig{
-			Insecure: true, // Accept unsigned/self-signed certificates
-		},
 	}
+	tlsConfig.apply(&config.TLSClientConfig)
 
 	return config, nil
 }
@@ -0,0 +1,380 @@
+package client
+
+import (
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testPolicy retries quickly so failure-mode tests stay fast
+func testPolicy(retries int) retryPolicy {
+	return retryPolicy{retries: retries, backoff: time.Millisecond}
+}
+
+// newOAuthServer starts a server whose challenge endpoint never issues a token, so every
+// attempt reaches the token endpoint, answered by tokenHandler. It returns the server and
+// a counter of token endpoint requests.
+func newOAuthServer(t *testing.T, tokenHandler http.HandlerFunc) (*httptest.Server, *int32) {
+	t.Helper()
+	var tokenRequests int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/oauth/authorize", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+	mux.HandleFunc("/oauth/token", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&tokenRequests, 1)
+		tokenHandler(w, r)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server, &tokenRequests
+}
+
+func TestGetOAuthToken_ChallengeRedirect(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Location", "https://oauth.example.com/implicit#access_token=sha256~abc&token_type=Bearer")
+		w.WriteHeader(http.StatusFound)
+	}))
+	defer server.Close()
+
+	token, err := getOAuthToken(server.URL, "developer", "secret", TLSConfig{}, testPolicy(0))
+	if err != nil {
+		t.Fatalf("getOAuthToken() error = %v", err)
+	}
+	if token != "sha256~abc" {
+		t.Errorf("Expected token from the redirect fragment, got %q", token)
+	}
+}
+
+func TestGetOAuthToken_AuthFailureNotRetried(t *testing.T) {
+	server, tokenRequests := newOAuthServer(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+	})
+
+	_, err := getOAuthToken(server.URL, "developer", "wrong", TLSConfig{}, testPolicy(3))
+	if !errors.Is(err, ErrAuthFailed) {
+		t.Fatalf("Expected ErrAuthFailed, got %v", err)
+	}
+	if errors.Is(err, ErrServerUnreachable) {
+		t.Errorf("Expected an auth failure not to be reported as unreachable, got %v", err)
+	}
+	if got := atomic.LoadInt32(tokenRequests); got != 1 {
+		t.Errorf("Expected 1 token request, got %d", got)
+	}
+}
+
+func TestGetOAuthToken_ServerErrorRetried(t *testing.T) {
+	var calls int32
+	server, tokenRequests := newOAuthServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			http.Error(w, "oauth server starting", http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token": "sha256~retried", "token_type": "Bearer"}`))
+	})
+
+	token, err := getOAuthToken(server.URL, "developer", "secret", TLSConfig{}, testPolicy(2))
+	if err != nil {
+		t.Fatalf("getOAuthToken() error = %v", err)
+	}
+	if token != "sha256~retried" {
+		t.Errorf("Expected token from the third attempt, got %q", token)
+	}
+	if got := atomic.LoadInt32(tokenRequests); got != 3 {
+		t.Errorf("Expected 3 token requests, got %d", got)
+	}
+}
+
+func TestGetOAuthToken_RetryBudgetExhausted(t *testing.T) {
+	server, tokenRequests := newOAuthServer(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "oauth server starting", http.StatusServiceUnavailable)
+	})
+
+	_, err := getOAuthToken(server.URL, "developer", "secret", TLSConfig{}, testPolicy(2))
+	var statusErr *tokenStatusError
+	if !errors.As(err, &statusErr) || statusErr.status != http.StatusServiceUnavailable {
+		t.Fatalf("Expected a 503 token status error, got %v", err)
+	}
+	if got := atomic.LoadInt32(tokenRequests); got != 3 {
+		t.Errorf("Expected 1 attempt plus 2 retries, got %d token requests", got)
+	}
+}
+
+func TestGetOAuthToken_Unreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	_, err := getOAuthToken(url, "developer", "secret", TLSConfig{}, testPolicy(1))
+	if !errors.Is(err, ErrServerUnreachable) {
+		t.Fatalf("Expected ErrServerUnreachable, got %v", err)
+	}
+	if errors.Is(err, ErrAuthFailed) {
+		t.Errorf("Expected a network failure not to be reported as an auth failure, got %v", err)
+	}
+}
+
+func TestGetOAuthToken_TwoHopRedirect(t *testing.T) {
+	// The API server redirects to its advertised OAuth server, which redirects again with the token
+	var oauthServer *httptest.Server
+	oauthServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "" {
+			t.Errorf("Expected the challenge credentials on the second hop")
+		}
+		http.Redirect(w, r, oauthServer.URL+"/oauth/token/implicit#access_token=sha256~hop&token_type=Bearer", http.StatusFound)
+	}))
+	defer oauthServer.Close()
+
+	var tokenRequests int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/oauth-authorization-server", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"issuer": "` + oauthServer.URL + `"}`))
+	})
+	mux.HandleFunc("/oauth/authorize", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, oauthServer.URL+"/oauth/authorize?"+r.URL.RawQuery, http.StatusFound)
+	})
+	mux.HandleFunc("/oauth/token", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&tokenRequests, 1)
+	})
+	apiServer := httptest.NewServer(mux)
+	defer apiServer.Close()
+
+	token, err := getOAuthToken(apiServer.URL, "developer", "secret", TLSConfig{}, testPolicy(0))
+	if err != nil {
+		t.Fatalf("getOAuthToken() error = %v", err)
+	}
+	if token != "sha256~hop" {
+		t.Errorf("Expected token from the second hop, got %q", token)
+	}
+	if got := atomic.LoadInt32(&tokenRequests); got != 0 {
+		t.Errorf("Expected no token endpoint requests, got %d", got)
+	}
+}
+
+func TestGetOAuthToken_UndiscoveredHostNotFollowed(t *testing.T) {
+	// An authorize endpoint on a host the API server does not advertise as its issuer must not
+	// receive the challenge credentials
+	var otherRequests int32
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&otherRequests, 1)
+	}))
+	defer other.Close()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/oauth/authorize", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, other.URL+"/oauth/authorize?"+r.URL.RawQuery, http.StatusFound)
+	})
+	mux.HandleFunc("/oauth/token", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token": "sha256~direct"}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	token, err := getOAuthToken(server.URL, "developer", "secret", TLSConfig{}, testPolicy(0))
+	if err != nil {
+		t.Fatalf("getOAuthToken() error = %v", err)
+	}
+	if token != "sha256~direct" {
+		t.Errorf("Expected the token endpoint fallback, got %q", token)
+	}
+	if got := atomic.LoadInt32(&otherRequests); got != 0 {
+		t.Errorf("Expected the undiscovered host not to be contacted, got %d requests", got)
+	}
+}
+
+func TestGetOAuthToken_HTTPSDowngradeNotFollowed(t *testing.T) {
+	// Even the advertised issuer must not receive the credentials over plain http once the
+	// chain started on https
+	var issuerRequests int32
+	issuer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&issuerRequests, 1)
+	}))
+	defer issuer.Close()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/oauth-authorization-server", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"issuer": "` + issuer.URL + `"}`))
+	})
+	mux.HandleFunc("/oauth/authorize", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, issuer.URL+"/oauth/authorize?"+r.URL.RawQuery, http.StatusFound)
+	})
+	mux.HandleFunc("/oauth/token", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token": "sha256~direct"}`))
+	})
+	server := httptest.NewTLSServer(mux)
+	defer server.Close()
+
+	token, err := getOAuthToken(server.URL, "developer", "secret", TLSConfig{Insecure: true}, testPolicy(0))
+	if err != nil {
+		t.Fatalf("getOAuthToken() error = %v", err)
+	}
+	if token != "sha256~direct" {
+		t.Errorf("Expected the token endpoint fallback, got %q", token)
+	}
+	if got := atomic.LoadInt32(&issuerRequests); got != 0 {
+		t.Errorf("Expected no plain http request to the issuer, got %d requests", got)
+	}
+}
+
+func TestGetOAuthToken_ExternalIdPNotFollowed(t *testing.T) {
+	var idpRequests int32
+	idp := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&idpRequests, 1)
+	}))
+	defer idp.Close()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/oauth/authorize", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, idp.URL+"/realms/corp/protocol/openid-connect/auth", http.StatusFound)
+	})
+	mux.HandleFunc("/oauth/token", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token": "sha256~direct"}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	token, err := getOAuthToken(server.URL, "developer", "secret", TLSConfig{}, testPolicy(0))
+	if err != nil {
+		t.Fatalf("getOAuthToken() error = %v", err)
+	}
+	if token != "sha256~direct" {
+		t.Errorf("Expected the token endpoint fallback, got %q", token)
+	}
+	if got := atomic.LoadInt32(&idpRequests); got != 0 {
+		t.Errorf("Expected the external IdP not to be contacted, got %d requests", got)
+	}
+}
+
+func TestGetOAuthToken_RedirectLoopBounded(t *testing.T) {
+	var authorizeRequests int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/oauth/authorize", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&authorizeRequests, 1)
+		http.Redirect(w, r, "/oauth/authorize?"+r.URL.RawQuery, http.StatusFound)
+	})
+	mux.HandleFunc("/oauth/token", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	if _, err := getOAuthToken(server.URL, "developer", "secret", TLSConfig{}, testPolicy(0)); !errors.Is(err, ErrAuthFailed) {
+		t.Errorf("Expected the token endpoint fallback to report ErrAuthFailed, got %v", err)
+	}
+	if got := atomic.LoadInt32(&authorizeRequests); got != maxOAuthRedirects+1 {
+		t.Errorf("Expected %d authorize requests, got %d", maxOAuthRedirects+1, got)
+	}
+}
+
+// newTLSTokenServer starts an HTTPS server with a self-signed certificate whose token
+// endpoint issues a token, and returns it with a PEM file holding its certificate
+func newTLSTokenServer(t *testing.T) (*httptest.Server, string) {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/oauth/authorize", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+	mux.HandleFunc("/oauth/token", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token": "sha256~tls"}`))
+	})
+	server := httptest.NewTLSServer(mux)
+	t.Cleanup(server.Close)
+
+	caFile := filepath.Join(t.TempDir(), "ca.crt")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caFile, certPEM, 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	return server, caFile
+}
+
+func TestGetOAuthToken_UntrustedCertificateRejected(t *testing.T) {
+	server, _ := newTLSTokenServer(t)
+
+	_, err := getOAuthToken(server.URL, "developer", "secret", TLSConfig{}, testPolicy(2))
+	if !errors.Is(err, ErrCertificateUntrusted) {
+		t.Fatalf("Expected ErrCertificateUntrusted, got %v", err)
+	}
+	if errors.Is(err, ErrServerUnreachable) || retryable(err) {
+		t.Errorf("Expected a certificate failure not to be reported as unreachable or retried, got %v", err)
+	}
+}
+
+func TestGetOAuthToken_InsecureAcceptsUntrustedCertificate(t *testing.T) {
+	server, _ := newTLSTokenServer(t)
+
+	token, err := getOAuthToken(server.URL, "developer", "secret", TLSConfig{Insecure: true}, testPolicy(0))
+	if err != nil {
+		t.Fatalf("getOAuthToken() error = %v", err)
+	}
+	if token != "sha256~tls" {
+		t.Errorf("Expected the token from the TLS server, got %q", token)
+	}
+}
+
+func TestGetOAuthToken_CAFileTrustsCertificate(t *testing.T) {
+	server, caFile := newTLSTokenServer(t)
+
+	token, err := getOAuthToken(server.URL, "developer", "secret", TLSConfig{CAFile: caFile}, testPolicy(0))
+	if err != nil {
+		t.Fatalf("getOAuthToken() error = %v", err)
+	}
+	if token != "sha256~tls" {
+		t.Errorf("Expected the token from the TLS server, got %q", token)
+	}
+}
+
+func TestTryKubeconfig_VerifiesWithKubeconfigCA(t *testing.T) {
+	kubeconfig := filepath.Join(t.TempDir(), "config")
+	contents := `apiVersion: v1
+kind: Config
+clusters:
+- name: cluster
+  cluster:
+    server: https://api.example.com:6443
+    certificate-authority-data: ` + "Y2EtZGF0YQ==" + `
+contexts:
+- name: ctx
+  context:
+    cluster: cluster
+    user: user
+current-context: ctx
+users:
+- name: user
+  user:
+    token: sha256~kube
+`
+	if err := os.WriteFile(kubeconfig, []byte(contents), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	t.Setenv("KUBECONFIG", kubeconfig)
+
+	config, err := tryKubeconfig(TLSConfig{})
+	if err != nil {
+		t.Fatalf("tryKubeconfig() error = %v", err)
+	}
+	if config.TLSClientConfig.Insecure || string(config.TLSClientConfig.CAData) != "ca-data" {
+		t.Errorf("Expected verification with the kubeconfig CA by default, got %+v", config.TLSClientConfig)
+	}
+
+	config, err = tryKubeconfig(TLSConfig{Insecure: true})
+	if err != nil {
+		t.Fatalf("tryKubeconfig() error = %v", err)
+	}
+	if !config.TLSClientConfig.Insecure || len(config.TLSClientConfig.CAData) != 0 {
+		t.Errorf("Expected insecure mode without CA data, got %+v", config.TLSClientConfig)
+	}
+}
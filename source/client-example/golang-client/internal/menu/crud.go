@@ -10,6 +10,13 @@ import (
 // CRUDMenu represents a CRUD menu for a Kubernetes object
 type CRUDMenu struct {
 	ObjectType string
+	extras     []crudOption
+}
+
+// crudOption is an object-specific action shown after the standard CRUD actions
+type crudOption struct {
+	key         string
+	description string
 }
 
 // NewCRUDMenu creates a new CRUD menu
@@ -19,6 +26,11 @@ func NewCRUDMenu(objectType string) *CRUDMenu {
 	}
 }
 
+// AddOption adds an object-specific action, shown after the standard actions
+func (c *CRUDMenu) AddOption(key, description string) {
+	c.extras = append(c.extras, crudOption{key: key, description: description})
+}
+
 // Display shows the CRUD menu and returns the selected action
 func (c *CRUDMenu) Display() (string, error) {
 	fmt.Println("\n" + strings.Repeat("-", 50))
@@ -30,6 +42,9 @@ func (c *CRUDMenu) Display() (string, error) {
 	fmt.Println("4. Update")
 	fmt.Println("5. Delete")
 	fmt.Println("6. Add Annotation")
+	for _, opt := range c.extras {
+		fmt.Printf("%s. %s\n", opt.key, opt.description)
+	}
 	fmt.Println("B. Back to main menu")
 	fmt.Println(strings.Repeat("-", 50))
 	fmt.Print("Select an action: ")
@@ -45,6 +60,9 @@ func (c *CRUDMenu) Display() (string, error) {
 	validChoices := map[string]bool{
 		"1": true, "2": true, "3": true, "4": true, "5": true, "6": true, "B": true,
 	}
+	for _, opt := range c.extras {
+		validChoices[strings.ToUpper(opt.key)] = true
+	}
 
 	if !validChoices[choice] {
 		return "", fmt.Errorf("invalid option: %s", choice)
@@ -81,8 +99,27 @@ func GetName(prompt string) string {
 	return strings.TrimSpace(name)
 }
 
+// assumeYes auto-confirms GetConfirmation prompts when input is not a terminal
+var assumeYes bool
+
+// stdinIsTerminal reports whether input comes from an interactive terminal
+var stdinIsTerminal = func() bool {
+	info, err := os.Stdin.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// SetAssumeYes sets whether confirmations are answered yes without prompting when input is
+// scripted rather than typed at a terminal. Interactive sessions still prompt.
+func SetAssumeYes(yes bool) {
+	assumeYes = yes
+}
+
 // GetConfirmation prompts for yes/no confirmation
 func GetConfirmation(prompt string) bool {
+	if assumeYes && !stdinIsTerminal() {
+		fmt.Println(prompt + " (yes/no): yes (--yes)")
+		return true
+	}
 	fmt.Print(prompt + " (yes/no): ")
 	reader := bufio.NewReader(os.Stdin)
 	response, _ := reader.ReadString('\n')
@@ -0,0 +1,53 @@
+package menu
+
+import (
+	"os"
+	"testing"
+)
+
+// withStdin replaces os.Stdin with a pipe holding input, and stdinIsTerminal with terminal,
+// for the duration of the test
+func withStdin(t *testing.T, input string, terminal bool) {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("os.Pipe() error = %v", err)
+	}
+	if _, err := w.WriteString(input); err != nil {
+		t.Fatalf("WriteString() error = %v", err)
+	}
+	w.Close()
+
+	stdin, isTerminal := os.Stdin, stdinIsTerminal
+	os.Stdin = r
+	stdinIsTerminal = func() bool { return terminal }
+	t.Cleanup(func() {
+		os.Stdin, stdinIsTerminal = stdin, isTerminal
+		r.Close()
+		SetAssumeYes(false)
+	})
+}
+
+func TestGetConfirmation_AssumeYes(t *testing.T) {
+	tests := []struct {
+		name      string
+		assumeYes bool
+		terminal  bool
+		input     string
+		want      bool
+	}{
+		{name: "scripted input with --yes skips the prompt", assumeYes: true, input: "no\n", want: true},
+		{name: "terminal with --yes still prompts", assumeYes: true, terminal: true, input: "no\n", want: false},
+		{name: "scripted input without --yes reads the answer", input: "no\n", want: false},
+		{name: "scripted yes without --yes", input: "yes\n", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withStdin(t, tt.input, tt.terminal)
+			SetAssumeYes(tt.assumeYes)
+			if got := GetConfirmation("Delete?"); got != tt.want {
+				t.Errorf("GetConfirmation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package menu
+
+import (
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// pageLimit is the page size for list commands; 0 lists everything in one request
+var pageLimit int64
+
+// startToken is the continue token for the first page of the next list command
+var startToken string
+
+// SetPaging sets the page size for list commands and the continue token to resume the
+// first list from. The token is used once, since continue tokens belong to one listing.
+func SetPaging(limit int64, continueToken string) {
+	pageLimit = limit
+	startToken = continueToken
+}
+
+// ListPages calls listPage for each page of a list, passing the page's ListOptions.
+// listPage prints its page and returns the continue token for the next one. More pages are
+// offered with a prompt at a terminal. Scripted sessions stop after one page and print the
+// continue token to resume from.
+func ListPages(listPage func(opts metav1.ListOptions) (string, error)) error {
+	token := startToken
+	startToken = ""
+
+	for {
+		next, err := listPage(metav1.ListOptions{Limit: pageLimit, Continue: token})
+		if err != nil {
+			return err
+		}
+		if next == "" {
+			return nil
+		}
+
+		if !stdinIsTerminal() {
+			fmt.Println("More results available. Resume with:")
+			fmt.Printf("  --limit %d --continue %s\n\n", pageLimit, next)
+			return nil
+		}
+		answer := strings.ToLower(GetName("More results available. Show next page? (yes/no): "))
+		if answer != "yes" && answer != "y" {
+			return nil
+		}
+		token = next
+	}
+}
@@ -0,0 +1,57 @@
+package menu
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// pagedList returns a ListPages callback serving three pages and recording the options of each call
+func pagedList(calls *[]metav1.ListOptions) func(opts metav1.ListOptions) (string, error) {
+	next := map[string]string{"": "page-2", "page-2": "page-3", "page-3": ""}
+	return func(opts metav1.ListOptions) (string, error) {
+		*calls = append(*calls, opts)
+		return next[opts.Continue], nil
+	}
+}
+
+func TestListPages_Scripted(t *testing.T) {
+	withStdin(t, "", false)
+	SetPaging(2, "page-2")
+	t.Cleanup(func() { SetPaging(0, "") })
+
+	var calls []metav1.ListOptions
+	if err := ListPages(pagedList(&calls)); err != nil {
+		t.Fatalf("ListPages() error = %v", err)
+	}
+	want := []metav1.ListOptions{{Limit: 2, Continue: "page-2"}}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("Expected one page resumed from --continue, got %+v", calls)
+	}
+
+	// The --continue token only applies to the first listing
+	calls = nil
+	if err := ListPages(pagedList(&calls)); err != nil {
+		t.Fatalf("ListPages() error = %v", err)
+	}
+	want = []metav1.ListOptions{{Limit: 2}}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("Expected the second listing to start from the first page, got %+v", calls)
+	}
+}
+
+func TestListPages_InteractiveNextPage(t *testing.T) {
+	withStdin(t, "yes\n", true)
+	SetPaging(2, "page-2")
+	t.Cleanup(func() { SetPaging(0, "") })
+
+	var calls []metav1.ListOptions
+	if err := ListPages(pagedList(&calls)); err != nil {
+		t.Fatalf("ListPages() error = %v", err)
+	}
+	want := []metav1.ListOptions{{Limit: 2, Continue: "page-2"}, {Limit: 2, Continue: "page-3"}}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("Expected the next page after answering yes, got %+v", calls)
+	}
+}
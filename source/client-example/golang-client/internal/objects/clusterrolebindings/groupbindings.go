@@ -0,0 +1,96 @@
+package clusterrolebindings
+
+import (
+	"context"
+	"fmt"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// GroupBinding is a ClusterRoleBinding or RoleBinding whose subjects include a group
+type GroupBinding struct {
+	Kind      string // ClusterRoleBinding or RoleBinding
+	Namespace string // Empty for ClusterRoleBindings
+	Name      string
+	RoleKind  string // ClusterRole or Role
+	RoleName  string
+}
+
+// FindGroupBindings returns the ClusterRoleBindings and then the RoleBindings in every
+// namespace that grant a role to the named group
+func FindGroupBindings(clientset kubernetes.Interface, group string) ([]GroupBinding, error) {
+	ctx := context.Background()
+	var bindings []GroupBinding
+
+	clusterRoleBindings, err := clientset.RbacV1().ClusterRoleBindings().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing cluster role bindings: %w", err)
+	}
+	for _, crb := range clusterRoleBindings.Items {
+		if hasGroupSubject(crb.Subjects, group) {
+			bindings = append(bindings, GroupBinding{
+				Kind:     "ClusterRoleBinding",
+				Name:     crb.Name,
+				RoleKind: crb.RoleRef.Kind,
+				RoleName: crb.RoleRef.Name,
+			})
+		}
+	}
+
+	roleBindings, err := clientset.RbacV1().RoleBindings(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing role bindings: %w", err)
+	}
+	for _, rb := range roleBindings.Items {
+		if hasGroupSubject(rb.Subjects, group) {
+			bindings = append(bindings, GroupBinding{
+				Kind:      "RoleBinding",
+				Namespace: rb.Namespace,
+				Name:      rb.Name,
+				RoleKind:  rb.RoleRef.Kind,
+				RoleName:  rb.RoleRef.Name,
+			})
+		}
+	}
+
+	return bindings, nil
+}
+
+// hasGroupSubject reports whether subjects include the named group
+func hasGroupSubject(subjects []rbacv1.Subject, group string) bool {
+	for _, subject := range subjects {
+		if subject.Kind == rbacv1.GroupKind && subject.Name == group {
+			return true
+		}
+	}
+	return false
+}
+
+// HandleShowGroupRBAC prints the cluster role bindings and role bindings that grant roles to a group
+func HandleShowGroupRBAC(clientset *kubernetes.Clientset, group string) error {
+	bindings, err := FindGroupBindings(clientset, group)
+	if err != nil {
+		return err
+	}
+
+	if len(bindings) == 0 {
+		fmt.Printf("\nGroup '%s' is not bound to any roles.\n", group)
+		fmt.Println("Its members only have the access granted to all authenticated users and to them individually.")
+		fmt.Println()
+		return nil
+	}
+
+	fmt.Printf("\nFound %d binding(s) for group '%s':\n\n", len(bindings), group)
+	for i, binding := range bindings {
+		if binding.Namespace == "" {
+			fmt.Printf("%d. %s %s -> %s %s\n", i+1, binding.Kind, binding.Name, binding.RoleKind, binding.RoleName)
+		} else {
+			fmt.Printf("%d. %s %s/%s -> %s %s\n", i+1, binding.Kind, binding.Namespace, binding.Name, binding.RoleKind, binding.RoleName)
+		}
+	}
+	fmt.Println()
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package clusterrolebindings
+
+import (
+	"reflect"
+	"testing"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestFindGroupBindings(t *testing.T) {
+	premium := rbacv1.Subject{Kind: rbacv1.GroupKind, APIGroup: rbacv1.GroupName, Name: "premium-users"}
+	clientset := fake.NewSimpleClientset(
+		&rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "premium-view"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "alice"}, premium},
+			RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "view"},
+		},
+		&rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "other-admin"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "admins"}},
+			RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "admin"},
+		},
+		&rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "model-readers", Namespace: "llm"},
+			Subjects:   []rbacv1.Subject{premium},
+			RoleRef:    rbacv1.RoleRef{Kind: "Role", Name: "model-reader"},
+		},
+		&rbacv1.RoleBinding{
+			// A user named like the group is not the group
+			ObjectMeta: metav1.ObjectMeta{Name: "lookalike", Namespace: "llm"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "premium-users"}},
+			RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "edit"},
+		},
+	)
+
+	bindings, err := FindGroupBindings(clientset, "premium-users")
+	if err != nil {
+		t.Fatalf("FindGroupBindings() error = %v", err)
+	}
+	want := []GroupBinding{
+		{Kind: "ClusterRoleBinding", Name: "premium-view", RoleKind: "ClusterRole", RoleName: "view"},
+		{Kind: "RoleBinding", Namespace: "llm", Name: "model-readers", RoleKind: "Role", RoleName: "model-reader"},
+	}
+	if !reflect.DeepEqual(bindings, want) {
+		t.Errorf("Expected %+v, got %+v", want, bindings)
+	}
+
+	bindings, err = FindGroupBindings(clientset, "nobody")
+	if err != nil || len(bindings) != 0 {
+		t.Errorf("Expected no bindings for an unbound group, got %+v, %v", bindings, err)
+	}
+}
@@ -4,12 +4,14 @@ import (
 	"fmt"
 
 	"github.com/bryon/ocp-lister/internal/menu"
+	"github.com/bryon/ocp-lister/internal/objects/clusterrolebindings"
 	"k8s.io/client-go/kubernetes"
 )
 
 // HandleCRUDMenu handles the CRUD menu for groups
 func HandleCRUDMenu(clientset *kubernetes.Clientset) {
 	crudMenu := menu.NewCRUDMenu("Groups")
+	crudMenu.AddOption("7", "Show RBAC (role bindings for a group)")
 
 	for {
 		choice := crudMenu.DisplayAndGetChoice()
@@ -42,6 +44,16 @@ func HandleCRUDMenu(clientset *kubernetes.Clientset) {
 			}
 			fmt.Printf("Add annotation to group %s - Not yet implemented (requires OpenShift client)\n", name)
 
+		case "7": // Show RBAC
+			name := menu.GetName("Enter group name: ")
+			if name == "" {
+				fmt.Println("Group name cannot be empty")
+				continue
+			}
+			if err := clusterrolebindings.HandleShowGroupRBAC(clientset, name); err != nil {
+				fmt.Printf("Error: %v\n", err)
+			}
+
 		case "B": // Back
 			return
 		}
@@ -0,0 +1,80 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/yaml"
+)
+
+// dryRun prints the manifest a deploy would create instead of creating it
+var dryRun bool
+
+// SetDryRun sets whether deploys print their manifest instead of creating the model
+func SetDryRun(enabled bool) {
+	dryRun = enabled
+}
+
+// validateModel checks that model is a well-formed LLMInferenceService for getModelResource,
+// reporting every problem found
+func validateModel(model *unstructured.Unstructured) error {
+	var problems []string
+
+	if apiVersion := model.GetAPIVersion(); apiVersion != "serving.kserve.io/v1alpha1" {
+		problems = append(problems, fmt.Sprintf("apiVersion must be serving.kserve.io/v1alpha1, got %q", apiVersion))
+	}
+	if kind := model.GetKind(); kind != "LLMInferenceService" {
+		problems = append(problems, fmt.Sprintf("kind must be LLMInferenceService, got %q", kind))
+	}
+	for _, msg := range validation.IsDNS1123Label(model.GetName()) {
+		problems = append(problems, "metadata.name: "+msg)
+	}
+	for _, msg := range validation.IsDNS1123Label(model.GetNamespace()) {
+		problems = append(problems, "metadata.namespace: "+msg)
+	}
+	if _, err := modelTiers(model); err != nil {
+		problems = append(problems, fmt.Sprintf("metadata.annotations[%s]: %v", TiersAnnotation, err))
+	}
+	for _, field := range [][]string{{"spec", "model", "name"}, {"spec", "model", "uri"}} {
+		if value, _, _ := unstructured.NestedString(model.Object, field...); value == "" {
+			problems = append(problems, strings.Join(field, ".")+" is required")
+		}
+	}
+	if containers, _, _ := unstructured.NestedSlice(model.Object, "spec", "template", "containers"); len(containers) == 0 {
+		problems = append(problems, "spec.template.containers must have at least one container")
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid model manifest:\n  - %s", strings.Join(problems, "\n  - "))
+	}
+	return nil
+}
+
+// renderDryRun validates the model that would be deployed with the specified name and namespace
+// and returns its manifest as YAML
+func renderDryRun(name, namespace string) ([]byte, error) {
+	model := newModel(name, namespace)
+	if err := validateModel(model); err != nil {
+		return nil, err
+	}
+	manifest, err := yaml.Marshal(model.Object)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling model to YAML: %w", err)
+	}
+	return manifest, nil
+}
+
+// printDryRun prints the manifest that deploying the model would create, without creating it
+func printDryRun(name, namespace string) error {
+	manifest, err := renderDryRun(name, namespace)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("\nDry run: the following manifest would be created (nothing was deployed)")
+	fmt.Println("\n" + string(manifest))
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"sigs.k8s.io/yaml"
+)
+
+func TestRenderDryRun(t *testing.T) {
+	manifest, err := renderDryRun("my-model", "llm")
+	if err != nil {
+		t.Fatalf("renderDryRun() error = %v", err)
+	}
+
+	var rendered map[string]interface{}
+	if err := yaml.Unmarshal(manifest, &rendered); err != nil {
+		t.Fatalf("Expected YAML output, got %v:\n%s", err, manifest)
+	}
+	metadata, _ := rendered["metadata"].(map[string]interface{})
+	if rendered["kind"] != "LLMInferenceService" || metadata["name"] != "my-model" || metadata["namespace"] != "llm" {
+		t.Errorf("Expected the rendered LLMInferenceService llm/my-model, got:\n%s", manifest)
+	}
+}
+
+func TestValidateModel(t *testing.T) {
+	if err := validateModel(newModel("my-model", "llm")); err != nil {
+		t.Errorf("Expected the deploy manifest to be valid, got %v", err)
+	}
+
+	model := newModel("My_Model", "llm")
+	model.SetKind("InferenceService")
+	model.SetAnnotations(map[string]string{TiersAnnotation: "premium"})
+	delete(model.Object["spec"].(map[string]interface{})["model"].(map[string]interface{}), "uri")
+
+	err := validateModel(model)
+	if err == nil {
+		t.Fatal("Expected an invalid manifest to be rejected")
+	}
+	for _, want := range []string{"kind", "metadata.name", TiersAnnotation, "spec.model.uri"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected the error to report %s, got %v", want, err)
+		}
+	}
+}
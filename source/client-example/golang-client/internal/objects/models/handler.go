@@ -14,6 +14,8 @@ func HandleModelMenu(clientset *kubernetes.Clientset) {
 	modelMenu.AddOption("2", "Undeploy")
 	modelMenu.AddOption("3", "List")
 	modelMenu.AddOption("4", "Get")
+	modelMenu.AddOption("5", "List by tier")
+	modelMenu.AddOption("6", "Annotate with tier")
 	modelMenu.AddOption("B", "Back to main menu")
 
 	for {
@@ -78,6 +80,39 @@ func HandleModelMenu(clientset *kubernetes.Clientset) {
 				fmt.Printf("Error: %v\n", err)
 			}
 
+		case "5": // List by tier
+			tier := menu.GetName("Enter tier name: ")
+			if tier == "" {
+				fmt.Println("Tier name cannot be empty")
+				continue
+			}
+			namespace := menu.GetName("Enter namespace (or press Enter for 'llm'): ")
+			if namespace == "" {
+				namespace = "llm"
+			}
+			if err := HandleListByTier(clientset, namespace, tier); err != nil {
+				fmt.Printf("Error: %v\n", err)
+			}
+
+		case "6": // Annotate with tier
+			name := menu.GetName("Enter model name to annotate: ")
+			if name == "" {
+				fmt.Println("Model name cannot be empty")
+				continue
+			}
+			namespace := menu.GetName("Enter namespace (or press Enter for 'llm'): ")
+			if namespace == "" {
+				namespace = "llm"
+			}
+			tier := menu.GetName("Enter tier name to add: ")
+			if tier == "" {
+				fmt.Println("Tier name cannot be empty")
+				continue
+			}
+			if err := HandleAnnotateWithTier(clientset, name, namespace, tier); err != nil {
+				fmt.Printf("Error: %v\n", err)
+			}
+
 		case "B": // Back
 			return
 		}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/bryon/ocp-lister/internal/auth"
 	"github.com/bryon/ocp-lister/internal/client"
+	"github.com/bryon/ocp-lister/internal/menu"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -23,7 +24,7 @@ func getModelClient(clientset *kubernetes.Clientset) (dynamic.Interface, error)
 	}
 
 	// Get REST config
-	config, err := client.GetRESTConfig(authConfig.Server, authConfig.Username, authConfig.Password)
+	config, err := client.GetRESTConfig(authConfig.Server, authConfig.Username, authConfig.Password, authConfig.OAuthRetries, authConfig.TLSConfig())
 	if err != nil {
 		return nil, fmt.Errorf("failed to get REST config: %w", err)
 	}
@@ -46,36 +47,16 @@ func getModelResource() schema.GroupVersionResource {
 	}
 }
 
-// HandleDeploy deploys an LLMInferenceService with the specified name and namespace
+// newModel returns the LLMInferenceService to deploy with the specified name and namespace
 // All other fields are set exactly as in the GitHub example
-func HandleDeploy(clientset *kubernetes.Clientset, name, namespace string) error {
-	ctx := context.Background()
-
-	// Check if namespace exists
-	_, err := clientset.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
-	if err != nil {
-		return fmt.Errorf("namespace '%s' does not exist: %w", namespace, err)
-	}
-
-	dynamicClient, err := getModelClient(clientset)
-	if err != nil {
-		return err
-	}
-
-	// Check if model already exists
-	_, err = dynamicClient.Resource(getModelResource()).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
-	if err == nil {
-		return fmt.Errorf("model '%s' already exists in namespace '%s'", name, namespace)
-	}
-
-	// Create the LLMInferenceService object exactly as in the GitHub example
-	model := &unstructured.Unstructured{
+func newModel(name, namespace string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
 		Object: map[string]interface{}{
 			"apiVersion": "serving.kserve.io/v1alpha1",
 			"kind":       "LLMInferenceService",
 			"metadata": map[string]interface{}{
 				"annotations": map[string]interface{}{
-					"alpha.maas.opendatahub.io/tiers": `["redhat-users-tier"]`,
+					TiersAnnotation: `["redhat-users-tier"]`,
 				},
 				"name":      name,
 				"namespace": namespace,
@@ -165,6 +146,38 @@ func HandleDeploy(clientset *kubernetes.Clientset, name, namespace string) error
 			},
 		},
 	}
+}
+
+// HandleDeploy deploys an LLMInferenceService with the specified name and namespace.
+// With dry run enabled it prints the manifest instead, without contacting the cluster.
+func HandleDeploy(clientset *kubernetes.Clientset, name, namespace string) error {
+	if dryRun {
+		return printDryRun(name, namespace)
+	}
+
+	ctx := context.Background()
+
+	// Check if namespace exists
+	_, err := clientset.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("namespace '%s' does not exist: %w", namespace, err)
+	}
+
+	dynamicClient, err := getModelClient(clientset)
+	if err != nil {
+		return err
+	}
+
+	// Check if model already exists
+	_, err = dynamicClient.Resource(getModelResource()).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err == nil {
+		return fmt.Errorf("model '%s' already exists in namespace '%s'", name, namespace)
+	}
+
+	model := newModel(name, namespace)
+	if err := validateModel(model); err != nil {
+		return err
+	}
 
 	// Create the model
 	created, err := dynamicClient.Resource(getModelResource()).Namespace(namespace).Create(ctx, model, metav1.CreateOptions{})
@@ -217,7 +230,7 @@ func HandleUndeploy(clientset *kubernetes.Clientset, name, namespace string) err
 	return nil
 }
 
-// HandleList lists all LLMInferenceService models in the specified namespace
+// HandleList lists the LLMInferenceService models in the specified namespace, a page at a time
 func HandleList(clientset *kubernetes.Clientset, namespace string) error {
 	ctx := context.Background()
 
@@ -226,26 +239,28 @@ func HandleList(clientset *kubernetes.Clientset, namespace string) error {
 		return err
 	}
 
-	// List models in specified namespace
-	modelList, err := dynamicClient.Resource(getModelResource()).Namespace(namespace).List(ctx, metav1.ListOptions{})
-	if err != nil {
-		return fmt.Errorf("failed to list models: %w", err)
-	}
-
-	if len(modelList.Items) == 0 {
-		fmt.Printf("\nNo models found in namespace '%s'.\n", namespace)
+	return menu.ListPages(func(opts metav1.ListOptions) (string, error) {
+		// List models in specified namespace
+		modelList, err := dynamicClient.Resource(getModelResource()).Namespace(namespace).List(ctx, opts)
+		if err != nil {
+			return "", fmt.Errorf("failed to list models: %w", err)
+		}
+
+		if len(modelList.Items) == 0 {
+			fmt.Printf("\nNo models found in namespace '%s'.\n", namespace)
+			fmt.Println()
+			return modelList.GetContinue(), nil
+		}
+
+		fmt.Printf("\nFound %d model(s) in namespace '%s':\n\n", len(modelList.Items), namespace)
+		for i := range modelList.Items {
+			tiers, err := modelTiers(&modelList.Items[i])
+			fmt.Printf("%d. %s (%s)\n", i+1, modelList.Items[i].GetName(), formatTierList(tiers, err))
+		}
 		fmt.Println()
-		return nil
-	}
-
-	fmt.Printf("\nFound %d model(s) in namespace '%s':\n\n", len(modelList.Items), namespace)
-	for i, model := range modelList.Items {
-		name, _, _ := unstructured.NestedString(model.Object, "metadata", "name")
-		fmt.Printf("%d. %s\n", i+1, name)
-	}
-	fmt.Println()
 
-	return nil
+		return modelList.GetContinue(), nil
+	})
 }
 
 // HandleGet retrieves and displays a specific model as JSON
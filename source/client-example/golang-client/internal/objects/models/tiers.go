@@ -0,0 +1,175 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+// TiersAnnotation is the annotation listing the tiers that can access a model, as a JSON array
+const TiersAnnotation = "alpha.maas.opendatahub.io/tiers"
+
+// ParseTiersFromAnnotation parses the tiers annotation value (JSON array string) into a slice of tier names
+func ParseTiersFromAnnotation(annotationValue string) ([]string, error) {
+	if annotationValue == "" {
+		return []string{}, nil
+	}
+
+	var tiers []string
+	if err := json.Unmarshal([]byte(annotationValue), &tiers); err != nil {
+		return nil, fmt.Errorf("failed to parse tiers annotation: %w", err)
+	}
+
+	return tiers, nil
+}
+
+// FormatTiersAnnotation formats a slice of tier names as the tiers annotation value (JSON array string)
+func FormatTiersAnnotation(tiers []string) (string, error) {
+	if tiers == nil {
+		tiers = []string{}
+	}
+	data, err := json.Marshal(tiers)
+	if err != nil {
+		return "", fmt.Errorf("failed to format tiers annotation: %w", err)
+	}
+	return string(data), nil
+}
+
+// modelTiers returns the tiers in a model's tiers annotation
+func modelTiers(model *unstructured.Unstructured) ([]string, error) {
+	return ParseTiersFromAnnotation(model.GetAnnotations()[TiersAnnotation])
+}
+
+// formatTierList describes tiers for display next to a model name
+func formatTierList(tiers []string, err error) string {
+	if err != nil {
+		return "tiers: invalid annotation"
+	}
+	if len(tiers) == 0 {
+		return "tiers: none"
+	}
+	return "tiers: " + strings.Join(tiers, ", ")
+}
+
+// containsTier reports whether tiers includes tier
+func containsTier(tiers []string, tier string) bool {
+	for _, t := range tiers {
+		if t == tier {
+			return true
+		}
+	}
+	return false
+}
+
+// listModelsByTier returns the models in namespace whose tiers annotation includes tier.
+// Models with a malformed annotation are skipped.
+func listModelsByTier(dynamicClient dynamic.Interface, namespace, tier string) ([]unstructured.Unstructured, error) {
+	modelList, err := dynamicClient.Resource(getModelResource()).Namespace(namespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list models: %w", err)
+	}
+
+	var matches []unstructured.Unstructured
+	for i := range modelList.Items {
+		tiers, err := modelTiers(&modelList.Items[i])
+		if err == nil && containsTier(tiers, tier) {
+			matches = append(matches, modelList.Items[i])
+		}
+	}
+	return matches, nil
+}
+
+// annotateModelWithTier adds tier to a model's tiers annotation and returns the resulting tiers.
+// added is false when the model already had the tier, in which case the model is not updated.
+func annotateModelWithTier(dynamicClient dynamic.Interface, name, namespace, tier string) (tiers []string, added bool, err error) {
+	ctx := context.Background()
+	models := dynamicClient.Resource(getModelResource()).Namespace(namespace)
+
+	model, err := models.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, false, fmt.Errorf("error getting model: %w", err)
+	}
+
+	tiers, err = modelTiers(model)
+	if err != nil {
+		return nil, false, fmt.Errorf("model '%s' has an invalid %s annotation: %w", name, TiersAnnotation, err)
+	}
+	if containsTier(tiers, tier) {
+		return tiers, false, nil
+	}
+
+	tiers = append(tiers, tier)
+	value, err := FormatTiersAnnotation(tiers)
+	if err != nil {
+		return nil, false, err
+	}
+	annotations := model.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[TiersAnnotation] = value
+	model.SetAnnotations(annotations)
+
+	if _, err := models.Update(ctx, model, metav1.UpdateOptions{}); err != nil {
+		return nil, false, fmt.Errorf("error updating model with tier annotation: %w", err)
+	}
+	return tiers, true, nil
+}
+
+// HandleListByTier lists the models in the specified namespace that are annotated with tier
+func HandleListByTier(clientset *kubernetes.Clientset, namespace, tier string) error {
+	dynamicClient, err := getModelClient(clientset)
+	if err != nil {
+		return err
+	}
+
+	matches, err := listModelsByTier(dynamicClient, namespace, tier)
+	if err != nil {
+		return err
+	}
+
+	if len(matches) == 0 {
+		fmt.Printf("\nNo models with tier '%s' found in namespace '%s'.\n", tier, namespace)
+		fmt.Println()
+		return nil
+	}
+
+	fmt.Printf("\nFound %d model(s) with tier '%s' in namespace '%s':\n\n", len(matches), tier, namespace)
+	for i := range matches {
+		tiers, err := modelTiers(&matches[i])
+		fmt.Printf("%d. %s (%s)\n", i+1, matches[i].GetName(), formatTierList(tiers, err))
+	}
+	fmt.Println()
+
+	return nil
+}
+
+// HandleAnnotateWithTier adds tier to the tiers annotation of the specified model
+func HandleAnnotateWithTier(clientset *kubernetes.Clientset, name, namespace, tier string) error {
+	dynamicClient, err := getModelClient(clientset)
+	if err != nil {
+		return err
+	}
+
+	tiers, added, err := annotateModelWithTier(dynamicClient, name, namespace, tier)
+	if err != nil {
+		return err
+	}
+
+	if !added {
+		fmt.Printf("\nModel '%s' already has tier '%s'.\n", name, tier)
+	} else {
+		fmt.Printf("\n✓ Successfully added tier '%s' to model: %s\n", tier, name)
+	}
+	fmt.Printf("  Namespace: %s\n", namespace)
+	fmt.Printf("  %s\n", formatTierList(tiers, nil))
+	fmt.Println()
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package models
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+// newTestModel returns an LLMInferenceService with the given tiers annotation, or none when empty
+func newTestModel(namespace, name, tiersAnnotation string) *unstructured.Unstructured {
+	model := &unstructured.Unstructured{}
+	model.SetAPIVersion("serving.kserve.io/v1alpha1")
+	model.SetKind("LLMInferenceService")
+	model.SetNamespace(namespace)
+	model.SetName(name)
+	if tiersAnnotation != "" {
+		model.SetAnnotations(map[string]string{TiersAnnotation: tiersAnnotation})
+	}
+	return model
+}
+
+// newTestModelClient returns a fake dynamic client serving objects as LLMInferenceServices
+func newTestModelClient(objects ...runtime.Object) *dynamicfake.FakeDynamicClient {
+	return dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{getModelResource(): "LLMInferenceServiceList"}, objects...)
+}
+
+func TestParseTiersFromAnnotation(t *testing.T) {
+	tiers, err := ParseTiersFromAnnotation(`["free","premium"]`)
+	if err != nil || !reflect.DeepEqual(tiers, []string{"free", "premium"}) {
+		t.Errorf("Expected [free premium], got %v, %v", tiers, err)
+	}
+	if tiers, err := ParseTiersFromAnnotation(""); err != nil || len(tiers) != 0 {
+		t.Errorf("Expected no tiers for an empty annotation, got %v, %v", tiers, err)
+	}
+	if _, err := ParseTiersFromAnnotation("premium"); err == nil {
+		t.Error("Expected an error for a value that is not a JSON array")
+	}
+}
+
+func TestListModelsByTier(t *testing.T) {
+	client := newTestModelClient(
+		newTestModel("llm", "premium-model", `["free","premium"]`),
+		newTestModel("llm", "free-model", `["free"]`),
+		newTestModel("llm", "unannotated", ""),
+		newTestModel("llm", "malformed", "premium"),
+		newTestModel("other", "elsewhere", `["premium"]`),
+	)
+
+	matches, err := listModelsByTier(client, "llm", "premium")
+	if err != nil {
+		t.Fatalf("listModelsByTier() error = %v", err)
+	}
+	if len(matches) != 1 || matches[0].GetName() != "premium-model" {
+		t.Errorf("Expected only premium-model, got %v", matches)
+	}
+}
+
+func TestAnnotateModelWithTier(t *testing.T) {
+	client := newTestModelClient(
+		newTestModel("llm", "model", `["free"]`),
+		newTestModel("llm", "unannotated", ""),
+	)
+
+	tiers, added, err := annotateModelWithTier(client, "model", "llm", "premium")
+	if err != nil || !added || !reflect.DeepEqual(tiers, []string{"free", "premium"}) {
+		t.Fatalf("Expected premium to be added after free, got %v, %v, %v", tiers, added, err)
+	}
+	stored, err := client.Resource(getModelResource()).Namespace("llm").Get(context.Background(), "model", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got := stored.GetAnnotations()[TiersAnnotation]; got != `["free","premium"]` {
+		t.Errorf("Expected the stored annotation to include premium, got %s", got)
+	}
+
+	if _, added, err := annotateModelWithTier(client, "model", "llm", "premium"); err != nil || added {
+		t.Errorf("Expected an existing tier not to be added again, got %v, %v", added, err)
+	}
+
+	tiers, added, err = annotateModelWithTier(client, "unannotated", "llm", "free")
+	if err != nil || !added || !reflect.DeepEqual(tiers, []string{"free"}) {
+		t.Errorf("Expected the annotation to be created, got %v, %v, %v", tiers, added, err)
+	}
+}
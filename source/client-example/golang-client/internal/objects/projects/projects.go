@@ -6,19 +6,21 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/bryon/ocp-lister/internal/menu"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 )
 
-// ListProjects retrieves and returns a list of all projects (namespaces) the user has access to
-func ListProjects(clientset *kubernetes.Clientset) ([]string, error) {
+// ListProjects retrieves a page of the projects (namespaces) the user has access to.
+// opts.Limit and opts.Continue select the page, and the returned continue token is empty on the last page.
+func ListProjects(clientset kubernetes.Interface, opts metav1.ListOptions) ([]string, string, error) {
 	ctx := context.Background()
 
 	// List all namespaces (in OpenShift, projects are namespaces)
-	namespaces, err := clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	namespaces, err := clientset.CoreV1().Namespaces().List(ctx, opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list namespaces: %w", err)
+		return nil, "", fmt.Errorf("failed to list namespaces: %w", err)
 	}
 
 	// Extract namespace names
@@ -27,7 +29,7 @@ func ListProjects(clientset *kubernetes.Clientset) ([]string, error) {
 		projects = append(projects, ns.Name)
 	}
 
-	return projects, nil
+	return projects, namespaces.Continue, nil
 }
 
 // PrintProjects prints the list of projects to stdout
@@ -46,12 +48,14 @@ func PrintProjects(projects []string) {
 
 // HandleList handles the list action for projects
 func HandleList(clientset *kubernetes.Clientset) error {
-	projectList, err := ListProjects(clientset)
-	if err != nil {
-		return fmt.Errorf("error listing projects: %w", err)
-	}
-	PrintProjects(projectList)
-	return nil
+	return menu.ListPages(func(opts metav1.ListOptions) (string, error) {
+		projectList, next, err := ListProjects(clientset, opts)
+		if err != nil {
+			return "", fmt.Errorf("error listing projects: %w", err)
+		}
+		PrintProjects(projectList)
+		return next, nil
+	})
 }
 
 // HandleGet handles the get action for a specific project
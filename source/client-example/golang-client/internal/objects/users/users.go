@@ -7,6 +7,7 @@ import (
 
 	"github.com/bryon/ocp-lister/internal/auth"
 	"github.com/bryon/ocp-lister/internal/client"
+	"github.com/bryon/ocp-lister/internal/menu"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -23,7 +24,7 @@ func getUserClient(clientset *kubernetes.Clientset) (dynamic.Interface, error) {
 	}
 
 	// Get REST config
-	config, err := client.GetRESTConfig(authConfig.Server, authConfig.Username, authConfig.Password)
+	config, err := client.GetRESTConfig(authConfig.Server, authConfig.Username, authConfig.Password, authConfig.OAuthRetries, authConfig.TLSConfig())
 	if err != nil {
 		return nil, fmt.Errorf("failed to get REST config: %w", err)
 	}
@@ -46,19 +47,15 @@ func getUserResource() schema.GroupVersionResource {
 	}
 }
 
-// ListUsers retrieves and returns a list of all users
-func ListUsers(clientset *kubernetes.Clientset) ([]string, error) {
+// ListUsers retrieves a page of users. opts.Limit and opts.Continue select the page, and the
+// returned continue token is empty on the last page.
+func ListUsers(dynamicClient dynamic.Interface, opts metav1.ListOptions) ([]string, string, error) {
 	ctx := context.Background()
 
-	dynamicClient, err := getUserClient(clientset)
-	if err != nil {
-		return nil, err
-	}
-
 	// List users
-	userList, err := dynamicClient.Resource(getUserResource()).List(ctx, metav1.ListOptions{})
+	userList, err := dynamicClient.Resource(getUserResource()).List(ctx, opts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list users: %w", err)
+		return nil, "", fmt.Errorf("failed to list users: %w", err)
 	}
 
 	// Extract user names
@@ -69,7 +66,7 @@ func ListUsers(clientset *kubernetes.Clientset) ([]string, error) {
 		}
 	}
 
-	return users, nil
+	return users, userList.GetContinue(), nil
 }
 
 // PrintUsers prints the list of users to stdout
@@ -88,12 +85,19 @@ func PrintUsers(users []string) {
 
 // HandleList handles the list action for users
 func HandleList(clientset *kubernetes.Clientset) error {
-	userList, err := ListUsers(clientset)
+	dynamicClient, err := getUserClient(clientset)
 	if err != nil {
-		return fmt.Errorf("error listing users: %w", err)
+		return err
 	}
-	PrintUsers(userList)
-	return nil
+
+	return menu.ListPages(func(opts metav1.ListOptions) (string, error) {
+		userList, next, err := ListUsers(dynamicClient, opts)
+		if err != nil {
+			return "", fmt.Errorf("error listing users: %w", err)
+		}
+		PrintUsers(userList)
+		return next, nil
+	})
 }
 
 // HandleGet handles the get action for a specific user
@@ -15,16 +15,86 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"log"
 	"maas-toolbox/docs"
 	"maas-toolbox/internal/api"
+	"maas-toolbox/internal/models"
 	"maas-toolbox/internal/service"
 	"maas-toolbox/internal/storage"
+	"maas-toolbox/internal/webhook"
+	"net"
+	"net/http"
 	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
 )
 
+// getEnvBool returns the boolean value of an environment variable, or fallback when unset or invalid
+func getEnvBool(key string, fallback bool) bool {
+	value, err := strconv.ParseBool(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+// getEnvInt returns the integer value of an environment variable, or fallback when unset or invalid
+func getEnvInt(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+// splitList splits a comma-separated list, trimming whitespace and dropping empty entries
+func splitList(value string) []string {
+	items := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// getEnvString returns the value of an environment variable, or fallback when unset or empty
+func getEnvString(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// listenAddress joins host and port into a listen address, rejecting an invalid port or a host
+// that does not resolve. An empty host listens on all interfaces.
+func listenAddress(host, port string) (string, error) {
+	if number, err := strconv.Atoi(port); err != nil || number < 1 || number > 65535 {
+		return "", fmt.Errorf("invalid port %q: must be a number between 1 and 65535", port)
+	}
+	addr := net.JoinHostPort(host, port)
+	if _, err := net.ResolveTCPAddr("tcp", addr); err != nil {
+		return "", fmt.Errorf("invalid listen address %s: %w", addr, err)
+	}
+	return addr, nil
+}
+
+// getEnvDuration returns the duration value of an environment variable, or fallback when unset or invalid
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
 // @title           Open Data Hub MaaS Toolbox API
 // @version         1.0
 // @description     REST API service for managing tier-to-group mappings in the Open Data Hub Model as a Service (MaaS) project.
@@ -46,7 +116,7 @@ func init() {
 	docs.SwaggerInfo.Title = "Open Data Hub Maas Toolbox API"
 	docs.SwaggerInfo.Version = "1.0"
 	docs.SwaggerInfo.Description = "REST API service for managing tier-to-group mappings in the Open Data Hub Model as a Service (MaaS) project."
-	docs.SwaggerInfo.BasePath = "/api/v1"
+	docs.SwaggerInfo.BasePath = api.DefaultBasePath
 	docs.SwaggerInfo.Schemes = []string{"http", "https"}
 
 	// Set Swagger host from environment variable or default to localhost
@@ -76,9 +146,16 @@ func init() {
 
 func main() {
 	// Command line flags
-	port := flag.String("port", "8080", "Port to run the server on")
+	host := flag.String("host", getEnvString("HOST", ""), "Interface address to bind, e.g. 127.0.0.1 (default: all interfaces)")
+	port := flag.String("port", getEnvString("PORT", "8080"), "Port to run the server on")
+	readOnly := flag.Bool("read-only", getEnvBool("READ_ONLY", false), "Skip the ConfigMap write-access check for read-only deployments")
 	flag.Parse()
 
+	addr, err := listenAddress(*host, *port)
+	if err != nil {
+		log.Fatalf("Invalid --host/--port: %v", err)
+	}
+
 	// Get environment variables for Kubernetes configuration
 	namespace := os.Getenv("NAMESPACE")
 	if namespace == "" {
@@ -89,31 +166,275 @@ func main() {
 	if configMapName == "" {
 		configMapName = "tier-to-group-mapping"
 	}
+	if err := storage.ValidateConfigMapRef(namespace, configMapName); err != nil {
+		log.Fatalf("Invalid NAMESPACE or CONFIGMAP_NAME: %v", err)
+	}
 
-	// Initialize Kubernetes client
-	k8sClient, err := storage.NewKubernetesClient()
+	// Initialize Kubernetes clients
+	k8sClient, dynamicClient, err := storage.NewKubernetesClients()
 	if err != nil {
 		log.Fatalf("Failed to create Kubernetes client: %v", err)
 		os.Exit(1)
 	}
 
 	// Create Kubernetes storage
-	tierStorage := storage.NewK8sTierStorage(k8sClient, namespace, configMapName)
+	tierStorage := storage.NewK8sTierStorage(k8sClient, dynamicClient, namespace, configMapName)
 	log.Printf("Using Kubernetes ConfigMap storage")
 	log.Printf("Namespace: %s", namespace)
 	log.Printf("ConfigMap: %s", configMapName)
 
+	// Tier mutation counters and Go runtime metrics, served at /metrics
+	metricsRegistry := prometheus.NewRegistry()
+	metricsRegistry.MustRegister(collectors.NewGoCollector(), collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+
+	// Last successful ConfigMap load and save times, for staleness alerts
+	tierStorage.SyncMetrics = storage.NewSyncMetrics(metricsRegistry)
+
+	// Built-in groups accepted without an API lookup
+	if specialGroups := os.Getenv("SPECIAL_GROUPS"); specialGroups != "" {
+		tierStorage.SpecialGroups = splitList(specialGroups)
+	}
+	log.Printf("Special groups: %v", tierStorage.SpecialGroups)
+
+	// Group resource for clusters whose groups are not served by user.openshift.io
+	groupResource, err := storage.ParseGroupResource(
+		getEnvString("GROUP_API_GROUP", storage.DefaultGroupResource.Group),
+		getEnvString("GROUP_API_VERSION", storage.DefaultGroupResource.Version),
+		getEnvString("GROUP_API_RESOURCE", storage.DefaultGroupResource.Resource),
+	)
+	if err != nil {
+		log.Fatalf("Invalid GROUP_API_GROUP, GROUP_API_VERSION or GROUP_API_RESOURCE: %v", err)
+	}
+	tierStorage.GroupResource = groupResource
+	log.Printf("Group resource: %s", groupResource)
+	if err := tierStorage.CheckGroupsAPI(); err != nil {
+		log.Printf("WARNING: group resource %s is not discoverable, group lookups will fail: %v", groupResource, err)
+	}
+
+	// Compact encoding for large configurations; Load detects the encoding either way
+	tierStorage.Compress = getEnvBool("COMPRESS_TIERS", false)
+	log.Printf("Compress tiers in ConfigMap: %t", tierStorage.Compress)
+
+	// One data key per tier instead of the single tiers key; Load reads either layout
+	tierStorage.PerTierKeys = getEnvBool("PER_TIER_KEYS", false)
+	log.Printf("Store each tier in its own ConfigMap key: %t", tierStorage.PerTierKeys)
+	if getEnvBool("MIGRATE_TIERS_LAYOUT", false) {
+		if _, err := tierStorage.MigrateLayout(context.Background()); err != nil {
+			log.Fatalf("Failed to migrate the tier ConfigMap layout: %v", err)
+		}
+	}
+
+	// Liveness: report sustained ConfigMap API failures so the kubelet restarts a wedged client
+	var livenessChecks []api.LivenessCheck
+	livenessThreshold := getEnvInt("LIVENESS_FAILURE_THRESHOLD", storage.DefaultLivenessFailureThreshold)
+	livenessWindow := getEnvDuration("LIVENESS_FAILURE_WINDOW", storage.DefaultLivenessFailureWindow)
+	if livenessThreshold > 0 {
+		tierStorage.Liveness = storage.NewFailureTracker(livenessThreshold, livenessWindow)
+		livenessChecks = append(livenessChecks, api.LivenessCheck{Name: "kubernetes-client", Check: tierStorage.Liveness.Check})
+		log.Printf("Liveness fails after %d consecutive Kubernetes API failures over %s", livenessThreshold, livenessWindow)
+	} else {
+		log.Printf("Liveness failure tracking disabled")
+	}
+
+	// Informer-backed group existence checks, falling back to live Gets until synced
+	groupCache := getEnvBool("GROUP_CACHE", false)
+	if groupCache {
+		resync := getEnvDuration("GROUP_CACHE_RESYNC", storage.DefaultGroupCacheResync)
+		tierStorage.GroupCache = storage.NewGroupCache(dynamicClient, tierStorage.GroupResource, resync)
+		tierStorage.GroupCache.Start(make(chan struct{}))
+		log.Printf("Group cache enabled (resync %s)", resync)
+	} else {
+		log.Printf("Group cache disabled, group existence uses live Gets")
+	}
+
+	// Informer-backed tier loads, falling back to live Gets until synced
+	configMapCache := getEnvBool("CONFIGMAP_CACHE", false)
+	if configMapCache {
+		resync := getEnvDuration("CONFIGMAP_CACHE_RESYNC", storage.DefaultConfigMapCacheResync)
+		tierStorage.ConfigMapCache = storage.NewConfigMapCache(k8sClient, namespace, configMapName, resync)
+		tierStorage.ConfigMapCache.Start(make(chan struct{}))
+		log.Printf("ConfigMap cache enabled (resync %s)", resync)
+	} else {
+		log.Printf("ConfigMap cache disabled, tier loads use live Gets")
+	}
+
+	// Readiness checks reported by /readyz
+	// The OpenShift and KServe APIs only back the group, user and LLMInferenceService
+	// features, so their absence is reported without failing readiness
+	readinessChecks := []api.ReadinessCheck{
+		{Name: "configmap", Check: tierStorage.CheckConfigMapAccess},
+		{Name: "openshift-groups", Check: tierStorage.CheckGroupsAPI, Optional: true},
+		{Name: "openshift-users", Check: tierStorage.CheckUsersAPI, Optional: true},
+		{Name: "llminferenceservices", Check: tierStorage.CheckLLMInferenceServiceAPI, Optional: true},
+	}
+	if tierStorage.GroupCache != nil {
+		// Lookups fall back to live Gets while unsynced, so this never fails readiness
+		readinessChecks = append(readinessChecks, api.ReadinessCheck{Name: "group-cache", Check: tierStorage.GroupCache.Check, Optional: true})
+	}
+	if tierStorage.ConfigMapCache != nil {
+		// Loads fall back to live Gets while unsynced, so this never fails readiness
+		readinessChecks = append(readinessChecks, api.ReadinessCheck{Name: "configmap-cache", Check: tierStorage.ConfigMapCache.Check, Optional: true})
+	}
+
+	// Fail fast when write access is expected but denied
+	if *readOnly {
+		log.Printf("Read-only mode: skipping ConfigMap write-access check")
+	} else {
+		if err := tierStorage.CheckWriteAccess(); err != nil {
+			log.Fatalf("ConfigMap write-access check failed: %v", err)
+		}
+		log.Printf("ConfigMap write access confirmed")
+		readinessChecks = append(readinessChecks, api.ReadinessCheck{Name: "write-access", Check: tierStorage.CheckWriteAccess})
+	}
+
+	// Load the existing tiers once so a malformed hand-edit is caught before traffic arrives
+	startupLoadCheck := strings.ToLower(os.Getenv("STARTUP_LOAD_CHECK"))
+	switch startupLoadCheck {
+	case "":
+		startupLoadCheck = "warn"
+	case "warn", "fail", "off":
+	default:
+		log.Fatalf("Invalid STARTUP_LOAD_CHECK %q: must be one of warn, fail, off", startupLoadCheck)
+	}
+	if startupLoadCheck == "off" {
+		log.Printf("Startup load check disabled")
+	} else if config, err := tierStorage.Load(context.Background()); err != nil {
+		if startupLoadCheck == "fail" {
+			log.Fatalf("Startup load of ConfigMap %s/%s failed: %v", namespace, configMapName, err)
+		}
+		log.Printf("WARNING: ***** Startup load of ConfigMap %s/%s failed: %v. Requests will fail until the tiers YAML is fixed. *****", namespace, configMapName, err)
+	} else {
+		log.Printf("Startup load check passed: %d tiers parsed", len(config.Tiers))
+	}
+
+	// Group duplicate detection policy (exact or case-insensitive)
+	groupMatchPolicy, err := service.ParseGroupMatchPolicy(os.Getenv("GROUP_MATCH_POLICY"))
+	if err != nil {
+		log.Fatalf("Invalid GROUP_MATCH_POLICY: %v", err)
+	}
+	log.Printf("Group match policy: %s", groupMatchPolicy)
+
+	// Implicit system:authenticated matching during tier resolution
+	implicitAuthenticated := getEnvBool("IMPLICIT_AUTHENTICATED_MATCH", true)
+	log.Printf("Implicit system:authenticated matching: %t", implicitAuthenticated)
+
+	// Change notifications (disabled when WEBHOOK_URL is unset)
+	notifier := webhook.NewNotifier(os.Getenv("WEBHOOK_URL"), os.Getenv("WEBHOOK_SECRET"))
+	if notifier != nil {
+		log.Printf("Webhook notifications enabled (signed: %t)", os.Getenv("WEBHOOK_SECRET") != "")
+	}
+
+	// Optional strict ladder: reject tiers that share a level
+	uniqueLevels := getEnvBool("UNIQUE_TIER_LEVELS", false)
+	log.Printf("Unique tier levels enforced: %t", uniqueLevels)
+
+	// Soft-delete keeps deleted tiers restorable until purged
+	softDelete := getEnvBool("SOFT_DELETE", false)
+	softDeleteRetention := getEnvDuration("SOFT_DELETE_RETENTION", service.DefaultSoftDeleteRetention)
+	log.Printf("Soft delete: %t (retention %s)", softDelete, softDeleteRetention)
+
+	// Colons in group names are limited to system: and <namespace>:<name> forms unless opted out
+	allowGroupNameColons := getEnvBool("ALLOW_GROUP_NAME_COLONS", false)
+	models.SetAllowGroupNameColons(allowGroupNameColons)
+	log.Printf("Colons allowed anywhere in group names: %t", allowGroupNameColons)
+
+	// Optional minimum: reject tiers that would grant access to nobody
+	requireGroups := getEnvBool("REQUIRE_TIER_GROUPS", false)
+	log.Printf("Tier groups required: %t", requireGroups)
+
+	// Optional upper bound on tier levels; 0 sets no maximum
+	maxLevel := getEnvInt("MAX_TIER_LEVEL", 0)
+	log.Printf("Max tier level: %d", maxLevel)
+
+	// Cap on the number of stored tiers, so runaway automation cannot outgrow the ConfigMap; 0 sets no maximum
+	maxTiers := getEnvInt("MAX_TIERS", service.DefaultMaxTiers)
+	log.Printf("Max tiers: %d", maxTiers)
+
+	// Bound request bodies on mutating routes so a huge payload cannot exhaust memory
+	maxBodyBytes := getEnvInt("MAX_REQUEST_BODY_BYTES", int(api.DefaultMaxRequestBodyBytes))
+	log.Printf("Max request body size: %d bytes", maxBodyBytes)
+
+	// Cap on concurrent /tiers/stream connections
+	maxStreams := getEnvInt("MAX_TIER_STREAMS", service.DefaultMaxChangeSubscribers)
+	log.Printf("Max concurrent tier change streams: %d", maxStreams)
+
 	// Initialize service
-	tierService := service.NewTierService(tierStorage)
+	tierService := service.NewTierService(tierStorage,
+		service.WithMetrics(metricsRegistry),
+		service.WithGroupMatchPolicy(groupMatchPolicy),
+		service.WithImplicitAuthenticatedMatch(implicitAuthenticated),
+		service.WithNotifier(notifier),
+		service.WithMaxChangeSubscribers(maxStreams),
+		service.WithUniqueLevels(uniqueLevels),
+		service.WithMaxLevel(maxLevel),
+		service.WithMaxTiers(maxTiers),
+		service.WithRequireGroups(requireGroups),
+		service.WithSoftDelete(softDelete),
+		service.WithSoftDeleteRetention(softDeleteRetention),
+	)
+
+	// Namespace for annotate and remove-tier requests that omit one; unset keeps it required
+	defaultLLMNamespace := os.Getenv("DEFAULT_LLM_NAMESPACE")
+	if defaultLLMNamespace != "" {
+		log.Printf("Default LLMInferenceService namespace: %s", defaultLLMNamespace)
+	}
+
+	// Namespaces to scan for LLMInferenceServices; unset lists cluster-wide
+	llmStorage := storage.NewLLMInferenceServiceStorage(dynamicClient)
+	if namespaces := os.Getenv("LLM_SERVICE_NAMESPACES"); namespaces != "" {
+		llmStorage.Namespaces = splitList(namespaces)
+		llmStorage.ListConcurrency = getEnvInt("LLM_SERVICE_LIST_CONCURRENCY", storage.DefaultNamespaceListConcurrency)
+		log.Printf("Listing LLMInferenceServices in namespaces %v, %d at a time", llmStorage.Namespaces, llmStorage.ListConcurrency)
+	}
+
+	llmServiceService := service.NewLLMInferenceServiceService(tierService, llmStorage,
+		service.WithDefaultNamespace(defaultLLMNamespace),
+	)
 
 	// Setup router
-	router := api.SetupRouter(tierService)
+	// Prefix of the API routes, for mounting behind a gateway without rewrites
+	basePath := api.NormalizeBasePath(os.Getenv("API_BASE_PATH"))
+	log.Printf("API base path: %s", basePath)
+
+	// Swagger UI publishes the full API description; production deployments may turn it off
+	enableSwagger := getEnvBool("ENABLE_SWAGGER", true)
+	log.Printf("Swagger UI enabled: %t", enableSwagger)
+
+	// Creating LLMInferenceServices widens the toolbox's write access, so it is opt-in
+	enableLLMCreate := getEnvBool("ENABLE_LLM_SERVICE_CREATE", false)
+	log.Printf("LLMInferenceService creation enabled: %t", enableLLMCreate)
+
+	// Annotating a service with every tier is a testing convenience, so it is opt-in
+	enableAnnotateAll := getEnvBool("ENABLE_ANNOTATE_ALL_TIERS", false)
+	log.Printf("Annotate with all tiers enabled: %t", enableAnnotateAll)
+
+	router := api.SetupRouter(tierService, llmServiceService,
+		api.WithReadinessChecks(readinessChecks...),
+		api.WithLivenessChecks(livenessChecks...),
+		api.WithBasePath(basePath),
+		api.WithSwagger(enableSwagger),
+		api.WithLLMInferenceServiceCreate(enableLLMCreate),
+		api.WithAnnotateAllTiers(enableAnnotateAll),
+		api.WithMaxRequestBodyBytes(int64(maxBodyBytes)),
+		api.WithMetrics(metricsRegistry),
+	)
+
+	// Bound request latency when the Kubernetes API is slow; zero disables the timeout
+	requestTimeout := getEnvDuration("REQUEST_TIMEOUT", 0)
+	if requestTimeout > 0 {
+		log.Printf("Requests time out with 503 after %s", requestTimeout)
+	} else {
+		log.Printf("Request timeout disabled")
+	}
 
 	// Start server
-	addr := fmt.Sprintf(":%s", *port)
-	log.Printf("Starting server on %s", addr)
+	if *host == "" {
+		log.Printf("Starting server on %s (all interfaces)", addr)
+	} else {
+		log.Printf("Starting server on %s", addr)
+	}
 
-	if err := router.Run(addr); err != nil {
+	if err := http.ListenAndServe(addr, api.TimeoutHandler(router, requestTimeout)); err != nil {
 		log.Fatalf("Failed to start server: %v", err)
 		os.Exit(1)
 	}
@@ -0,0 +1,124 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"sigs.k8s.io/yaml"
+)
+
+// Report binding validation failures by JSON field name (group) rather than Go field name (Group)
+func init() {
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(jsonFieldName)
+	}
+}
+
+// jsonFieldName returns the name of field in its json tag, falling back to the Go name
+func jsonFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return field.Name
+	}
+	return name
+}
+
+// bindBody decodes the request body into obj and validates it. A YAML Content-Type is
+// converted to JSON first, so YAML bodies follow the json tags and JSON validation exactly.
+func bindBody(c *gin.Context, obj interface{}) error {
+	if !isYAMLContentType(c.ContentType()) {
+		return c.ShouldBindJSON(obj)
+	}
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return err
+	}
+	if len(strings.TrimSpace(string(body))) == 0 {
+		return io.EOF
+	}
+	data, err := yaml.YAMLToJSON(body)
+	if err != nil {
+		return fmt.Errorf("malformed YAML: %w", err)
+	}
+	return binding.JSON.BindBody(data, obj)
+}
+
+// isYAMLContentType reports whether contentType is one of yamlMediaTypes
+func isYAMLContentType(contentType string) bool {
+	contentType = strings.ToLower(contentType)
+	for _, mediaType := range yamlMediaTypes {
+		if contentType == mediaType {
+			return true
+		}
+	}
+	return false
+}
+
+// describeBindError explains why a request body could not be bound, as
+// "invalid request body: <reason>". For binding validation failures it also
+// returns the failing field when exactly one field failed, for ErrorResponse.Field.
+func describeBindError(err error) (message, field string) {
+	var (
+		syntaxErr     *json.SyntaxError
+		typeErr       *json.UnmarshalTypeError
+		validationErr validator.ValidationErrors
+	)
+	switch {
+	case errors.Is(err, io.EOF):
+		return "invalid request body: body is empty", ""
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return "invalid request body: malformed JSON: unexpected end of input", ""
+	case errors.As(err, &syntaxErr):
+		return fmt.Sprintf("invalid request body: malformed JSON at offset %d: %v", syntaxErr.Offset, syntaxErr), ""
+	case errors.As(err, &typeErr):
+		if typeErr.Field == "" {
+			return fmt.Sprintf("invalid request body: expected %s, got JSON %s", typeErr.Type, typeErr.Value), ""
+		}
+		return fmt.Sprintf("invalid request body: field %s must be %s, got JSON %s", typeErr.Field, typeErr.Type, typeErr.Value), typeErr.Field
+	case errors.As(err, &validationErr):
+		var missing, invalid []string
+		for _, fieldErr := range validationErr {
+			if fieldErr.Tag() == "required" {
+				missing = append(missing, fieldErr.Field())
+			} else {
+				invalid = append(invalid, fmt.Sprintf("%s (%s)", fieldErr.Field(), fieldErr.Tag()))
+			}
+		}
+		var reasons []string
+		if len(missing) > 0 {
+			reasons = append(reasons, "missing required fields: "+strings.Join(missing, ", "))
+		}
+		if len(invalid) > 0 {
+			reasons = append(reasons, "invalid fields: "+strings.Join(invalid, ", "))
+		}
+		if len(validationErr) == 1 {
+			field = validationErr[0].Field()
+		}
+		return "invalid request body: " + strings.Join(reasons, "; "), field
+	}
+	return "invalid request body: " + err.Error(), ""
+}
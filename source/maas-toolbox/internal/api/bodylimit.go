@@ -0,0 +1,60 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultMaxRequestBodyBytes is the default request body limit for mutating routes.
+// It matches the 1 MiB limit on ConfigMap data, so no accepted body can be too large to store.
+const DefaultMaxRequestBodyBytes int64 = 1 << 20
+
+// limitRequestBody caps the body of POST, PUT, PATCH and DELETE requests at limit bytes.
+// Requests declaring a larger Content-Length are rejected up front; others fail with 413
+// when a handler reads past the limit. A limit of zero or less disables the check.
+func limitRequestBody(limit int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if limit <= 0 || c.Request.Body == nil {
+			c.Next()
+			return
+		}
+		switch c.Request.Method {
+		case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		default:
+			c.Next()
+			return
+		}
+
+		if c.Request.ContentLength > limit {
+			respondBodyTooLarge(c, limit)
+			c.Abort()
+			return
+		}
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+		c.Next()
+	}
+}
+
+// respondBodyTooLarge writes a 413 ErrorResponse for a request body over limit bytes
+func respondBodyTooLarge(c *gin.Context, limit int64) {
+	c.JSON(http.StatusRequestEntityTooLarge, ErrorResponse{
+		Error: fmt.Sprintf("request body exceeds the %d byte limit", limit),
+		Code:  CodeRequestBodyTooLarge,
+	})
+}
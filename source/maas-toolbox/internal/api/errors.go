@@ -0,0 +1,215 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"errors"
+	"maas-toolbox/internal/models"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Stable, machine-readable error codes returned in ErrorResponse.Code.
+// Clients should switch on these rather than on the human-readable message.
+const (
+	CodeTierNameRequired         = "TIER_NAME_REQUIRED"
+	CodeTierDescriptionRequired  = "TIER_DESCRIPTION_REQUIRED"
+	CodeTierDescriptionTooLong   = "TIER_DESCRIPTION_TOO_LONG"
+	CodeTierLevelInvalid         = "TIER_LEVEL_INVALID"
+	CodeTierNotFound             = "TIER_NOT_FOUND"
+	CodeTierAlreadyExists        = "TIER_ALREADY_EXISTS"
+	CodeTierNameImmutable        = "TIER_NAME_IMMUTABLE"
+	CodeGroupRequired            = "GROUP_REQUIRED"
+	CodeGroupAlreadyExists       = "GROUP_ALREADY_EXISTS"
+	CodeGroupNotFound            = "GROUP_NOT_FOUND"
+	CodeGroupNotFoundInCluster   = "GROUP_NOT_FOUND_IN_CLUSTER"
+	CodeInvalidK8sName           = "INVALID_K8S_NAME"
+	CodeInvalidGroupColon        = "INVALID_GROUP_COLON"
+	CodeInvalidTierAnnotation    = "INVALID_TIER_ANNOTATION"
+	CodeNamespaceRequired        = "NAMESPACE_REQUIRED"
+	CodeLLMServiceNameRequired   = "LLM_SERVICE_NAME_REQUIRED"
+	CodeLLMServiceNotFound       = "LLM_SERVICE_NOT_FOUND"
+	CodeTargetsRequired          = "TARGETS_REQUIRED"
+	CodeTierNotInAnnotation      = "TIER_NOT_IN_ANNOTATION"
+	CodeInvalidUsername          = "INVALID_USERNAME"
+	CodeUserNotFound             = "USER_NOT_FOUND"
+	CodeGroupLookupDenied        = "GROUP_LOOKUP_DENIED"
+	CodeInvalidContinueToken     = "INVALID_CONTINUE_TOKEN"
+	CodeInvalidPatch             = "INVALID_PATCH"
+	CodeUnsupportedPatchType     = "UNSUPPORTED_PATCH_TYPE"
+	CodeTooManySubscribers       = "TOO_MANY_SUBSCRIBERS"
+	CodeTierLevelConflict        = "TIER_LEVEL_CONFLICT"
+	CodeTierNotDeleted           = "TIER_NOT_DELETED"
+	CodeTierSoftDeleted          = "TIER_SOFT_DELETED"
+	CodeInvalidGroupPattern      = "INVALID_GROUP_PATTERN"
+	CodeTierGroupsRequired       = "TIER_GROUPS_REQUIRED"
+	CodeConfirmationRequired     = "CONFIRMATION_REQUIRED"
+	CodeAnnotationKeyRequired    = "ANNOTATION_KEY_REQUIRED"
+	CodeInvalidAnnotationKey     = "INVALID_ANNOTATION_KEY"
+	CodeAnnotationKeysIdentical  = "ANNOTATION_KEYS_IDENTICAL"
+	CodeNamespaceNotFound        = "NAMESPACE_NOT_FOUND"
+	CodeTierLevelRequired        = "TIER_LEVEL_REQUIRED"
+	CodeTierLevelTooHigh         = "TIER_LEVEL_TOO_HIGH"
+	CodeConcurrentModification   = "CONCURRENT_MODIFICATION"
+	CodeModelURIRequired         = "MODEL_URI_REQUIRED"
+	CodeLLMServiceAlreadyExists  = "LLM_SERVICE_ALREADY_EXISTS"
+	CodeLLMNamespaceNotFound     = "LLM_NAMESPACE_NOT_FOUND"
+	CodeTierETagMismatch         = "TIER_ETAG_MISMATCH"
+	CodeTierLimitReached         = "TIER_LIMIT_REACHED"
+	CodeTierMetadataTooLarge     = "TIER_METADATA_TOO_LARGE"
+	CodeTierMetadataKeyRequired  = "TIER_METADATA_KEY_REQUIRED"
+	CodeTierMetadataKeyTooLong   = "TIER_METADATA_KEY_TOO_LONG"
+	CodeTierMetadataValueTooLong = "TIER_METADATA_VALUE_TOO_LONG"
+	CodeTierNamesRequired        = "TIER_NAMES_REQUIRED"
+	CodeTierNameReserved         = "TIER_NAME_RESERVED"
+	CodeInvalidRequestBody       = "INVALID_REQUEST_BODY"
+	CodeInvalidQueryParameter    = "INVALID_QUERY_PARAMETER"
+	CodeRequestBodyTooLarge      = "REQUEST_BODY_TOO_LARGE"
+	CodeRequestTimeout           = "REQUEST_TIMEOUT"
+	CodeRouteNotFound            = "ROUTE_NOT_FOUND"
+	CodeMethodNotAllowed         = "METHOD_NOT_ALLOWED"
+	CodeInternalError            = "INTERNAL_ERROR"
+)
+
+// ErrorResponse represents an error response
+type ErrorResponse struct {
+	Error     string `json:"error" example:"tier not found"`          // Human-readable error message
+	Code      string `json:"code" example:"TIER_NOT_FOUND"`           // Stable machine-readable error code
+	Field     string `json:"field,omitempty" example:"groups"`        // Request field that failed validation, if any
+	Value     string `json:"value,omitempty" example:"Invalid_Group"` // Offending value within Field, if any
+	Length    int    `json:"length,omitempty" example:"300"`          // Actual length in characters, for values that are too long
+	MaxLength int    `json:"maxLength,omitempty" example:"256"`       // Maximum length in characters, for values that are too long
+}
+
+// errorMapping associates a sentinel error with its error code and HTTP status
+type errorMapping struct {
+	err    error
+	code   string
+	status int
+}
+
+// errorMappings maps each sentinel error in models/errors.go to its code and HTTP status.
+// Errors are matched with errors.Is, so wrapped sentinels are classified correctly.
+// Values that break a validation rule are 422; 400 is kept for requests that cannot be parsed
+// (bodies, query parameters, patch documents and continue tokens) or are missing a confirmation.
+var errorMappings = []errorMapping{
+	{models.ErrTierNameRequired, CodeTierNameRequired, http.StatusUnprocessableEntity},
+	{models.ErrTierDescriptionRequired, CodeTierDescriptionRequired, http.StatusUnprocessableEntity},
+	{models.ErrTierDescriptionTooLong, CodeTierDescriptionTooLong, http.StatusUnprocessableEntity},
+	{models.ErrTierLevelInvalid, CodeTierLevelInvalid, http.StatusUnprocessableEntity},
+	{models.ErrTierNotFound, CodeTierNotFound, http.StatusNotFound},
+	{models.ErrTierAlreadyExists, CodeTierAlreadyExists, http.StatusConflict},
+	{models.ErrTierNameImmutable, CodeTierNameImmutable, http.StatusUnprocessableEntity},
+	{models.ErrGroupRequired, CodeGroupRequired, http.StatusUnprocessableEntity},
+	{models.ErrGroupAlreadyExists, CodeGroupAlreadyExists, http.StatusConflict},
+	{models.ErrGroupNotFound, CodeGroupNotFound, http.StatusNotFound},
+	{models.ErrGroupNotFoundInCluster, CodeGroupNotFoundInCluster, http.StatusUnprocessableEntity},
+	{models.ErrInvalidKubernetesName, CodeInvalidK8sName, http.StatusUnprocessableEntity},
+	{models.ErrInvalidGroupColon, CodeInvalidGroupColon, http.StatusUnprocessableEntity},
+	{models.ErrInvalidTierAnnotation, CodeInvalidTierAnnotation, http.StatusConflict},
+	{models.ErrNamespaceRequired, CodeNamespaceRequired, http.StatusUnprocessableEntity},
+	{models.ErrLLMServiceNameRequired, CodeLLMServiceNameRequired, http.StatusUnprocessableEntity},
+	{models.ErrLLMServiceNotFound, CodeLLMServiceNotFound, http.StatusNotFound},
+	{models.ErrTargetsRequired, CodeTargetsRequired, http.StatusUnprocessableEntity},
+	{models.ErrTierNotInAnnotation, CodeTierNotInAnnotation, http.StatusNotFound},
+	{models.ErrInvalidUsername, CodeInvalidUsername, http.StatusUnprocessableEntity},
+	{models.ErrUserNotFound, CodeUserNotFound, http.StatusNotFound},
+	{models.ErrGroupLookupDenied, CodeGroupLookupDenied, http.StatusForbidden},
+	{models.ErrInvalidContinueToken, CodeInvalidContinueToken, http.StatusBadRequest},
+	{models.ErrInvalidPatch, CodeInvalidPatch, http.StatusBadRequest},
+	{models.ErrUnsupportedPatchType, CodeUnsupportedPatchType, http.StatusUnsupportedMediaType},
+	{models.ErrTooManySubscribers, CodeTooManySubscribers, http.StatusServiceUnavailable},
+	{models.ErrTierLevelConflict, CodeTierLevelConflict, http.StatusConflict},
+	{models.ErrTierNotDeleted, CodeTierNotDeleted, http.StatusConflict},
+	{models.ErrTierSoftDeleted, CodeTierSoftDeleted, http.StatusConflict},
+	{models.ErrInvalidGroupPattern, CodeInvalidGroupPattern, http.StatusUnprocessableEntity},
+	{models.ErrTierGroupsRequired, CodeTierGroupsRequired, http.StatusUnprocessableEntity},
+	{models.ErrConfirmationRequired, CodeConfirmationRequired, http.StatusBadRequest},
+	{models.ErrAnnotationKeyRequired, CodeAnnotationKeyRequired, http.StatusUnprocessableEntity},
+	{models.ErrInvalidAnnotationKey, CodeInvalidAnnotationKey, http.StatusUnprocessableEntity},
+	{models.ErrAnnotationKeysIdentical, CodeAnnotationKeysIdentical, http.StatusUnprocessableEntity},
+	{models.ErrNamespaceNotFound, CodeNamespaceNotFound, http.StatusNotFound},
+	{models.ErrTierLevelRequired, CodeTierLevelRequired, http.StatusUnprocessableEntity},
+	{models.ErrTierLevelTooHigh, CodeTierLevelTooHigh, http.StatusUnprocessableEntity},
+	{models.ErrConcurrentModification, CodeConcurrentModification, http.StatusConflict},
+	{models.ErrModelURIRequired, CodeModelURIRequired, http.StatusUnprocessableEntity},
+	{models.ErrLLMServiceAlreadyExists, CodeLLMServiceAlreadyExists, http.StatusConflict},
+	{models.ErrLLMNamespaceNotFound, CodeLLMNamespaceNotFound, http.StatusNotFound},
+	{models.ErrTierETagMismatch, CodeTierETagMismatch, http.StatusConflict},
+	{models.ErrTierLimitReached, CodeTierLimitReached, http.StatusConflict},
+	{models.ErrTierMetadataTooLarge, CodeTierMetadataTooLarge, http.StatusUnprocessableEntity},
+	{models.ErrTierMetadataKeyRequired, CodeTierMetadataKeyRequired, http.StatusUnprocessableEntity},
+	{models.ErrTierMetadataKeyTooLong, CodeTierMetadataKeyTooLong, http.StatusUnprocessableEntity},
+	{models.ErrTierMetadataValueTooLong, CodeTierMetadataValueTooLong, http.StatusUnprocessableEntity},
+	{models.ErrTierNamesRequired, CodeTierNamesRequired, http.StatusUnprocessableEntity},
+	{models.ErrTierNameReserved, CodeTierNameReserved, http.StatusUnprocessableEntity},
+}
+
+// classifyError returns the error code and HTTP status for an error.
+// Unrecognized errors are reported as internal server errors.
+func classifyError(err error) (string, int) {
+	for _, mapping := range errorMappings {
+		if errors.Is(err, mapping.err) {
+			return mapping.code, mapping.status
+		}
+	}
+	return CodeInternalError, http.StatusInternalServerError
+}
+
+// respondError writes an ErrorResponse for err using its mapped code and HTTP status.
+// Validation errors additionally report the offending field and value.
+func respondError(c *gin.Context, err error) {
+	code, status := classifyError(err)
+	response := ErrorResponse{Error: err.Error(), Code: code}
+	var validationErr *models.ValidationError
+	if errors.As(err, &validationErr) {
+		response.Field = validationErr.Field
+		response.Value = validationErr.Value
+	}
+	var lengthErr *models.LengthError
+	if errors.As(err, &lengthErr) {
+		response.Length = lengthErr.Length
+		response.MaxLength = lengthErr.MaxLength
+	}
+	c.JSON(status, response)
+}
+
+// respondBindError writes a 400 ErrorResponse for a request body that could not be bound,
+// or a 413 when the body exceeded the request body limit
+func respondBindError(c *gin.Context, err error) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		respondBodyTooLarge(c, tooLarge.Limit)
+		return
+	}
+	message, field := describeBindError(err)
+	c.JSON(http.StatusBadRequest, ErrorResponse{Error: message, Code: CodeInvalidRequestBody, Field: field})
+}
+
+// respondQueryError writes a 400 ErrorResponse for an invalid query parameter
+func respondQueryError(c *gin.Context, err error) {
+	c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error(), Code: CodeInvalidQueryParameter})
+}
+
+// noRouteHandler writes a 404 ErrorResponse for a path no route matches
+func noRouteHandler(c *gin.Context) {
+	c.JSON(http.StatusNotFound, ErrorResponse{Error: "no route for " + c.Request.URL.Path, Code: CodeRouteNotFound})
+}
+
+// noMethodHandler writes a 405 ErrorResponse for a path served by a route, but not with this method
+func noMethodHandler(c *gin.Context) {
+	c.JSON(http.StatusMethodNotAllowed, ErrorResponse{Error: "method " + c.Request.Method + " is not allowed for " + c.Request.URL.Path, Code: CodeMethodNotAllowed})
+}
@@ -0,0 +1,135 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maas-toolbox/internal/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		err        error
+		wantCode   string
+		wantStatus int
+	}{
+		{models.ErrTierNameRequired, CodeTierNameRequired, http.StatusUnprocessableEntity},
+		{models.ErrTierDescriptionRequired, CodeTierDescriptionRequired, http.StatusUnprocessableEntity},
+		{models.ErrTierDescriptionTooLong, CodeTierDescriptionTooLong, http.StatusUnprocessableEntity},
+		{models.ErrTierLevelInvalid, CodeTierLevelInvalid, http.StatusUnprocessableEntity},
+		{models.ErrTierNotFound, CodeTierNotFound, http.StatusNotFound},
+		{models.ErrTierAlreadyExists, CodeTierAlreadyExists, http.StatusConflict},
+		{models.ErrTierNameImmutable, CodeTierNameImmutable, http.StatusUnprocessableEntity},
+		{models.ErrGroupRequired, CodeGroupRequired, http.StatusUnprocessableEntity},
+		{models.ErrGroupAlreadyExists, CodeGroupAlreadyExists, http.StatusConflict},
+		{models.ErrGroupNotFound, CodeGroupNotFound, http.StatusNotFound},
+		{models.ErrGroupNotFoundInCluster, CodeGroupNotFoundInCluster, http.StatusUnprocessableEntity},
+		{models.ErrInvalidKubernetesName, CodeInvalidK8sName, http.StatusUnprocessableEntity},
+		{models.ErrInvalidGroupColon, CodeInvalidGroupColon, http.StatusUnprocessableEntity},
+		{models.ErrInvalidTierAnnotation, CodeInvalidTierAnnotation, http.StatusConflict},
+		{models.ErrNamespaceRequired, CodeNamespaceRequired, http.StatusUnprocessableEntity},
+		{models.ErrLLMServiceNameRequired, CodeLLMServiceNameRequired, http.StatusUnprocessableEntity},
+		{models.ErrLLMServiceNotFound, CodeLLMServiceNotFound, http.StatusNotFound},
+		{models.ErrTargetsRequired, CodeTargetsRequired, http.StatusUnprocessableEntity},
+		{models.ErrTierNotInAnnotation, CodeTierNotInAnnotation, http.StatusNotFound},
+		{models.ErrInvalidUsername, CodeInvalidUsername, http.StatusUnprocessableEntity},
+		{models.ErrUserNotFound, CodeUserNotFound, http.StatusNotFound},
+		{models.ErrGroupLookupDenied, CodeGroupLookupDenied, http.StatusForbidden},
+		{models.ErrInvalidContinueToken, CodeInvalidContinueToken, http.StatusBadRequest},
+		{models.ErrInvalidPatch, CodeInvalidPatch, http.StatusBadRequest},
+		{models.ErrUnsupportedPatchType, CodeUnsupportedPatchType, http.StatusUnsupportedMediaType},
+		{models.ErrTooManySubscribers, CodeTooManySubscribers, http.StatusServiceUnavailable},
+		{models.ErrTierLevelConflict, CodeTierLevelConflict, http.StatusConflict},
+		{models.ErrTierNotDeleted, CodeTierNotDeleted, http.StatusConflict},
+		{models.ErrTierSoftDeleted, CodeTierSoftDeleted, http.StatusConflict},
+		{models.ErrInvalidGroupPattern, CodeInvalidGroupPattern, http.StatusUnprocessableEntity},
+		{models.ErrTierGroupsRequired, CodeTierGroupsRequired, http.StatusUnprocessableEntity},
+		{models.ErrConfirmationRequired, CodeConfirmationRequired, http.StatusBadRequest},
+		{models.ErrAnnotationKeyRequired, CodeAnnotationKeyRequired, http.StatusUnprocessableEntity},
+		{models.ErrInvalidAnnotationKey, CodeInvalidAnnotationKey, http.StatusUnprocessableEntity},
+		{models.ErrAnnotationKeysIdentical, CodeAnnotationKeysIdentical, http.StatusUnprocessableEntity},
+		{models.ErrNamespaceNotFound, CodeNamespaceNotFound, http.StatusNotFound},
+		{models.ErrTierLevelRequired, CodeTierLevelRequired, http.StatusUnprocessableEntity},
+		{models.ErrTierLevelTooHigh, CodeTierLevelTooHigh, http.StatusUnprocessableEntity},
+		{models.ErrConcurrentModification, CodeConcurrentModification, http.StatusConflict},
+		{models.ErrModelURIRequired, CodeModelURIRequired, http.StatusUnprocessableEntity},
+		{models.ErrLLMServiceAlreadyExists, CodeLLMServiceAlreadyExists, http.StatusConflict},
+		{models.ErrLLMNamespaceNotFound, CodeLLMNamespaceNotFound, http.StatusNotFound},
+		{models.ErrTierETagMismatch, CodeTierETagMismatch, http.StatusConflict},
+		{models.ErrTierLimitReached, CodeTierLimitReached, http.StatusConflict},
+		{models.ErrTierMetadataTooLarge, CodeTierMetadataTooLarge, http.StatusUnprocessableEntity},
+		{models.ErrTierMetadataKeyRequired, CodeTierMetadataKeyRequired, http.StatusUnprocessableEntity},
+		{models.ErrTierMetadataKeyTooLong, CodeTierMetadataKeyTooLong, http.StatusUnprocessableEntity},
+		{models.ErrTierMetadataValueTooLong, CodeTierMetadataValueTooLong, http.StatusUnprocessableEntity},
+		{models.ErrTierNamesRequired, CodeTierNamesRequired, http.StatusUnprocessableEntity},
+		{models.ErrTierNameReserved, CodeTierNameReserved, http.StatusUnprocessableEntity},
+		{errors.New("something unexpected"), CodeInternalError, http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.wantCode, func(t *testing.T) {
+			code, status := classifyError(tt.err)
+			if code != tt.wantCode || status != tt.wantStatus {
+				t.Errorf("classifyError(%v) = (%s, %d), want (%s, %d)", tt.err, code, status, tt.wantCode, tt.wantStatus)
+			}
+
+			// Wrapped sentinels must classify the same way
+			wrapped := fmt.Errorf("context: %w", tt.err)
+			code, status = classifyError(wrapped)
+			if code != tt.wantCode || status != tt.wantStatus {
+				t.Errorf("classifyError(wrapped %v) = (%s, %d), want (%s, %d)", tt.err, code, status, tt.wantCode, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestErrorMappings_CoverAllSentinels(t *testing.T) {
+	// Every mapped sentinel must have a distinct code
+	seen := make(map[string]bool)
+	for _, mapping := range errorMappings {
+		if seen[mapping.code] {
+			t.Errorf("Duplicate error code %s", mapping.code)
+		}
+		seen[mapping.code] = true
+	}
+}
+
+func TestErrorResponse_IncludesCode(t *testing.T) {
+	router, _ := setupTestRouter()
+
+	req, _ := http.NewRequest("GET", "/api/v1/tiers/missing", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("Expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+
+	var response ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if response.Code != CodeTierNotFound {
+		t.Errorf("Expected code %s, got %s", CodeTierNotFound, response.Code)
+	}
+	if response.Error != models.ErrTierNotFound.Error() {
+		t.Errorf("Expected message %q, got %q", models.ErrTierNotFound.Error(), response.Error)
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"maas-toolbox/internal/models"
+	"maas-toolbox/internal/storage"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Response formats supported by content negotiation
+const (
+	formatJSON = "json"
+	formatYAML = "yaml"
+)
+
+// ContentTypeYAML is the Content-Type used for YAML responses
+const ContentTypeYAML = "application/yaml; charset=utf-8"
+
+// yamlMediaTypes lists the Accept values that select a YAML response
+var yamlMediaTypes = []string{"application/yaml", "application/x-yaml", "text/yaml"}
+
+// responseFormat determines the response format for a request.
+// The format query parameter takes precedence over the Accept header; JSON is the default.
+func responseFormat(c *gin.Context) (string, error) {
+	switch format := strings.ToLower(c.Query("format")); format {
+	case "":
+	case formatJSON, formatYAML:
+		return format, nil
+	default:
+		return "", fmt.Errorf("format must be one of %s, %s", formatJSON, formatYAML)
+	}
+
+	accept := strings.ToLower(c.GetHeader("Accept"))
+	for _, mediaType := range yamlMediaTypes {
+		if strings.Contains(accept, mediaType) {
+			return formatYAML, nil
+		}
+	}
+	return formatJSON, nil
+}
+
+// responseOptions controls how a response body is shaped and encoded
+type responseOptions struct {
+	format string   // formatJSON or formatYAML
+	fields []string // Fields to keep in each object; nil keeps all fields
+	pretty bool     // Indent JSON for humans; JSON is compact by default
+}
+
+// parseResponseOptions reads the format, fields and pretty query parameters.
+// Requested fields are validated against allowedFields.
+func parseResponseOptions(c *gin.Context, allowedFields []string) (responseOptions, error) {
+	format, err := responseFormat(c)
+	if err != nil {
+		return responseOptions{}, err
+	}
+	fields, err := parseFields(c.Query("fields"), allowedFields)
+	if err != nil {
+		return responseOptions{}, err
+	}
+	pretty, err := parseBoolQuery(c, "pretty")
+	if err != nil {
+		return responseOptions{}, err
+	}
+	return responseOptions{format: format, fields: fields, pretty: pretty}, nil
+}
+
+// parseFields parses a comma-separated list of field names, rejecting unknown fields
+func parseFields(raw string, allowedFields []string) ([]string, error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	allowed := make(map[string]bool, len(allowedFields))
+	for _, field := range allowedFields {
+		allowed[field] = true
+	}
+
+	var fields []string
+	for _, field := range strings.Split(raw, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		if !allowed[field] {
+			return nil, fmt.Errorf("unknown field %q: fields must be a comma-separated list of %s", field, strings.Join(allowedFields, ", "))
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// projectFields reduces body to the requested fields.
+// body must encode to a JSON object or an array of objects.
+func projectFields(body interface{}, fields []string) (interface{}, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, err
+	}
+
+	project := func(value interface{}) interface{} {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return value
+		}
+		projected := make(map[string]interface{}, len(fields))
+		for _, field := range fields {
+			if v, exists := object[field]; exists {
+				projected[field] = v
+			}
+		}
+		return projected
+	}
+
+	if list, ok := decoded.([]interface{}); ok {
+		for i := range list {
+			list[i] = project(list[i])
+		}
+		return list, nil
+	}
+	return project(decoded), nil
+}
+
+// respondFormatted writes body as YAML or JSON, projected to the requested fields
+func respondFormatted(c *gin.Context, status int, opts responseOptions, body interface{}) {
+	if opts.fields != nil {
+		projected, err := projectFields(body, opts.fields)
+		if err != nil {
+			respondError(c, fmt.Errorf("failed to select fields: %w", err))
+			return
+		}
+		body = projected
+	}
+
+	writeFormatted(c, status, opts, body)
+}
+
+// respondList writes items as a bare array, or wrapped in a models.ListResponse when paginate is set.
+// Field projection applies to each item rather than to the envelope.
+func respondList(c *gin.Context, status int, opts responseOptions, paginate bool, items interface{}, total int, continueToken string) {
+	if !paginate {
+		respondFormatted(c, status, opts, items)
+		return
+	}
+
+	if opts.fields != nil {
+		projected, err := projectFields(items, opts.fields)
+		if err != nil {
+			respondError(c, fmt.Errorf("failed to select fields: %w", err))
+			return
+		}
+		items = projected
+	}
+
+	writeFormatted(c, status, opts, models.ListResponse{Items: items, Total: total, Continue: continueToken})
+}
+
+// writeFormatted encodes body as YAML, or as compact or indented JSON
+func writeFormatted(c *gin.Context, status int, opts responseOptions, body interface{}) {
+	if opts.format != formatYAML {
+		if opts.pretty {
+			c.IndentedJSON(status, body)
+			return
+		}
+		c.JSON(status, body)
+		return
+	}
+
+	data, err := storage.MarshalYAML(body)
+	if err != nil {
+		respondError(c, fmt.Errorf("failed to marshal YAML: %w", err))
+		return
+	}
+	c.Data(status, ContentTypeYAML, data)
+}
@@ -15,12 +15,16 @@
 package api
 
 import (
+	"errors"
+	"fmt"
 	"log"
 	"maas-toolbox/internal/models"
 	"maas-toolbox/internal/service"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 // TierHandler handles HTTP requests for tier management
@@ -37,9 +41,47 @@ func NewTierHandler(service *service.TierService, llmServiceService *service.LLM
 	}
 }
 
-// ErrorResponse represents an error response
-type ErrorResponse struct {
-	Error string `json:"error"`
+// parseBoolQuery parses an optional boolean query parameter, defaulting to false when absent
+func parseBoolQuery(c *gin.Context, name string) (bool, error) {
+	raw := c.Query(name)
+	if raw == "" {
+		return false, nil
+	}
+	value, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, fmt.Errorf("%s must be a boolean", name)
+	}
+	return value, nil
+}
+
+// parseLabelSelectorQuery parses the optional labelSelector query parameter as a Kubernetes
+// label selector, returning a nil selector when absent
+func parseLabelSelectorQuery(c *gin.Context) (labels.Selector, error) {
+	raw := c.Query("labelSelector")
+	if raw == "" {
+		return nil, nil
+	}
+	selector, err := labels.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("labelSelector is invalid: %v", err)
+	}
+	return selector, nil
+}
+
+// jsonResponse is the response options for endpoints that only produce JSON
+var jsonResponse = responseOptions{format: formatJSON}
+
+// parseLimitQuery parses the optional limit query parameter, returning 0 when absent
+func parseLimitQuery(c *gin.Context) (int64, error) {
+	raw := c.Query("limit")
+	if raw == "" {
+		return 0, nil
+	}
+	limit, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || limit <= 0 {
+		return 0, fmt.Errorf("limit must be a positive integer")
+	}
+	return limit, nil
 }
 
 // CreateTier handles POST /api/v1/tiers
@@ -47,17 +89,19 @@ type ErrorResponse struct {
 // @Description  Create a new tier with name, description, level, and groups. The tier name must be unique and cannot be changed after creation.
 // @Tags         tiers
 // @Accept       json
+// @Accept       application/yaml
 // @Produce      json
 // @Param        tier  body      models.Tier  true  "Tier object"
 // @Success      201   {object}  models.Tier  "Tier created successfully"
-// @Failure      400   {object}  ErrorResponse  "Bad request - validation error"
+// @Failure      400   {object}  ErrorResponse  "Bad request - invalid request body"
+// @Failure      422   {object}  ErrorResponse  "Unprocessable entity - validation error"
 // @Failure      409   {object}  ErrorResponse  "Conflict - tier already exists"
 // @Failure      500   {object}  ErrorResponse  "Internal server error"
 // @Router       /tiers [post]
 func (h *TierHandler) CreateTier(c *gin.Context) {
 	var tier models.Tier
-	if err := c.ShouldBindJSON(&tier); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+	if err := bindBody(c, &tier); err != nil {
+		respondBindError(c, err)
 		return
 	}
 
@@ -68,135 +112,436 @@ func (h *TierHandler) CreateTier(c *gin.Context) {
 
 	// Validate required fields for creation
 	if tier.Name == "" {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: models.ErrTierNameRequired.Error()})
+		respondError(c, models.NewValidationError(models.FieldName, models.ErrTierNameRequired))
 		return
 	}
 	if tier.Description == "" {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: models.ErrTierDescriptionRequired.Error()})
+		respondError(c, models.NewValidationError(models.FieldDescription, models.ErrTierDescriptionRequired))
 		return
 	}
 
-	if err := h.service.CreateTier(&tier); err != nil {
-		switch err {
-		case models.ErrTierAlreadyExists:
-			c.JSON(http.StatusConflict, ErrorResponse{Error: err.Error()})
-		case models.ErrTierNameRequired, models.ErrTierDescriptionRequired, models.ErrTierLevelInvalid, models.ErrInvalidKubernetesName, models.ErrGroupNotFoundInCluster:
-			c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
-		default:
-			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
-		}
+	if err := h.service.CreateTier(c.Request.Context(), &tier); err != nil {
+		respondError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusCreated, tier)
 }
 
+// EnsureTierResultHeader reports whether PUT /api/v1/tiers created, updated or left the tier unchanged
+const EnsureTierResultHeader = "X-Tier-Ensure-Result"
+
+// EnsureTier handles PUT /api/v1/tiers
+// @Summary      Create or update a tier
+// @Description  Idempotently make the tier named in the body match it: create the tier when it does not exist, otherwise replace its description, level and groups. The name is only used to find the tier and cannot be changed. A tier that already matches is left untouched.
+// @Tags         tiers
+// @Accept       json
+// @Accept       application/yaml
+// @Produce      json
+// @Param        tier  body      models.Tier  true  "Desired tier"
+// @Success      200   {object}  models.Tier  "Tier updated, or already matching"
+// @Success      201   {object}  models.Tier  "Tier created"
+// @Header       200,201  {string}  X-Tier-Ensure-Result  "created, updated or unchanged"
+// @Header       200,201  {string}  ETag  "Entity tag of the tier"
+// @Failure      400   {object}  ErrorResponse  "Bad request - invalid request body"
+// @Failure      422   {object}  ErrorResponse  "Unprocessable entity - validation error"
+// @Failure      409   {object}  ErrorResponse  "Conflict - a soft-deleted or case-variant tier exists, or the level is taken"
+// @Failure      500   {object}  ErrorResponse  "Internal server error"
+// @Router       /tiers [put]
+func (h *TierHandler) EnsureTier(c *gin.Context) {
+	var tier models.Tier
+	if err := bindBody(c, &tier); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	result, err := h.service.EnsureTier(c.Request.Context(), &tier)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	status := http.StatusOK
+	if result == service.EnsureCreated {
+		status = http.StatusCreated
+	}
+	c.Header(EnsureTierResultHeader, result)
+	c.Header("ETag", tier.ETag())
+	c.JSON(status, tier)
+}
+
+// ImportTiers handles POST /api/v1/tiers/import
+// @Summary      Import tiers
+// @Description  Create or replace each tier in the request by name; existing tiers not in the request are kept.
+// @Description  The import is atomic by default: any invalid tier fails the whole import and nothing is saved.
+// @Description  Set partial=true to skip invalid tiers, apply the rest, and report a per-tier status.
+// @Tags         tiers
+// @Accept       json
+// @Accept       application/yaml
+// @Produce      json
+// @Param        config   body      models.TierConfig  true   "Tiers to import"
+// @Param        partial  query     bool               false  "Skip invalid tiers instead of failing the import"
+// @Success      200      {object}  models.ImportResponse  "Per-tier import outcomes"
+// @Failure      400      {object}  ErrorResponse  "Bad request - invalid request body"
+// @Failure      422      {object}  ErrorResponse  "Unprocessable entity - invalid tier (atomic mode)"
+// @Failure      409      {object}  ErrorResponse  "Conflict - duplicate tier in import (atomic mode)"
+// @Failure      500      {object}  ErrorResponse  "Internal server error"
+// @Router       /tiers/import [post]
+func (h *TierHandler) ImportTiers(c *gin.Context) {
+	partial, err := parseBoolQuery(c, "partial")
+	if err != nil {
+		respondQueryError(c, err)
+		return
+	}
+
+	var config models.TierConfig
+	if err := bindBody(c, &config); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	response, err := h.service.ImportTiers(c.Request.Context(), config.Tiers, partial)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
 // GetTiers handles GET /api/v1/tiers
 // @Summary      List all tiers
 // @Description  Retrieve a list of all tiers in the system
 // @Tags         tiers
 // @Produce      json
-// @Success      200  {array}   models.Tier  "List of tiers"
+// @Produce      application/yaml
+// @Param        includeDeleted  query     bool    false  "Include soft-deleted tiers"
+// @Param        group   query     string  false  "Only return tiers containing this group, exactly or through a wildcard pattern; avoids path-encoding group names such as system:authenticated"
+// @Param        labelSelector  query  string  false  "Only return tiers whose metadata matches this Kubernetes label selector, e.g. owner=team-a or owner notin (team-b)"
+// @Param        format  query     string  false  "Response format (json or yaml); overrides the Accept header"
+// @Param        fields  query     string  false  "Comma-separated fields to include (name, description, level, groups, metadata, deletedAt)"
+// @Param        pretty  query     bool    false  "Indent the JSON response; responses are compact by default"
+// @Param        paginate  query   bool    false  "Wrap the list in a models.ListResponse envelope"
+// @Success      200  {array}   models.Tier  "List of tiers (models.ListResponse when paginate=true)"
+// @Failure      400  {object}  ErrorResponse  "Bad request - invalid query parameter"
+// @Failure      422  {object}  ErrorResponse  "Unprocessable entity - invalid group name"
 // @Failure      500  {object}  ErrorResponse  "Internal server error"
 // @Router       /tiers [get]
 func (h *TierHandler) GetTiers(c *gin.Context) {
 	log.Printf("GET /api/v1/tiers - Request received from %s", c.ClientIP())
-	tiers, err := h.service.GetTiers()
+	includeDeleted, err := parseBoolQuery(c, "includeDeleted")
+	if err != nil {
+		respondQueryError(c, err)
+		return
+	}
+	paginate, err := parseBoolQuery(c, "paginate")
+	if err != nil {
+		respondQueryError(c, err)
+		return
+	}
+	opts, err := parseResponseOptions(c, models.TierFields)
+	if err != nil {
+		respondQueryError(c, err)
+		return
+	}
+	selector, err := parseLabelSelectorQuery(c)
+	if err != nil {
+		respondQueryError(c, err)
+		return
+	}
+
+	var tiers []models.Tier
+	if includeDeleted {
+		tiers, err = h.service.GetAllTiers(c.Request.Context())
+	} else {
+		tiers, err = h.service.GetTiers(c.Request.Context())
+	}
 	if err != nil {
 		log.Printf("GET /api/v1/tiers - Error: %v", err)
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		respondError(c, err)
 		return
 	}
 
+	if group := c.Query("group"); group != "" {
+		tiers, err = h.service.FilterTiersByGroup(tiers, group)
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+	}
+	if selector != nil {
+		tiers = h.service.FilterTiersByMetadata(tiers, selector)
+	}
+
 	log.Printf("GET /api/v1/tiers - Returning %d tiers", len(tiers))
-	c.JSON(http.StatusOK, tiers)
+	respondList(c, http.StatusOK, opts, paginate, tiers, len(tiers), "")
 }
 
 // GetTier handles GET /api/v1/tiers/:name
 // @Summary      Get a specific tier
-// @Description  Retrieve a tier by its name
+// @Description  Retrieve a tier by its name. Set includeUsage=true to add a serviceCount of LLMInferenceServices using the tier (requires a cluster-wide list).
 // @Tags         tiers
 // @Produce      json
-// @Param        name  path      string  true  "Tier name"
-// @Success      200    {object}  models.Tier  "Tier details"
+// @Produce      application/yaml
+// @Param        name          path      string  true   "Tier name"
+// @Param        includeUsage  query     bool    false  "Include the number of LLMInferenceServices using this tier"
+// @Param        includeDeleted  query   bool    false  "Return the tier even if it has been soft-deleted"
+// @Param        format        query     string  false  "Response format (json or yaml); overrides the Accept header"
+// @Param        fields        query     string  false  "Comma-separated fields to include (name, description, level, groups, metadata, deletedAt, and serviceCount with includeUsage)"
+// @Param        pretty        query     bool    false  "Indent the JSON response; responses are compact by default"
+// @Success      200    {object}  models.TierWithUsage  "Tier details (serviceCount only present when includeUsage=true)"
+// @Header       200    {string}  ETag  "Entity tag of the tier"
+// @Failure      400    {object}  ErrorResponse  "Bad request - invalid query parameter"
 // @Failure      404    {object}  ErrorResponse  "Tier not found"
 // @Failure      500    {object}  ErrorResponse  "Internal server error"
 // @Router       /tiers/{name} [get]
 func (h *TierHandler) GetTier(c *gin.Context) {
 	name := c.Param("name")
-	tier, err := h.service.GetTier(name)
+
+	includeUsage, err := parseBoolQuery(c, "includeUsage")
 	if err != nil {
-		if err == models.ErrTierNotFound {
-			c.JSON(http.StatusNotFound, ErrorResponse{Error: err.Error()})
-		} else {
-			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
-		}
+		respondQueryError(c, err)
+		return
+	}
+	includeDeleted, err := parseBoolQuery(c, "includeDeleted")
+	if err != nil {
+		respondQueryError(c, err)
+		return
+	}
+	allowedFields := models.TierFields
+	if includeUsage {
+		allowedFields = models.TierWithUsageFields
+	}
+	opts, err := parseResponseOptions(c, allowedFields)
+	if err != nil {
+		respondQueryError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, tier)
+	var tier *models.Tier
+	if includeDeleted {
+		tier, err = h.service.GetTierIncludingDeleted(c.Request.Context(), name)
+	} else {
+		tier, err = h.service.GetTier(c.Request.Context(), name)
+	}
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Header("ETag", tier.ETag())
+
+	if !includeUsage {
+		respondFormatted(c, http.StatusOK, opts, tier)
+		return
+	}
+
+	count, err := h.llmServiceService.CountLLMInferenceServicesByTier(c.Request.Context(), name)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respondFormatted(c, http.StatusOK, opts, models.TierWithUsage{Tier: *tier, ServiceCount: count})
+}
+
+// HeadTier handles HEAD /api/v1/tiers/:name
+// @Summary      Check whether a tier exists
+// @Description  Return 200 with the tier's ETag if it exists, or 404 otherwise. No body is written.
+// @Tags         tiers
+// @Param        name  path  string  true  "Tier name"
+// @Success      200   "Tier exists"
+// @Header       200   {string}  ETag  "Entity tag of the tier"
+// @Failure      404   "Tier not found"
+// @Failure      500   "Internal server error"
+// @Router       /tiers/{name} [head]
+func (h *TierHandler) HeadTier(c *gin.Context) {
+	name := c.Param("name")
+
+	tier, err := h.service.GetTier(c.Request.Context(), name)
+	if err != nil {
+		_, status := classifyError(err)
+		c.Status(status)
+		return
+	}
+
+	c.Header("ETag", tier.ETag())
+	c.Status(http.StatusOK)
 }
 
 // UpdateTier handles PUT /api/v1/tiers/:name
 // @Summary      Update a tier
-// @Description  Update a tier's description, level, or groups. The tier name cannot be changed.
+// @Description  Update a tier's description, level, or groups. Omitted fields are left unchanged, so a body without level keeps the current level. The tier name cannot be changed.
 // @Tags         tiers
 // @Accept       json
+// @Accept       application/yaml
 // @Produce      json
 // @Param        name     path      string       true  "Tier name"
-// @Param        updates  body      models.Tier  true  "Tier update object (name field is ignored)"
+// @Param        updates  body      models.TierUpdate  true  "Tier update object (name field is ignored)"
+// @Param        If-Match  header   string       false  "Apply the update only if the tier's ETag still matches"
 // @Success      200      {object}  models.Tier  "Updated tier"
-// @Failure      400      {object}  ErrorResponse  "Bad request - validation error"
+// @Header       200      {string}  ETag  "Entity tag of the updated tier"
+// @Failure      400      {object}  ErrorResponse  "Bad request - invalid request body"
+// @Failure      422      {object}  ErrorResponse  "Unprocessable entity - validation error"
 // @Failure      404      {object}  ErrorResponse  "Tier not found"
+// @Failure      409      {object}  ErrorResponse  "The tier changed since the If-Match ETag was read"
 // @Failure      500      {object}  ErrorResponse  "Internal server error"
 // @Router       /tiers/{name} [put]
 func (h *TierHandler) UpdateTier(c *gin.Context) {
 	name := c.Param("name")
-	var updates models.Tier
+	var updates models.TierUpdate
 
-	// Bind JSON - name field is optional for updates
-	if err := c.ShouldBindJSON(&updates); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+	// Bind JSON or YAML - name field is optional for updates
+	if err := bindBody(c, &updates); err != nil {
+		respondBindError(c, err)
 		return
 	}
 
 	// Check if user is trying to change the name (which is immutable)
 	// We check the original value from JSON before overwriting it
 	if updates.Name != "" && updates.Name != name {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: models.ErrTierNameImmutable.Error()})
+		respondError(c, models.ErrTierNameImmutable)
 		return
 	}
 
 	// Ensure name is set from URL path (not from JSON body) for validation
 	updates.Name = name
 
-	if err := h.service.UpdateTier(name, &updates); err != nil {
-		switch err {
-		case models.ErrTierNotFound:
-			c.JSON(http.StatusNotFound, ErrorResponse{Error: err.Error()})
-		case models.ErrTierNameImmutable:
-			c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
-		case models.ErrTierDescriptionRequired, models.ErrTierLevelInvalid, models.ErrInvalidKubernetesName, models.ErrGroupNotFoundInCluster:
-			c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
-		default:
-			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
-		}
+	if err := h.service.UpdateTier(c.Request.Context(), name, &updates, c.GetHeader("If-Match")); err != nil {
+		respondError(c, err)
 		return
 	}
 
 	// Return updated tier
-	tier, err := h.service.GetTier(name)
+	tier, err := h.service.GetTier(c.Request.Context(), name)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.Header("ETag", tier.ETag())
+	c.JSON(http.StatusOK, tier)
+}
+
+// SetTierLevel handles POST /api/v1/tiers/:name/level
+// @Summary      Set a tier's level
+// @Description  Set only the level of an existing tier. Unlike update, the level is always required, so 0 is never mistaken for an omitted level. The level must be non-negative, at most the configured maximum, and unique when unique levels are enforced.
+// @Tags         tiers
+// @Accept       json
+// @Produce      json
+// @Param        name     path      string                  true  "Tier name"
+// @Param        request  body      models.SetLevelRequest  true  "New level"
+// @Success      200      {object}  models.Tier  "Updated tier"
+// @Failure      400      {object}  ErrorResponse  "Bad request - invalid request body"
+// @Failure      422      {object}  ErrorResponse  "Unprocessable entity - level missing, negative or above the maximum"
+// @Failure      404      {object}  ErrorResponse  "Tier not found"
+// @Failure      409      {object}  ErrorResponse  "Another tier already has this level"
+// @Failure      500      {object}  ErrorResponse  "Internal server error"
+// @Router       /tiers/{name}/level [post]
+func (h *TierHandler) SetTierLevel(c *gin.Context) {
+	name := c.Param("name")
+
+	var req models.SetLevelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+	if err := req.Validate(); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	tier, err := h.service.SetTierLevel(c.Request.Context(), name, *req.Level)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tier)
+}
+
+// MoveTierLevel handles POST /api/v1/tiers/:name/move-level
+// @Summary      Move a tier to a level
+// @Description  Move a tier to a level, optionally shifting every other tier at or above that level up by one (cascade=true) to open the slot. All changes are saved together. Without cascade only the moved tier changes, so the move is rejected when unique levels are enforced and the level is taken. Every tier whose level changed is reported.
+// @Tags         tiers
+// @Accept       json
+// @Produce      json
+// @Param        name     path      string                  true   "Tier name"
+// @Param        cascade  query     bool                    false  "Shift tiers at or above the level up by one"
+// @Param        request  body      models.SetLevelRequest  true   "Target level"
+// @Success      200      {object}  models.MoveLevelResponse  "Tiers whose levels changed"
+// @Failure      400      {object}  ErrorResponse  "Bad request - invalid request body"
+// @Failure      422      {object}  ErrorResponse  "Unprocessable entity - level missing, negative or above the maximum"
+// @Failure      404      {object}  ErrorResponse  "Tier not found"
+// @Failure      409      {object}  ErrorResponse  "Another tier already has this level"
+// @Failure      500      {object}  ErrorResponse  "Internal server error"
+// @Router       /tiers/{name}/move-level [post]
+func (h *TierHandler) MoveTierLevel(c *gin.Context) {
+	cascade, err := parseBoolQuery(c, "cascade")
+	if err != nil {
+		respondQueryError(c, err)
+		return
+	}
+
+	var req models.SetLevelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+	if err := req.Validate(); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	response, err := h.service.MoveTierLevel(c.Request.Context(), c.Param("name"), *req.Level, cascade)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// PatchTier handles PATCH /api/v1/tiers/:name
+// @Summary      Patch a tier
+// @Description  Apply an RFC 6902 JSON Patch (application/json-patch+json) or RFC 7386 JSON Merge Patch (application/merge-patch+json) to a tier. The patched tier must keep its name and pass validation.
+// @Tags         tiers
+// @Accept       application/json-patch+json
+// @Accept       application/merge-patch+json
+// @Produce      json
+// @Param        name   path      string  true  "Tier name"
+// @Param        patch  body      string  true  "Patch document"
+// @Param        If-Match  header  string  false  "Apply the patch only if the tier's ETag still matches"
+// @Success      200    {object}  models.Tier  "Patched tier"
+// @Header       200    {string}  ETag  "Entity tag of the patched tier"
+// @Failure      400    {object}  ErrorResponse  "Bad request - invalid request body or patch"
+// @Failure      422    {object}  ErrorResponse  "Unprocessable entity - validation error"
+// @Failure      404    {object}  ErrorResponse  "Tier not found"
+// @Failure      409    {object}  ErrorResponse  "The tier changed since the If-Match ETag was read"
+// @Failure      415    {object}  ErrorResponse  "Unsupported patch content type"
+// @Failure      500    {object}  ErrorResponse  "Internal server error"
+// @Router       /tiers/{name} [patch]
+func (h *TierHandler) PatchTier(c *gin.Context) {
+	name := c.Param("name")
+
+	patch, err := c.GetRawData()
+	if err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	tier, err := h.service.PatchTier(c.Request.Context(), name, c.ContentType(), patch, c.GetHeader("If-Match"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		respondError(c, err)
 		return
 	}
 
+	c.Header("ETag", tier.ETag())
 	c.JSON(http.StatusOK, tier)
 }
 
 // DeleteTier handles DELETE /api/v1/tiers/:name
 // @Summary      Delete a tier
-// @Description  Delete a tier by its name
+// @Description  Delete a tier by its name. In soft-delete mode the tier is marked deleted and can be restored until it is purged.
 // @Tags         tiers
 // @Param        name  path  string  true  "Tier name"
 // @Success      204   "No content - tier deleted successfully"
@@ -205,64 +550,225 @@ func (h *TierHandler) UpdateTier(c *gin.Context) {
 // @Router       /tiers/{name} [delete]
 func (h *TierHandler) DeleteTier(c *gin.Context) {
 	name := c.Param("name")
-	if err := h.service.DeleteTier(name); err != nil {
-		if err == models.ErrTierNotFound {
-			c.JSON(http.StatusNotFound, ErrorResponse{Error: err.Error()})
-		} else {
-			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
-		}
+	if err := h.service.DeleteTier(c.Request.Context(), name); err != nil {
+		respondError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusNoContent, nil)
 }
 
+// DeleteTiers handles DELETE /api/v1/tiers/batch
+// @Summary      Delete several tiers
+// @Description  Delete the named tiers in a single save and report each one as deleted or not-found. Tiers annotated on any LLMInferenceService are kept and reported as referenced unless force=true. In soft-delete mode the tiers are marked deleted and can be restored until they are purged.
+// @Tags         tiers
+// @Accept       json
+// @Produce      json
+// @Param        force    query     bool                           false  "Delete tiers even when LLMInferenceServices are annotated with them"
+// @Param        request  body      models.BatchDeleteTiersRequest  true   "Tiers to delete"
+// @Success      200      {object}  models.BatchDeleteTiersResponse  "Per-tier results"
+// @Failure      400      {object}  ErrorResponse  "Bad request - invalid request body or query parameter"
+// @Failure      422      {object}  ErrorResponse  "Unprocessable entity - no names or an invalid tier name"
+// @Failure      409      {object}  ErrorResponse  "An LLMInferenceService has an invalid tiers annotation"
+// @Failure      500      {object}  ErrorResponse  "Internal server error"
+// @Router       /tiers/batch [delete]
+func (h *TierHandler) DeleteTiers(c *gin.Context) {
+	force, err := parseBoolQuery(c, "force")
+	if err != nil {
+		respondQueryError(c, err)
+		return
+	}
+
+	var req models.BatchDeleteTiersRequest
+	if err := bindBody(c, &req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	response, err := h.llmServiceService.DeleteTiers(c.Request.Context(), &req, force)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// RestoreTier handles POST /api/v1/tiers/:name/restore
+// @Summary      Restore a soft-deleted tier
+// @Description  Clear the soft-delete mark on a tier so it is returned by normal reads again
+// @Tags         tiers
+// @Produce      json
+// @Param        name  path      string  true  "Tier name"
+// @Success      200   {object}  models.Tier  "Restored tier"
+// @Failure      404   {object}  ErrorResponse  "Tier not found"
+// @Failure      409   {object}  ErrorResponse  "Tier is not deleted"
+// @Failure      500   {object}  ErrorResponse  "Internal server error"
+// @Router       /tiers/{name}/restore [post]
+func (h *TierHandler) RestoreTier(c *gin.Context) {
+	tier, err := h.service.RestoreTier(c.Request.Context(), c.Param("name"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tier)
+}
+
+// PurgeTiers handles POST /api/v1/tiers/purge
+// @Summary      Purge soft-deleted tiers
+// @Description  Permanently remove tiers that were soft-deleted longer ago than the configured retention
+// @Tags         tiers
+// @Produce      json
+// @Success      200  {object}  models.PurgeResponse  "Purged tiers"
+// @Failure      500  {object}  ErrorResponse  "Internal server error"
+// @Router       /tiers/purge [post]
+func (h *TierHandler) PurgeTiers(c *gin.Context) {
+	purged, err := h.service.PurgeDeletedTiers(c.Request.Context())
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.PurgeResponse{Purged: purged, Retention: h.service.SoftDeleteRetention().String()})
+}
+
 // AddGroupRequest represents the request body for adding a group
 // @Description Request body for adding a group to a tier
 type AddGroupRequest struct {
 	Group string `json:"group" binding:"required" example:"premium-users"` // Kubernetes group name to add
 }
 
+// Add group results reported in AddGroupResponse
+const (
+	AddGroupResultAdded          = "added"
+	AddGroupResultAlreadyPresent = "already-present"
+)
+
+// AddGroupResponse represents the response body for an idempotent add group request
+// @Description Updated tier and whether the group was added or already present
+type AddGroupResponse struct {
+	models.Tier
+	Result  string `json:"result" example:"added"`  // added or already-present
+	WasNoOp bool   `json:"wasNoOp" example:"false"` // True if the group was already in the tier and nothing changed
+}
+
 // AddGroup handles POST /api/v1/tiers/:name/groups
 // @Summary      Add a group to a tier
-// @Description  Add a Kubernetes group to a tier. The group must not already exist in the tier.
+// @Description  Add a Kubernetes group to a tier. By default the group must not already exist in the tier (409).
+// @Description  With idempotent=true an already-present group returns 200 with wasNoOp=true, and the response reports whether the group was added or already present.
 // @Tags         groups
 // @Accept       json
 // @Produce      json
-// @Param        name   path      string           true  "Tier name"
-// @Param        group  body      AddGroupRequest   true  "Group to add"
-// @Success      200    {object}  models.Tier      "Updated tier with new group"
-// @Failure      400    {object}  ErrorResponse    "Bad request - validation error"
+// @Param        name        path      string           true   "Tier name"
+// @Param        group       body      AddGroupRequest  true   "Group to add"
+// @Param        idempotent  query     bool             false  "Treat an already-present group as success"
+// @Success      200    {object}  models.Tier       "Updated tier with new group (AddGroupResponse when idempotent=true)"
+// @Failure      400    {object}  ErrorResponse    "Bad request - invalid request body"
+// @Failure      422    {object}  ErrorResponse    "Unprocessable entity - validation error"
 // @Failure      404    {object}  ErrorResponse    "Tier not found"
 // @Failure      409    {object}  ErrorResponse    "Conflict - group already exists"
 // @Failure      500    {object}  ErrorResponse    "Internal server error"
 // @Router       /tiers/{name}/groups [post]
 func (h *TierHandler) AddGroup(c *gin.Context) {
 	tierName := c.Param("name")
+
+	idempotent, err := parseBoolQuery(c, "idempotent")
+	if err != nil {
+		respondQueryError(c, err)
+		return
+	}
+
 	var req AddGroupRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		respondBindError(c, err)
 		return
 	}
 
-	if err := h.service.AddGroup(tierName, req.Group); err != nil {
-		switch err {
-		case models.ErrTierNotFound:
-			c.JSON(http.StatusNotFound, ErrorResponse{Error: err.Error()})
-		case models.ErrGroupRequired, models.ErrInvalidKubernetesName, models.ErrGroupNotFoundInCluster:
-			c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
-		case models.ErrGroupAlreadyExists:
-			c.JSON(http.StatusConflict, ErrorResponse{Error: err.Error()})
-		default:
-			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+	result := AddGroupResultAdded
+	if err := h.service.AddGroup(c.Request.Context(), tierName, req.Group); err != nil {
+		if !idempotent || !errors.Is(err, models.ErrGroupAlreadyExists) {
+			respondError(c, err)
+			return
 		}
-		return
+		result = AddGroupResultAlreadyPresent
 	}
 
 	// Return updated tier
-	tier, err := h.service.GetTier(tierName)
+	tier, err := h.service.GetTier(c.Request.Context(), tierName)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	if !idempotent {
+		c.JSON(http.StatusOK, tier)
+		return
+	}
+
+	c.JSON(http.StatusOK, AddGroupResponse{
+		Tier:    *tier,
+		Result:  result,
+		WasNoOp: result == AddGroupResultAlreadyPresent,
+	})
+}
+
+// AddGroups handles POST /api/v1/tiers/:name/groups/batch
+// @Summary      Add several groups to a tier
+// @Description  Validate every group, including that it exists in the cluster, and report each invalid group with its reason. When any group is invalid nothing is saved and the report is returned with 422, unless partial=true, in which case the valid groups are saved. Groups already in the tier are reported as already-present.
+// @Tags         groups
+// @Accept       json
+// @Produce      json
+// @Param        name     path      string                        true   "Tier name"
+// @Param        partial  query     bool                          false  "Save the valid groups even when some are invalid"
+// @Param        request  body      models.BatchAddGroupsRequest  true   "Groups to add"
+// @Success      200      {object}  models.BatchAddGroupsResponse  "Per-group results"
+// @Failure      422      {object}  models.BatchAddGroupsResponse  "Some groups are invalid and nothing was saved"
+// @Failure      404      {object}  ErrorResponse  "Tier not found"
+// @Failure      500      {object}  ErrorResponse  "Internal server error"
+// @Router       /tiers/{name}/groups/batch [post]
+func (h *TierHandler) AddGroups(c *gin.Context) {
+	partial, err := parseBoolQuery(c, "partial")
+	if err != nil {
+		respondQueryError(c, err)
+		return
+	}
+
+	var req models.BatchAddGroupsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	response, err := h.service.AddGroups(c.Request.Context(), c.Param("name"), &req, partial)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	if !response.Applied {
+		c.JSON(http.StatusUnprocessableEntity, response)
+		return
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// CopyGroups handles POST /api/v1/tiers/:name/copy-groups-from/:source
+// @Summary      Copy groups from another tier
+// @Description  Add every group of the source tier that the target tier does not already list, leaving the source tier and the target's description and level unchanged. Copied groups must exist in the cluster unless they are wildcard patterns.
+// @Tags         groups
+// @Produce      json
+// @Param        name    path      string  true  "Target tier name"
+// @Param        source  path      string  true  "Source tier name"
+// @Success      200     {object}  models.Tier  "Updated target tier"
+// @Failure      422     {object}  ErrorResponse  "Unprocessable entity - a copied group does not exist in the cluster"
+// @Failure      404     {object}  ErrorResponse  "Target or source tier not found"
+// @Failure      500     {object}  ErrorResponse  "Internal server error"
+// @Router       /tiers/{name}/copy-groups-from/{source} [post]
+func (h *TierHandler) CopyGroups(c *gin.Context) {
+	tier, err := h.service.CopyGroups(c.Request.Context(), c.Param("name"), c.Param("source"))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		respondError(c, err)
 		return
 	}
 
@@ -284,24 +790,15 @@ func (h *TierHandler) RemoveGroup(c *gin.Context) {
 	tierName := c.Param("name")
 	groupName := c.Param("group")
 
-	if err := h.service.RemoveGroup(tierName, groupName); err != nil {
-		switch err {
-		case models.ErrTierNotFound:
-			c.JSON(http.StatusNotFound, ErrorResponse{Error: err.Error()})
-		case models.ErrGroupNotFound:
-			c.JSON(http.StatusNotFound, ErrorResponse{Error: err.Error()})
-		case models.ErrGroupRequired, models.ErrInvalidKubernetesName:
-			c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
-		default:
-			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
-		}
+	if err := h.service.RemoveGroup(c.Request.Context(), tierName, groupName); err != nil {
+		respondError(c, err)
 		return
 	}
 
 	// Return updated tier
-	tier, err := h.service.GetTier(tierName)
+	tier, err := h.service.GetTier(c.Request.Context(), tierName)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		respondError(c, err)
 		return
 	}
 
@@ -313,58 +810,305 @@ func (h *TierHandler) RemoveGroup(c *gin.Context) {
 // @Description  Retrieve all tiers that contain the specified Kubernetes group
 // @Tags         groups
 // @Produce      json
-// @Param        group  path      string  true  "Group name"
-// @Success      200    {array}   models.Tier  "List of tiers containing the group"
-// @Failure      400    {object}  ErrorResponse  "Bad request - invalid group name format"
+// @Param        group      path      string  true   "Group name; URL-encode colons (system%3Aauthenticated) if a proxy rejects them, or use GET /tiers?group="
+// @Param        effective  query     bool    false  "Also include tiers matched implicitly through system:authenticated, ordered by level"
+// @Param        paginate   query     bool    false  "Wrap the list in a models.ListResponse envelope"
+// @Success      200    {array}   models.Tier  "List of tiers containing the group (models.ListResponse when paginate=true)"
+// @Failure      422    {object}  ErrorResponse  "Unprocessable entity - invalid group name format"
 // @Failure      500    {object}  ErrorResponse  "Internal server error"
 // @Router       /groups/{group}/tiers [get]
 func (h *TierHandler) GetTiersByGroup(c *gin.Context) {
 	groupName := c.Param("group")
-	tiers, err := h.service.GetTiersByGroup(groupName)
+
+	effective, err := parseBoolQuery(c, "effective")
 	if err != nil {
-		if err == models.ErrInvalidKubernetesName {
-			c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
-		} else {
-			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
-		}
+		respondQueryError(c, err)
+		return
+	}
+	paginate, err := parseBoolQuery(c, "paginate")
+	if err != nil {
+		respondQueryError(c, err)
+		return
+	}
+
+	var tiers []models.Tier
+	if effective {
+		tiers, err = h.service.GetEffectiveTiersByGroup(c.Request.Context(), groupName)
+	} else {
+		tiers, err = h.service.GetTiersByGroup(c.Request.Context(), groupName)
+	}
+	if err != nil {
+		respondError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, tiers)
+	respondList(c, http.StatusOK, jsonResponse, paginate, tiers, len(tiers), "")
 }
 
-// GetLLMInferenceServicesByTier handles GET /api/v1/tiers/:name/llminferenceservices
-// @Summary      Get LLMInferenceServices by tier
-// @Description  Retrieve all LLMInferenceService instances that have the specified tier in their annotation
-// @Tags         llminferenceservices
+// RemoveGroupFromAllTiers handles DELETE /api/v1/groups/:group
+// @Summary      Remove a group from every tier
+// @Description  Remove the group (or wildcard group pattern) from every tier that lists it, in a single save, and return the tiers that were modified. Use dryRun=true to preview.
+// @Tags         groups
 // @Produce      json
-// @Param        name  path      string  true  "Tier name"
-// @Success      200   {array}   models.LLMInferenceService  "List of LLMInferenceService instances with the tier"
+// @Param        group   path      string  true   "Group name or wildcard group pattern"
+// @Param        dryRun  query     bool    false  "Report affected tiers without modifying them"
+// @Success      200     {object}  models.RemoveGroupResponse  "Tiers the group was removed from"
+// @Failure      400     {object}  ErrorResponse  "Bad request - invalid query parameter"
+// @Failure      422     {object}  ErrorResponse  "Unprocessable entity - invalid group name format"
+// @Failure      500     {object}  ErrorResponse  "Internal server error"
+// @Router       /groups/{group} [delete]
+func (h *TierHandler) RemoveGroupFromAllTiers(c *gin.Context) {
+	dryRun, err := parseBoolQuery(c, "dryRun")
+	if err != nil {
+		respondQueryError(c, err)
+		return
+	}
+
+	response, err := h.service.RemoveGroupFromAllTiers(c.Request.Context(), c.Param("group"), dryRun)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetUnassignedGroups handles GET /api/v1/groups/unassigned
+// @Summary      List groups not assigned to any tier
+// @Description  List the OpenShift groups in the cluster that no tier references, exactly or through a wildcard pattern, to find coverage gaps. Soft-deleted tiers do not count. system: groups are skipped unless includeSystem=true, which also considers the built-in special groups.
+// @Tags         groups
+// @Produce      json
+// @Param        includeSystem  query     bool  false  "Also report system: groups, including built-in special groups"
+// @Success      200            {object}  models.UnassignedGroupsResponse  "Groups no tier references"
+// @Failure      400            {object}  ErrorResponse  "Bad request - invalid query parameter"
+// @Failure      403            {object}  ErrorResponse  "Listing OpenShift groups is denied"
+// @Failure      500            {object}  ErrorResponse  "Internal server error"
+// @Router       /groups/unassigned [get]
+func (h *TierHandler) GetUnassignedGroups(c *gin.Context) {
+	includeSystem, err := parseBoolQuery(c, "includeSystem")
+	if err != nil {
+		respondQueryError(c, err)
+		return
+	}
+
+	response, err := h.service.GetUnassignedGroups(c.Request.Context(), includeSystem)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// RenameGroup handles POST /api/v1/groups/rename
+// @Summary      Rename a group across all tiers
+// @Description  Replace the from group with the to group in every tier that lists it, in a single save, and return the tiers that were modified. A tier that already lists the new name keeps a single entry. The new name must exist in the cluster unless it is a wildcard pattern.
+// @Tags         groups
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.RenameGroupRequest  true  "Current and new group name"
+// @Success      200      {object}  models.RenameGroupResponse  "Tiers that were modified"
+// @Failure      400      {object}  ErrorResponse  "Bad request - invalid request body"
+// @Failure      422      {object}  ErrorResponse  "Unprocessable entity - invalid group name or new group not found in cluster"
+// @Failure      500      {object}  ErrorResponse  "Internal server error"
+// @Router       /groups/rename [post]
+func (h *TierHandler) RenameGroup(c *gin.Context) {
+	var req models.RenameGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	response, err := h.service.RenameGroup(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ValidateGroup handles POST /api/v1/groups/validate
+// @Summary      Validate a group name
+// @Description  Check a raw group name against the server's group name rules without looking it up in the cluster. The response reports whether the name is accepted as submitted and, with the reason and error code when it is not, its normalized (trimmed, lowercased) form and whether that form is accepted.
+// @Tags         groups
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.ValidateGroupRequest  true  "Group name to validate"
+// @Success      200      {object}  models.ValidateGroupResponse  "Validation result"
+// @Failure      400      {object}  ErrorResponse  "Bad request - invalid request body"
+// @Router       /groups/validate [post]
+func (h *TierHandler) ValidateGroup(c *gin.Context) {
+	var req models.ValidateGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	normalized := models.NormalizeGroupName(req.Group)
+	response := models.ValidateGroupResponse{
+		Group:           req.Group,
+		Valid:           true,
+		Normalized:      normalized,
+		NormalizedValid: models.ValidateGroupName(normalized) == nil,
+	}
+	if err := models.ValidateGroupName(req.Group); err != nil {
+		response.Valid = false
+		response.Reason = err.Error()
+		response.Code, _ = classifyError(err)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ResolveTiers handles POST /api/v1/tiers/resolve
+// @Summary      Resolve tiers for a set of groups
+// @Description  Return every tier the caller's groups qualify for, highest level first, the winning tier, and why it won: each candidate with its level and matching groups, and the tiebreak rule applied. Tiers containing system:authenticated match every authenticated caller unless implicit matching is disabled.
+// @Tags         tiers
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.ResolveRequest  true  "Caller groups"
+// @Success      200      {object}  models.ResolveResponse  "Matching tiers and the winner"
+// @Failure      400      {object}  ErrorResponse  "Bad request - invalid request body"
+// @Failure      422      {object}  ErrorResponse  "Unprocessable entity - invalid group name format"
+// @Failure      500      {object}  ErrorResponse  "Internal server error"
+// @Router       /tiers/resolve [post]
+func (h *TierHandler) ResolveTiers(c *gin.Context) {
+	var req models.ResolveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+	if err := req.Validate(); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	matches, err := h.service.ResolveTiers(c.Request.Context(), req.Groups)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	response := models.ResolveResponse{Matches: matches, Why: service.ExplainResolution(matches)}
+	if len(matches) > 0 {
+		response.Winner = &matches[0].Tier
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// GetTiersForUser handles GET /api/v1/users/:user/tiers
+// @Summary      Get tiers for a user
+// @Description  Look up the OpenShift groups whose users include the user and return the tiers those groups resolve to, with the groups that matched each tier. Tiers containing system:authenticated match every user unless implicit matching is disabled.
+// @Tags         users
+// @Produce      json
+// @Param        user  path      string  true  "User name"
+// @Success      200   {object}  models.UserTiersResponse  "User's groups and matching tiers"
+// @Failure      422   {object}  ErrorResponse  "Unprocessable entity - invalid user name"
+// @Failure      404   {object}  ErrorResponse  "User not found"
+// @Failure      500   {object}  ErrorResponse  "Internal server error"
+// @Router       /users/{user}/tiers [get]
+func (h *TierHandler) GetTiersForUser(c *gin.Context) {
+	username := c.Param("user")
+
+	response, err := h.service.GetTiersForUser(c.Request.Context(), username)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetLLMInferenceServicesSummaryByTier handles GET /api/v1/tiers/:name/llminferenceservices/summary
+// @Summary      Summarize LLMInferenceServices by tier
+// @Description  Return the number of LLMInferenceServices annotated with the tier and a breakdown by namespace, largest namespace first, without listing the services themselves.
+// @Tags         llminferenceservices
+// @Produce      json
+// @Param        name  path      string  true  "Tier name"
+// @Success      200   {object}  models.TierServicesSummary  "Total and per-namespace service counts"
+// @Failure      404   {object}  ErrorResponse  "Tier not found"
+// @Failure      500   {object}  ErrorResponse  "Internal server error"
+// @Router       /tiers/{name}/llminferenceservices/summary [get]
+func (h *TierHandler) GetLLMInferenceServicesSummaryByTier(c *gin.Context) {
+	tierName := c.Param("name")
+
+	// Verify tier exists
+	if _, err := h.service.GetTier(c.Request.Context(), tierName); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	summary, err := h.llmServiceService.SummarizeLLMInferenceServicesByTier(c.Request.Context(), tierName)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
+
+// GetLLMInferenceServicesByTier handles GET /api/v1/tiers/:name/llminferenceservices
+// @Summary      Get LLMInferenceServices by tier
+// @Description  Retrieve all LLMInferenceService instances that have the specified tier in their annotation.
+// @Description  Pass limit (and then continue) to page through the cluster-wide listing; the response is then an LLMInferenceServicePage.
+// @Description  Filtering happens after each page is listed, so a page may contain fewer than limit services while a continue token is still returned.
+// @Description  Pass paginate=true to receive a models.ListResponse envelope instead, with or without limit.
+// @Tags         llminferenceservices
+// @Produce      json
+// @Param        name      path      string  true   "Tier name"
+// @Param        limit     query     int     false  "Maximum number of services to list per page"
+// @Param        continue  query     string  false  "Continue token from the previous page"
+// @Param        paginate  query     bool    false  "Wrap the list in a models.ListResponse envelope"
+// @Success      200   {array}   models.LLMInferenceService  "List of LLMInferenceService instances with the tier (models.LLMInferenceServicePage when paging with limit, models.ListResponse when paginate=true)"
+// @Failure      400   {object}  ErrorResponse  "Bad request - invalid limit or continue token"
 // @Failure      404   {object}  ErrorResponse  "Tier not found"
 // @Failure      500   {object}  ErrorResponse  "Internal server error"
 // @Router       /tiers/{name}/llminferenceservices [get]
 func (h *TierHandler) GetLLMInferenceServicesByTier(c *gin.Context) {
 	tierName := c.Param("name")
-	
+
+	limit, err := parseLimitQuery(c)
+	if err != nil {
+		respondQueryError(c, err)
+		return
+	}
+	continueToken := c.Query("continue")
+	paginate, err := parseBoolQuery(c, "paginate")
+	if err != nil {
+		respondQueryError(c, err)
+		return
+	}
+
 	// Verify tier exists
-	_, err := h.service.GetTier(tierName)
+	_, err = h.service.GetTier(c.Request.Context(), tierName)
 	if err != nil {
-		if err == models.ErrTierNotFound {
-			c.JSON(http.StatusNotFound, ErrorResponse{Error: err.Error()})
-		} else {
-			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		respondError(c, err)
+		return
+	}
+
+	if limit > 0 || continueToken != "" {
+		page, err := h.llmServiceService.GetLLMInferenceServicesByTierPage(c.Request.Context(), tierName, limit, continueToken)
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+		if paginate {
+			respondList(c, http.StatusOK, jsonResponse, true, page.Items, len(page.Items), page.Continue)
+			return
 		}
+		c.JSON(http.StatusOK, page)
 		return
 	}
 
 	// Get LLMInferenceServices for this tier
-	services, err := h.llmServiceService.GetLLMInferenceServicesByTier(tierName)
+	services, err := h.llmServiceService.GetLLMInferenceServicesByTier(c.Request.Context(), tierName)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		respondError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, services)
+	respondList(c, http.StatusOK, jsonResponse, paginate, services, len(services), "")
 }
 
 // GetLLMInferenceServicesByGroup handles GET /api/v1/groups/:group/llminferenceservices
@@ -372,9 +1116,10 @@ func (h *TierHandler) GetLLMInferenceServicesByTier(c *gin.Context) {
 // @Description  Retrieve all LLMInferenceService instances associated with the specified group (via tiers)
 // @Tags         llminferenceservices
 // @Produce      json
-// @Param        group  path      string  true  "Group name"
-// @Success      200    {array}   models.LLMInferenceService  "List of LLMInferenceService instances for the group"
-// @Failure      400    {object}  ErrorResponse  "Bad request - invalid group name format"
+// @Param        group     path      string  true   "Group name"
+// @Param        paginate  query     bool    false  "Wrap the list in a models.ListResponse envelope"
+// @Success      200    {array}   models.LLMInferenceService  "List of LLMInferenceService instances for the group (models.ListResponse when paginate=true)"
+// @Failure      422    {object}  ErrorResponse  "Unprocessable entity - invalid group name format"
 // @Failure      500    {object}  ErrorResponse  "Internal server error"
 // @Router       /groups/{group}/llminferenceservices [get]
 func (h *TierHandler) GetLLMInferenceServicesByGroup(c *gin.Context) {
@@ -382,16 +1127,452 @@ func (h *TierHandler) GetLLMInferenceServicesByGroup(c *gin.Context) {
 	
 	// Validate group name format
 	if err := models.ValidateGroupName(groupName); err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		respondError(c, err)
+		return
+	}
+	paginate, err := parseBoolQuery(c, "paginate")
+	if err != nil {
+		respondQueryError(c, err)
 		return
 	}
 
 	// Get LLMInferenceServices for this group
-	services, err := h.llmServiceService.GetLLMInferenceServicesByGroup(groupName)
+	services, err := h.llmServiceService.GetLLMInferenceServicesByGroup(c.Request.Context(), groupName)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	respondList(c, http.StatusOK, jsonResponse, paginate, services, len(services), "")
+}
+
+// GetGroupImpact handles GET /api/v1/groups/:group/impact
+// @Summary      Report the impact of removing a group
+// @Description  List the tiers containing the group, exactly or through a wildcard pattern, and the distinct LLMInferenceServices annotated with any of them, with counts. These are the services the group would lose access to if it were removed from those tiers. Requires a cluster-wide LLMInferenceService list per tier.
+// @Tags         groups
+// @Produce      json
+// @Param        group  path      string  true  "Group name"
+// @Success      200    {object}  models.GroupImpact  "Tiers and services affected by removing the group"
+// @Failure      422    {object}  ErrorResponse  "Unprocessable entity - invalid group name format"
+// @Failure      500    {object}  ErrorResponse  "Internal server error"
+// @Router       /groups/{group}/impact [get]
+func (h *TierHandler) GetGroupImpact(c *gin.Context) {
+	impact, err := h.llmServiceService.GetGroupImpact(c.Request.Context(), c.Param("group"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, impact)
+}
+
+// PruneTiers handles POST /api/v1/admin/prune-tiers
+// @Summary      Prune empty or unreferenced tiers
+// @Description  Delete the tiers matching criteria: empty (no groups), unreferenced (no LLMInferenceService annotated with the tier) or both (the default, requiring both). Pass dryRun=true to preview; otherwise confirm=true is required. Pruned tiers are soft-deleted when soft delete is enabled.
+// @Tags         admin
+// @Produce      json
+// @Param        criteria  query     string  false  "Which tiers to prune: empty, unreferenced or both (default both)"
+// @Param        dryRun    query     bool    false  "Report matching tiers without deleting them"
+// @Param        confirm   query     bool    false  "Required to delete when dryRun is not set"
+// @Success      200       {object}  models.PruneTiersResponse  "Tiers that were pruned"
+// @Failure      400       {object}  ErrorResponse  "Bad request - invalid query parameter or confirmation missing"
+// @Failure      409       {object}  ErrorResponse  "An LLMInferenceService has an invalid tiers annotation"
+// @Failure      500       {object}  ErrorResponse  "Internal server error"
+// @Router       /admin/prune-tiers [post]
+func (h *TierHandler) PruneTiers(c *gin.Context) {
+	criteria, err := models.ParsePruneCriteria(c.Query("criteria"))
+	if err != nil {
+		respondQueryError(c, err)
+		return
+	}
+	dryRun, err := parseBoolQuery(c, "dryRun")
+	if err != nil {
+		respondQueryError(c, err)
+		return
+	}
+	confirm, err := parseBoolQuery(c, "confirm")
+	if err != nil {
+		respondQueryError(c, err)
+		return
+	}
+	if !dryRun && !confirm {
+		respondError(c, models.ErrConfirmationRequired)
+		return
+	}
+
+	response, err := h.llmServiceService.PruneTiers(c.Request.Context(), criteria, dryRun)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ReconcileTiers handles POST /api/v1/admin/reconcile
+// @Summary      Reconcile tiers annotations against the tier configuration
+// @Description  Scan every LLMInferenceService and remove tiers from its annotation that no longer exist in the tier configuration. Soft-deleted tiers still exist and are kept. Set checkAccess=true to also warn about services whose remaining tiers grant access to no group. Each service is reported separately; pass dryRun=true to preview without updating anything.
+// @Tags         admin
+// @Produce      json
+// @Param        dryRun       query     bool  false  "Report orphaned tiers without updating services"
+// @Param        checkAccess  query     bool  false  "Warn about services whose remaining tiers have no groups"
+// @Success      200          {object}  models.ReconcileResponse  "Per-service reconcile results"
+// @Failure      400          {object}  ErrorResponse  "Bad request - invalid query parameter"
+// @Failure      500          {object}  ErrorResponse  "Internal server error"
+// @Router       /admin/reconcile [post]
+func (h *TierHandler) ReconcileTiers(c *gin.Context) {
+	dryRun, err := parseBoolQuery(c, "dryRun")
+	if err != nil {
+		respondQueryError(c, err)
+		return
+	}
+	checkAccess, err := parseBoolQuery(c, "checkAccess")
+	if err != nil {
+		respondQueryError(c, err)
+		return
+	}
+
+	response, err := h.llmServiceService.ReconcileTiers(c.Request.Context(), dryRun, checkAccess)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// MigrateAnnotations handles POST /api/v1/admin/migrate-annotations
+// @Summary      Migrate tiers annotations to a new key
+// @Description  Copy the tier list of every LLMInferenceService carrying oldKey to newKey. Services that already have newKey keep its tiers, merged with the old key's tiers without duplicates. Set removeOld to delete oldKey once merged. Each service is reported separately; pass dryRun=true to preview the merged tiers without updating anything.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.MigrateAnnotationsRequest  true  "Old and new annotation keys"
+// @Param        dryRun   query     bool                              false "Report the merged tiers without updating services"
+// @Success      200      {object}  models.MigrateAnnotationsResponse  "Per-service migration results"
+// @Failure      400      {object}  ErrorResponse  "Bad request - invalid request body"
+// @Failure      422      {object}  ErrorResponse  "Unprocessable entity - invalid annotation keys"
+// @Failure      500      {object}  ErrorResponse  "Internal server error"
+// @Router       /admin/migrate-annotations [post]
+func (h *TierHandler) MigrateAnnotations(c *gin.Context) {
+	var req models.MigrateAnnotationsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+	dryRun, err := parseBoolQuery(c, "dryRun")
+	if err != nil {
+		respondQueryError(c, err)
+		return
+	}
+
+	response, err := h.llmServiceService.MigrateAnnotations(c.Request.Context(), &req, dryRun)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetTierReport handles GET /api/v1/reports/tiers
+// @Summary      Get the tier usage report
+// @Description  One row per tier with its level, group count and, unless includeServices=false, the number of LLMInferenceServices annotated with it and their namespaces. Rows are ordered by level descending. Including services scans every LLMInferenceService in the cluster once; services with an invalid tiers annotation are skipped and counted.
+// @Tags         reports
+// @Produce      json
+// @Param        includeServices  query     bool  false  "Scan live LLMInferenceServices for usage (default true)"
+// @Success      200              {object}  models.TierReport  "Per-tier usage rows"
+// @Failure      400              {object}  ErrorResponse  "Bad request - invalid query parameter"
+// @Failure      500              {object}  ErrorResponse  "Internal server error"
+// @Router       /reports/tiers [get]
+func (h *TierHandler) GetTierReport(c *gin.Context) {
+	includeServices := true
+	if c.Query("includeServices") != "" {
+		var err error
+		if includeServices, err = parseBoolQuery(c, "includeServices"); err != nil {
+			respondQueryError(c, err)
+			return
+		}
+	}
+
+	report, err := h.llmServiceService.TierReport(c.Request.Context(), includeServices)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// GetModelsForUser handles GET /api/v1/users/:user/models
+// @Summary      Get LLMInferenceServices a user can access
+// @Description  Resolve the user's OpenShift groups into tiers, then return every LLMInferenceService annotated with one of those tiers, deduplicated, with the tiers that grant each. Tiers containing system:authenticated match every user unless implicit matching is disabled.
+// @Tags         users
+// @Produce      json
+// @Param        user  path      string  true  "User name"
+// @Success      200   {object}  models.UserModelsResponse  "User's groups, tiers and accessible services"
+// @Failure      422   {object}  ErrorResponse  "Unprocessable entity - invalid user name"
+// @Failure      403   {object}  ErrorResponse  "The server is not permitted to read OpenShift users or groups"
+// @Failure      404   {object}  ErrorResponse  "User not found"
+// @Failure      500   {object}  ErrorResponse  "Internal server error"
+// @Router       /users/{user}/models [get]
+func (h *TierHandler) GetModelsForUser(c *gin.Context) {
+	response, err := h.llmServiceService.GetModelsForUser(c.Request.Context(), c.Param("user"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetLLMInferenceService handles GET /api/v1/llminferenceservices/:namespace/:name
+// @Summary      Get an LLMInferenceService
+// @Description  Return one LLMInferenceService with each tier in its tiers annotation and whether that tier is still defined in the tier configuration.
+// @Tags         llminferenceservices
+// @Produce      json
+// @Param        namespace  path      string  true  "Namespace of the LLMInferenceService"
+// @Param        name       path      string  true  "Name of the LLMInferenceService"
+// @Success      200        {object}  models.LLMInferenceServiceDetail  "LLMInferenceService with resolved tiers"
+// @Failure      404        {object}  ErrorResponse  "LLMInferenceService or namespace not found"
+// @Failure      409        {object}  ErrorResponse  "The tiers annotation is invalid"
+// @Failure      500        {object}  ErrorResponse  "Internal server error"
+// @Router       /llminferenceservices/{namespace}/{name} [get]
+func (h *TierHandler) GetLLMInferenceService(c *gin.Context) {
+	detail, err := h.llmServiceService.GetLLMInferenceService(c.Request.Context(), c.Param("namespace"), c.Param("name"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, detail)
+}
+
+// CreateLLMInferenceService handles POST /api/v1/llminferenceservices
+// @Summary      Create an LLMInferenceService with a tier
+// @Description  Create a minimal LLMInferenceService serving a model URI, with its tiers annotation set to the requested tier. The tier must exist. Only available when ENABLE_LLM_SERVICE_CREATE is true. An omitted namespace defaults to DEFAULT_LLM_NAMESPACE when configured.
+// @Tags         llminferenceservices
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.CreateLLMInferenceServiceRequest  true  "LLMInferenceService to create"
+// @Success      201      {object}  models.LLMInferenceService  "Created LLMInferenceService"
+// @Failure      400      {object}  ErrorResponse  "Bad request - invalid request body"
+// @Failure      422      {object}  ErrorResponse  "Unprocessable entity - validation error"
+// @Failure      404      {object}  ErrorResponse  "Tier or namespace not found"
+// @Failure      409      {object}  ErrorResponse  "LLMInferenceService already exists"
+// @Failure      500      {object}  ErrorResponse  "Internal server error"
+// @Router       /llminferenceservices [post]
+func (h *TierHandler) CreateLLMInferenceService(c *gin.Context) {
+	var req models.CreateLLMInferenceServiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	created, err := h.llmServiceService.CreateLLMInferenceService(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, created)
+}
+
+// AnnotateLLMInferenceService handles POST /api/v1/llminferenceservices/annotate
+// @Summary      Add a tier to an LLMInferenceService
+// @Description  Add a tier to the tiers annotation of an LLMInferenceService. The tier must exist. Adding a tier that is already present is a no-op. An omitted namespace defaults to DEFAULT_LLM_NAMESPACE when configured. With dryRun=true the tier and service are checked and the resulting tiers returned, but the service is not modified.
+// @Tags         llminferenceservices
+// @Accept       json
+// @Produce      json
+// @Param        dryRun   query     bool                    false  "Check the change and return the resulting tiers without applying it"
+// @Param        request  body      models.AnnotateRequest  true   "LLMInferenceService and tier to add"
+// @Success      200      {object}  models.AnnotateResult   "Resulting tiers on the LLMInferenceService"
+// @Failure      400      {object}  ErrorResponse  "Bad request - invalid request body"
+// @Failure      422      {object}  ErrorResponse  "Unprocessable entity - validation error"
+// @Failure      404      {object}  ErrorResponse  "Tier or LLMInferenceService not found"
+// @Failure      409      {object}  ErrorResponse  "The LLMInferenceService changed while it was being annotated"
+// @Failure      500      {object}  ErrorResponse  "Internal server error"
+// @Router       /llminferenceservices/annotate [post]
+func (h *TierHandler) AnnotateLLMInferenceService(c *gin.Context) {
+	dryRun, err := parseBoolQuery(c, "dryRun")
+	if err != nil {
+		respondQueryError(c, err)
+		return
+	}
+
+	var req models.AnnotateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	tiers, err := h.llmServiceService.AnnotateLLMInferenceServiceWithTier(c.Request.Context(), &req, dryRun)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	status := models.AnnotateStatusSucceeded
+	if dryRun {
+		status = models.AnnotateStatusDryRun
+	}
+	c.JSON(http.StatusOK, models.AnnotateResult{
+		Namespace: req.Namespace,
+		Name:      req.Name,
+		Status:    status,
+		Tiers:     tiers,
+	})
+}
+
+// BatchAnnotateLLMInferenceServices handles POST /api/v1/llminferenceservices/annotate/batch
+// @Summary      Add a tier to many LLMInferenceServices
+// @Description  Add a tier to the tiers annotation of each target LLMInferenceService. The tier is validated once; individual target failures are reported per target and do not abort the batch.
+// @Tags         llminferenceservices
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.BatchAnnotateRequest   true  "Tier and LLMInferenceServices to annotate"
+// @Success      200      {object}  models.BatchAnnotateResponse  "Per-target results"
+// @Failure      400      {object}  ErrorResponse  "Bad request - invalid request body"
+// @Failure      422      {object}  ErrorResponse  "Unprocessable entity - validation error"
+// @Failure      404      {object}  ErrorResponse  "Tier not found"
+// @Failure      500      {object}  ErrorResponse  "Internal server error"
+// @Router       /llminferenceservices/annotate/batch [post]
+func (h *TierHandler) BatchAnnotateLLMInferenceServices(c *gin.Context) {
+	var req models.BatchAnnotateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	response, err := h.llmServiceService.BatchAnnotateLLMInferenceServices(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// RemoveTierFromLLMInferenceService handles DELETE /api/v1/llminferenceservices/annotate
+// @Summary      Remove a tier from an LLMInferenceService
+// @Description  Remove a tier from the tiers annotation of an LLMInferenceService. The tier does not need to exist in the tier configuration. An omitted namespace defaults to DEFAULT_LLM_NAMESPACE when configured.
+// @Tags         llminferenceservices
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.RemoveTierRequest  true  "LLMInferenceService and tier to remove"
+// @Success      200      {object}  models.AnnotateResult     "Remaining tiers on the LLMInferenceService"
+// @Failure      400      {object}  ErrorResponse  "Bad request - invalid request body"
+// @Failure      422      {object}  ErrorResponse  "Unprocessable entity - validation error"
+// @Failure      404      {object}  ErrorResponse  "LLMInferenceService not found or tier not in annotation"
+// @Failure      409      {object}  ErrorResponse  "The LLMInferenceService changed while the tier was being removed"
+// @Failure      500      {object}  ErrorResponse  "Internal server error"
+// @Router       /llminferenceservices/annotate [delete]
+func (h *TierHandler) RemoveTierFromLLMInferenceService(c *gin.Context) {
+	var req models.RemoveTierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	tiers, err := h.llmServiceService.RemoveTierFromLLMInferenceService(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.AnnotateResult{
+		Namespace: req.Namespace,
+		Name:      req.Name,
+		Status:    models.AnnotateStatusSucceeded,
+		Tiers:     tiers,
+	})
+}
+
+// AnnotateLLMInferenceServiceWithAllTiers handles POST /api/v1/llminferenceservices/annotate/all
+// @Summary      Add every tier to an LLMInferenceService
+// @Description  Merge the name of every configured tier into the tiers annotation of an LLMInferenceService in a single update, keeping the tiers it already holds. Intended for testing; only available when ENABLE_ANNOTATE_ALL_TIERS is true. An omitted namespace defaults to DEFAULT_LLM_NAMESPACE when configured.
+// @Tags         llminferenceservices
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.LLMInferenceServiceRef  true  "LLMInferenceService to annotate"
+// @Success      200      {object}  models.AnnotateAllTiersResponse  "Added tiers and the resulting tier list"
+// @Failure      400      {object}  ErrorResponse  "Bad request - invalid request body"
+// @Failure      422      {object}  ErrorResponse  "Unprocessable entity - validation error"
+// @Failure      404      {object}  ErrorResponse  "LLMInferenceService or namespace not found"
+// @Failure      409      {object}  ErrorResponse  "The LLMInferenceService has an invalid tiers annotation or changed while it was being annotated"
+// @Failure      500      {object}  ErrorResponse  "Internal server error"
+// @Router       /llminferenceservices/annotate/all [post]
+func (h *TierHandler) AnnotateLLMInferenceServiceWithAllTiers(c *gin.Context) {
+	var req models.LLMInferenceServiceRef
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	response, err := h.llmServiceService.AnnotateLLMInferenceServiceWithAllTiers(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ClearTiersFromLLMInferenceService handles DELETE /api/v1/llminferenceservices/annotate/all
+// @Summary      Remove all tiers from an LLMInferenceService
+// @Description  Set the tiers annotation of an LLMInferenceService to an empty list in a single update, whatever tiers it held. An omitted namespace defaults to DEFAULT_LLM_NAMESPACE when configured.
+// @Tags         llminferenceservices
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.LLMInferenceServiceRef  true  "LLMInferenceService to clear"
+// @Success      200      {object}  models.ClearTiersResponse  "Removed tiers and the resulting empty list"
+// @Failure      400      {object}  ErrorResponse  "Bad request - invalid request body"
+// @Failure      422      {object}  ErrorResponse  "Unprocessable entity - validation error"
+// @Failure      404      {object}  ErrorResponse  "LLMInferenceService or namespace not found"
+// @Failure      409      {object}  ErrorResponse  "The LLMInferenceService changed while its tiers were being cleared"
+// @Failure      500      {object}  ErrorResponse  "Internal server error"
+// @Router       /llminferenceservices/annotate/all [delete]
+func (h *TierHandler) ClearTiersFromLLMInferenceService(c *gin.Context) {
+	var req models.LLMInferenceServiceRef
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondBindError(c, err)
+		return
+	}
+
+	response, err := h.llmServiceService.ClearTiersFromLLMInferenceService(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// UnlinkTier handles POST /api/v1/tiers/:name/unlink
+// @Summary      Remove a tier from every LLMInferenceService
+// @Description  Remove the tier from the tiers annotation of every LLMInferenceService that has it. The tier does not need to exist in the tier configuration, so an admin can unlink before or after deleting it. Use dryRun=true to preview.
+// @Tags         llminferenceservices
+// @Produce      json
+// @Param        name    path      string  true   "Tier name"
+// @Param        dryRun  query     bool    false  "Report matching services without modifying them"
+// @Success      200     {object}  models.UnlinkTierResponse  "Counts and per-service results"
+// @Failure      400     {object}  ErrorResponse  "Bad request - invalid query parameter"
+// @Failure      500     {object}  ErrorResponse  "Internal server error"
+// @Router       /tiers/{name}/unlink [post]
+func (h *TierHandler) UnlinkTier(c *gin.Context) {
+	tierName := c.Param("name")
+
+	dryRun, err := parseBoolQuery(c, "dryRun")
+	if err != nil {
+		respondQueryError(c, err)
+		return
+	}
+
+	response, err := h.llmServiceService.UnlinkTier(c.Request.Context(), tierName, dryRun)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		respondError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, services)
+	c.JSON(http.StatusOK, response)
 }
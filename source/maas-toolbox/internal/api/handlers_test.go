@@ -15,41 +15,118 @@
 package api
 
 import (
+	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
 	"maas-toolbox/internal/models"
 	"maas-toolbox/internal/service"
 	"maas-toolbox/internal/storage"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/yaml.v3"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
 	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
 )
 
+// testClusterGroups are the OpenShift groups that exist in the fake cluster
+var testClusterGroups = []string{"premium-users", "vip-users", "enterprise-users"}
+
+// newFakeDynamicClient creates a fake dynamic client holding the given OpenShift groups
+func newFakeDynamicClient(groups ...string) *dynamicfake.FakeDynamicClient {
+	objects := make([]runtime.Object, 0, len(groups))
+	for _, group := range groups {
+		objects = append(objects, &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "user.openshift.io/v1",
+			"kind":       "Group",
+			"metadata":   map[string]interface{}{"name": group},
+		}})
+	}
+	return dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		{Group: "user.openshift.io", Version: "v1", Resource: "groups"}:                     "GroupList",
+		{Group: "user.openshift.io", Version: "v1", Resource: "users"}:                      "UserList",
+		{Group: "serving.kserve.io", Version: "v1alpha1", Resource: "llminferenceservices"}: "LLMInferenceServiceList",
+	}, objects...)
+}
+
+// newTestLLMServiceService creates an LLMInferenceServiceService backed by an empty fake cluster
+func newTestLLMServiceService(tierService *service.TierService) *service.LLMInferenceServiceService {
+	return service.NewLLMInferenceServiceService(tierService, storage.NewLLMInferenceServiceStorage(newFakeDynamicClient()))
+}
+
 // createEmptyMockK8sStorage creates a mock storage with no ConfigMap (will return empty)
+// and the test cluster groups
 func createEmptyMockK8sStorage() *storage.K8sTierStorage {
 	client := fake.NewSimpleClientset()
-	return storage.NewK8sTierStorage(client, "test", "tier-to-group-mapping")
+	return storage.NewK8sTierStorage(client, newFakeDynamicClient(testClusterGroups...), "test", "tier-to-group-mapping")
 }
 
 func setupTestRouter() (*gin.Engine, *TierHandler) {
+	return setupTestRouterWithStorage(createEmptyMockK8sStorage())
+}
+
+// setupTestRouterWithStorage creates the test router over the given tier storage
+func setupTestRouterWithStorage(mockStore *storage.K8sTierStorage) (*gin.Engine, *TierHandler) {
 	gin.SetMode(gin.TestMode)
-	mockStore := createEmptyMockK8sStorage()
 	tierService := service.NewTierService(mockStore)
-	handler := NewTierHandler(tierService)
+	handler := NewTierHandler(tierService, newTestLLMServiceService(tierService))
 	router := gin.New()
-	v1 := router.Group("/api/v1")
+	v1 := router.Group("/api/v1", limitRequestBody(DefaultMaxRequestBodyBytes))
 	{
 		v1.POST("/tiers", handler.CreateTier)
 		v1.GET("/tiers", handler.GetTiers)
+		v1.PUT("/tiers", handler.EnsureTier)
+		v1.POST("/tiers/resolve", handler.ResolveTiers)
+		v1.POST("/tiers/import", handler.ImportTiers)
+		v1.POST("/tiers/purge", handler.PurgeTiers)
+		v1.DELETE("/tiers/batch", handler.DeleteTiers)
+		v1.GET("/tiers/stream", handler.StreamTierChanges)
 		v1.GET("/tiers/:name", handler.GetTier)
+		v1.HEAD("/tiers/:name", handler.HeadTier)
 		v1.PUT("/tiers/:name", handler.UpdateTier)
+		v1.PATCH("/tiers/:name", handler.PatchTier)
+		v1.POST("/tiers/:name/level", handler.SetTierLevel)
+		v1.POST("/tiers/:name/move-level", handler.MoveTierLevel)
 		v1.DELETE("/tiers/:name", handler.DeleteTier)
+		v1.POST("/tiers/:name/restore", handler.RestoreTier)
 		v1.POST("/tiers/:name/groups", handler.AddGroup)
+		v1.POST("/tiers/:name/groups/batch", handler.AddGroups)
 		v1.DELETE("/tiers/:name/groups/:group", handler.RemoveGroup)
+		v1.POST("/tiers/:name/copy-groups-from/:source", handler.CopyGroups)
+		v1.POST("/groups/rename", handler.RenameGroup)
+		v1.POST("/groups/validate", handler.ValidateGroup)
+		v1.GET("/groups/unassigned", handler.GetUnassignedGroups)
 		v1.GET("/groups/:group/tiers", handler.GetTiersByGroup)
+		v1.DELETE("/groups/:group", handler.RemoveGroupFromAllTiers)
+		v1.GET("/tiers/:name/llminferenceservices", handler.GetLLMInferenceServicesByTier)
+		v1.GET("/tiers/:name/llminferenceservices/summary", handler.GetLLMInferenceServicesSummaryByTier)
+		v1.GET("/groups/:group/llminferenceservices", handler.GetLLMInferenceServicesByGroup)
+		v1.GET("/groups/:group/impact", handler.GetGroupImpact)
+		v1.GET("/users/:user/tiers", handler.GetTiersForUser)
+		v1.GET("/users/:user/models", handler.GetModelsForUser)
+		v1.GET("/llminferenceservices/:namespace/:name", handler.GetLLMInferenceService)
+		v1.POST("/llminferenceservices/annotate", handler.AnnotateLLMInferenceService)
+		v1.POST("/llminferenceservices/annotate/batch", handler.BatchAnnotateLLMInferenceServices)
+		v1.DELETE("/llminferenceservices/annotate", handler.RemoveTierFromLLMInferenceService)
+		v1.DELETE("/llminferenceservices/annotate/all", handler.ClearTiersFromLLMInferenceService)
+		v1.POST("/admin/prune-tiers", handler.PruneTiers)
+		v1.POST("/admin/migrate-annotations", handler.MigrateAnnotations)
+		v1.POST("/admin/reconcile", handler.ReconcileTiers)
+		v1.GET("/reports/tiers", handler.GetTierReport)
+		v1.POST("/tiers/:name/unlink", handler.UnlinkTier)
 	}
 	return router, handler
 }
@@ -179,7 +256,7 @@ func TestCreateTier_WithGroups(t *testing.T) {
 func TestCreateTier_VerifyGroupsDefaultedInStorage(t *testing.T) {
 	mockStore := createEmptyMockK8sStorage()
 	tierService := service.NewTierService(mockStore)
-	handler := NewTierHandler(tierService)
+	handler := NewTierHandler(tierService, newTestLLMServiceService(tierService))
 	router := gin.New()
 	gin.SetMode(gin.TestMode)
 	v1 := router.Group("/api/v1")
@@ -202,7 +279,7 @@ func TestCreateTier_VerifyGroupsDefaultedInStorage(t *testing.T) {
 	}
 
 	// Verify the tier was stored with empty groups
-	config, err := mockStore.Load()
+	config, err := mockStore.Load(context.Background())
 	if err != nil {
 		t.Fatalf("Failed to load config: %v", err)
 	}
@@ -366,6 +443,111 @@ func TestGetTiersByGroup_NoMatchingTiers(t *testing.T) {
 	}
 }
 
+func TestGetTiersByGroup_ColonGroup(t *testing.T) {
+	router, _ := setupTestRouter()
+	createTestTier(t, router, `{"name": "free", "description": "Free tier", "level": 1, "groups": ["system:authenticated"]}`)
+	createTestTier(t, router, `{"name": "premium", "description": "Premium tier", "level": 10, "groups": ["premium-users"]}`)
+
+	for _, url := range []string{
+		"/api/v1/groups/system:authenticated/tiers",
+		"/api/v1/groups/system%3Aauthenticated/tiers",
+		"/api/v1/tiers?group=system:authenticated",
+		"/api/v1/tiers?group=system%3Aauthenticated",
+	} {
+		t.Run(url, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", url, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+			}
+			var response []models.Tier
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if len(response) != 1 || response[0].Name != "free" {
+				t.Errorf("Expected only the free tier, got %+v", response)
+			}
+		})
+	}
+
+	// A group in no tier is an empty list, not null
+	req, _ := http.NewRequest("GET", "/api/v1/tiers?group=nobody", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK || strings.TrimSpace(w.Body.String()) != "[]" {
+		t.Errorf("Expected 200 with [], got %d: %s", w.Code, w.Body.String())
+	}
+
+	// The query parameter is validated like the path parameter
+	req, _ = http.NewRequest("GET", "/api/v1/tiers?group=InvalidGroup", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status %d, got %d", http.StatusUnprocessableEntity, w.Code)
+	}
+}
+
+func TestGetTiers_LabelSelector(t *testing.T) {
+	router, _ := setupTestRouter()
+	createTestTier(t, router, `{"name": "team-a", "description": "Team A", "level": 1, "metadata": {"owner": "team-a", "env": "prod"}}`)
+	createTestTier(t, router, `{"name": "team-b", "description": "Team B", "level": 2, "metadata": {"owner": "team-b"}}`)
+	createTestTier(t, router, `{"name": "shared", "description": "Shared", "level": 3}`)
+
+	tests := []struct {
+		selector string
+		want     []string
+	}{
+		{"owner=team-a", []string{"team-a"}},
+		{"owner==team-b", []string{"team-b"}},
+		{"owner!=team-a", []string{"team-b", "shared"}},
+		{"owner=team-a,env=prod", []string{"team-a"}},
+		{"owner in (team-a,team-b)", []string{"team-a", "team-b"}},
+		{"owner notin (team-a)", []string{"team-b", "shared"}},
+		{"owner", []string{"team-a", "team-b"}},
+		{"!owner", []string{"shared"}},
+		{"owner=nobody", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "/api/v1/tiers?labelSelector="+url.QueryEscape(tt.selector), nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+			}
+			var response []models.Tier
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			names := []string{}
+			for _, tier := range response {
+				names = append(names, tier.Name)
+			}
+			if !reflect.DeepEqual(names, tt.want) {
+				t.Errorf("Expected tiers %v, got %v", tt.want, names)
+			}
+		})
+	}
+
+	for _, selector := range []string{"owner in team-a", "=team-a", "owner=team a"} {
+		req, _ := http.NewRequest("GET", "/api/v1/tiers?labelSelector="+url.QueryEscape(selector), nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response ErrorResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+		if w.Code != http.StatusBadRequest || response.Code != CodeInvalidQueryParameter {
+			t.Errorf("%q: expected 400 %s, got %d %+v", selector, CodeInvalidQueryParameter, w.Code, response)
+		}
+	}
+}
+
 func TestGetTiersByGroup_InvalidGroupName(t *testing.T) {
 	router, _ := setupTestRouter()
 
@@ -374,8 +556,8 @@ func TestGetTiersByGroup_InvalidGroupName(t *testing.T) {
 	w := httptest.NewRecorder()
 	router.ServeHTTP(w, req)
 
-	if w.Code != http.StatusBadRequest {
-		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status %d, got %d", http.StatusUnprocessableEntity, w.Code)
 	}
 
 	var response ErrorResponse
@@ -387,3 +569,1973 @@ func TestGetTiersByGroup_InvalidGroupName(t *testing.T) {
 		t.Error("Expected error message in response")
 	}
 }
+
+func TestGetUnassignedGroups(t *testing.T) {
+	router, _ := setupTestRouter()
+	createTestTier(t, router, `{"name": "premium", "description": "Premium tier", "level": 10, "groups": ["premium-users"]}`)
+
+	req, _ := http.NewRequest("GET", "/api/v1/groups/unassigned", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var response models.UnassignedGroupsResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if response.IncludeSystem || response.ClusterGroups != len(testClusterGroups) || !reflect.DeepEqual(response.Groups, []string{"enterprise-users", "vip-users"}) {
+		t.Errorf("Unexpected unassigned groups: %+v", response)
+	}
+
+	req, _ = http.NewRequest("GET", "/api/v1/groups/unassigned?includeSystem=maybe", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for an invalid includeSystem, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestGetTier_InvalidIncludeUsage(t *testing.T) {
+	router, _ := setupTestRouter()
+
+	tierJSON := `{
+		"name": "free",
+		"description": "Free tier",
+		"level": 1
+	}`
+
+	req, _ := http.NewRequest("POST", "/api/v1/tiers", bytes.NewBufferString(tierJSON))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Failed to create tier: expected status %d, got %d", http.StatusCreated, w.Code)
+	}
+
+	// Without includeUsage the plain tier is returned
+	req, _ = http.NewRequest("GET", "/api/v1/tiers/free", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	if bytes.Contains(w.Body.Bytes(), []byte("serviceCount")) {
+		t.Errorf("serviceCount should only be present when includeUsage=true, got %s", w.Body.String())
+	}
+
+	// A non-boolean includeUsage is rejected
+	req, _ = http.NewRequest("GET", "/api/v1/tiers/free?includeUsage=maybe", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestGetLLMInferenceService_NotFound(t *testing.T) {
+	router, _ := setupTestRouter()
+
+	req, _ := http.NewRequest("GET", "/api/v1/llminferenceservices/models/missing", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusNotFound, w.Code, w.Body.String())
+	}
+	var response ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil || response.Code != CodeLLMServiceNotFound {
+		t.Errorf("Expected code %s, got %s", CodeLLMServiceNotFound, w.Body.String())
+	}
+}
+
+func TestBatchAnnotate_Validation(t *testing.T) {
+	router, _ := setupTestRouter()
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+	}{
+		{"missing tier", `{"targets": [{"namespace": "ns", "name": "svc"}]}`, http.StatusUnprocessableEntity},
+		{"no targets", `{"tier": "free", "targets": []}`, http.StatusUnprocessableEntity},
+		{"unknown tier", `{"tier": "missing", "targets": [{"namespace": "ns", "name": "svc"}]}`, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("POST", "/api/v1/llminferenceservices/annotate/batch", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestAnnotate_Validation(t *testing.T) {
+	router, _ := setupTestRouter()
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+	}{
+		{"missing namespace", `{"name": "svc", "tier": "free"}`, http.StatusUnprocessableEntity},
+		{"missing name", `{"namespace": "ns", "tier": "free"}`, http.StatusUnprocessableEntity},
+		{"missing tier", `{"namespace": "ns", "name": "svc"}`, http.StatusUnprocessableEntity},
+		{"malformed tier", `{"namespace": "ns", "name": "svc", "tier": "Not A Tier!"}`, http.StatusUnprocessableEntity},
+		{"tier ending in a hyphen", `{"namespace": "ns", "name": "svc", "tier": "free-"}`, http.StatusUnprocessableEntity},
+		{"unknown tier", `{"namespace": "ns", "name": "svc", "tier": "missing"}`, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("POST", "/api/v1/llminferenceservices/annotate", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestRemoveTier_Validation(t *testing.T) {
+	router, _ := setupTestRouter()
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"missing namespace", `{"name": "svc", "tier": "free"}`},
+		{"missing name", `{"namespace": "ns", "tier": "free"}`},
+		{"missing tier", `{"namespace": "ns", "name": "svc"}`},
+		{"malformed tier", `{"namespace": "ns", "name": "svc", "tier": "Not A Tier!"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("DELETE", "/api/v1/llminferenceservices/annotate", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusUnprocessableEntity {
+				t.Errorf("Expected status %d, got %d: %s", http.StatusUnprocessableEntity, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestClearTiers_Validation(t *testing.T) {
+	router, _ := setupTestRouter()
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantCode   string
+	}{
+		{"missing namespace", `{"name": "svc"}`, http.StatusUnprocessableEntity, CodeNamespaceRequired},
+		{"missing name", `{"namespace": "ns"}`, http.StatusUnprocessableEntity, CodeLLMServiceNameRequired},
+		{"missing service", `{"namespace": "ns", "name": "svc"}`, http.StatusNotFound, CodeLLMServiceNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("DELETE", "/api/v1/llminferenceservices/annotate/all", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			var response ErrorResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if response.Code != tt.wantCode {
+				t.Errorf("Expected code %s, got %s", tt.wantCode, response.Code)
+			}
+		})
+	}
+}
+
+func TestUnlinkTier_InvalidDryRun(t *testing.T) {
+	router, _ := setupTestRouter()
+
+	req, _ := http.NewRequest("POST", "/api/v1/tiers/free/unlink?dryRun=perhaps", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestAddGroups_Batch(t *testing.T) {
+	router, _ := setupTestRouter()
+	createTestTier(t, router, `{"name": "premium", "description": "Premium tier", "level": 10}`)
+
+	body := `{"groups": ["premium-users", "Bad_Group", "missing-users", "vip-users"]}`
+	tests := []struct {
+		name        string
+		url         string
+		wantStatus  int
+		wantApplied bool
+		wantGroups  []string
+	}{
+		{"invalid groups block the batch", "/api/v1/tiers/premium/groups/batch", http.StatusUnprocessableEntity, false, []string{}},
+		{"partial saves the valid groups", "/api/v1/tiers/premium/groups/batch?partial=true", http.StatusOK, true, []string{"premium-users", "vip-users"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("POST", tt.url, bytes.NewBufferString(body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			var response models.BatchAddGroupsResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if response.Applied != tt.wantApplied || response.Invalid != 2 || len(response.Results) != 4 {
+				t.Errorf("Expected applied=%v with 2 of 4 groups invalid, got %+v", tt.wantApplied, response)
+			}
+
+			req, _ = http.NewRequest("GET", "/api/v1/tiers/premium", nil)
+			w = httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			var tier models.Tier
+			if err := json.Unmarshal(w.Body.Bytes(), &tier); err != nil || !reflect.DeepEqual(tier.Groups, tt.wantGroups) {
+				t.Errorf("Expected groups %v, got %s", tt.wantGroups, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestAddGroup_Idempotent(t *testing.T) {
+	router, _ := setupTestRouter()
+
+	tierJSON := `{
+		"name": "free",
+		"description": "Free tier",
+		"level": 1,
+		"groups": ["system:authenticated"]
+	}`
+
+	req, _ := http.NewRequest("POST", "/api/v1/tiers", bytes.NewBufferString(tierJSON))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Failed to create tier: expected status %d, got %d", http.StatusCreated, w.Code)
+	}
+
+	groupJSON := `{"group": "system:authenticated"}`
+
+	// Strict mode (default) rejects the duplicate
+	req, _ = http.NewRequest("POST", "/api/v1/tiers/free/groups", bytes.NewBufferString(groupJSON))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusConflict {
+		t.Errorf("Expected status %d, got %d", http.StatusConflict, w.Code)
+	}
+
+	// Idempotent mode reports a no-op
+	req, _ = http.NewRequest("POST", "/api/v1/tiers/free/groups?idempotent=true", bytes.NewBufferString(groupJSON))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var response AddGroupResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if !response.WasNoOp {
+		t.Error("Expected wasNoOp to be true")
+	}
+	if response.Result != AddGroupResultAlreadyPresent {
+		t.Errorf("Expected result '%s', got '%s'", AddGroupResultAlreadyPresent, response.Result)
+	}
+	if response.Name != "free" || len(response.Groups) != 1 {
+		t.Errorf("Expected unchanged tier 'free' with 1 group, got %+v", response.Tier)
+	}
+}
+
+func TestCreateTier_ValidationErrorField(t *testing.T) {
+	router, _ := setupTestRouter()
+
+	tests := []struct {
+		name      string
+		body      string
+		wantCode  string
+		wantField string
+		wantValue string
+	}{
+		{"missing name", `{"description": "d", "level": 1}`, CodeTierNameRequired, models.FieldName, ""},
+		{"missing description", `{"name": "t", "level": 1}`, CodeTierDescriptionRequired, models.FieldDescription, ""},
+		{"negative level", `{"name": "t", "description": "d", "level": -1}`, CodeTierLevelInvalid, models.FieldLevel, ""},
+		{"invalid group", `{"name": "t", "description": "d", "level": 1, "groups": ["Bad_Group"]}`, CodeInvalidK8sName, models.FieldGroups, "Bad_Group"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("POST", "/api/v1/tiers", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusUnprocessableEntity {
+				t.Fatalf("Expected status %d, got %d: %s", http.StatusUnprocessableEntity, w.Code, w.Body.String())
+			}
+
+			var response ErrorResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if response.Code != tt.wantCode {
+				t.Errorf("Expected code %s, got %s", tt.wantCode, response.Code)
+			}
+			if response.Field != tt.wantField {
+				t.Errorf("Expected field %q, got %q", tt.wantField, response.Field)
+			}
+			if response.Value != tt.wantValue {
+				t.Errorf("Expected value %q, got %q", tt.wantValue, response.Value)
+			}
+		})
+	}
+}
+
+func TestCreateTier_DescriptionTooLong(t *testing.T) {
+	router, _ := setupTestRouter()
+
+	description := strings.Repeat("é", models.MaxTierDescriptionLength+1)
+	body := `{"name": "t", "description": "` + description + `", "level": 1}`
+	req, _ := http.NewRequest("POST", "/api/v1/tiers", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusUnprocessableEntity, w.Code, w.Body.String())
+	}
+	var response ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if response.Code != CodeTierDescriptionTooLong || response.Field != models.FieldDescription {
+		t.Errorf("Expected %s on field description, got %+v", CodeTierDescriptionTooLong, response)
+	}
+	if response.Length != models.MaxTierDescriptionLength+1 || response.MaxLength != models.MaxTierDescriptionLength {
+		t.Errorf("Expected length %d and maxLength %d in characters, got %d and %d",
+			models.MaxTierDescriptionLength+1, models.MaxTierDescriptionLength, response.Length, response.MaxLength)
+	}
+	if !strings.Contains(response.Error, "257 characters, maximum is 256") {
+		t.Errorf("Expected both lengths in the message, got %q", response.Error)
+	}
+}
+
+// createTestTier creates a tier through the API for tests that need existing data
+func createTestTier(t *testing.T, router *gin.Engine, tierJSON string) {
+	t.Helper()
+	req, _ := http.NewRequest("POST", "/api/v1/tiers", bytes.NewBufferString(tierJSON))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("Failed to create tier: status %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestGetTier_YAML(t *testing.T) {
+	router, _ := setupTestRouter()
+	createTestTier(t, router, `{"name": "free", "description": "Free tier", "level": 1, "groups": ["system:authenticated"]}`)
+
+	tests := []struct {
+		name   string
+		url    string
+		accept string
+	}{
+		{"accept header", "/api/v1/tiers/free", "application/yaml"},
+		{"format query", "/api/v1/tiers/free?format=yaml", ""},
+		{"format query overrides accept", "/api/v1/tiers/free?format=yaml", "application/json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", tt.url, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+			}
+			if got := w.Header().Get("Content-Type"); got != ContentTypeYAML {
+				t.Errorf("Expected Content-Type %q, got %q", ContentTypeYAML, got)
+			}
+
+			var tier models.Tier
+			if err := yaml.Unmarshal(w.Body.Bytes(), &tier); err != nil {
+				t.Fatalf("Failed to unmarshal YAML response: %v", err)
+			}
+			if tier.Name != "free" || tier.Description != "Free tier" || tier.Level != 1 {
+				t.Errorf("Unexpected tier from YAML: %+v", tier)
+			}
+			if len(tier.Groups) != 1 || tier.Groups[0] != "system:authenticated" {
+				t.Errorf("Expected groups [system:authenticated], got %v", tier.Groups)
+			}
+		})
+	}
+}
+
+func TestGetTiers_YAML(t *testing.T) {
+	router, _ := setupTestRouter()
+	createTestTier(t, router, `{"name": "free", "description": "Free tier", "level": 1}`)
+	createTestTier(t, router, `{"name": "premium", "description": "Premium tier", "level": 10}`)
+
+	req, _ := http.NewRequest("GET", "/api/v1/tiers", nil)
+	req.Header.Set("Accept", "application/yaml")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if got := w.Header().Get("Content-Type"); got != ContentTypeYAML {
+		t.Errorf("Expected Content-Type %q, got %q", ContentTypeYAML, got)
+	}
+
+	var tiers []models.Tier
+	if err := yaml.Unmarshal(w.Body.Bytes(), &tiers); err != nil {
+		t.Fatalf("Failed to unmarshal YAML response: %v", err)
+	}
+	if len(tiers) != 2 || tiers[0].Name != "free" || tiers[1].Name != "premium" {
+		t.Errorf("Unexpected tiers from YAML: %+v", tiers)
+	}
+}
+
+func TestGetTiers_DefaultsToJSON(t *testing.T) {
+	router, _ := setupTestRouter()
+
+	req, _ := http.NewRequest("GET", "/api/v1/tiers", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if got := w.Header().Get("Content-Type"); got != "application/json; charset=utf-8" {
+		t.Errorf("Expected JSON Content-Type, got %q", got)
+	}
+}
+
+func TestGetTiers_InvalidFormat(t *testing.T) {
+	router, _ := setupTestRouter()
+
+	req, _ := http.NewRequest("GET", "/api/v1/tiers?format=xml", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestGetTiers_Pretty(t *testing.T) {
+	router, _ := setupTestRouter()
+	createTestTier(t, router, `{"name": "free", "description": "Free tier", "level": 1}`)
+
+	tests := []struct {
+		name       string
+		path       string
+		wantIndent bool
+	}{
+		{"list defaults to compact", "/api/v1/tiers", false},
+		{"list pretty", "/api/v1/tiers?pretty=true", true},
+		{"get defaults to compact", "/api/v1/tiers/free", false},
+		{"get pretty", "/api/v1/tiers/free?pretty=true", true},
+		{"get pretty false", "/api/v1/tiers/free?pretty=false", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", tt.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+			}
+			if got := strings.Contains(w.Body.String(), "\n    "); got != tt.wantIndent {
+				t.Errorf("Expected indented=%v, got body %s", tt.wantIndent, w.Body.String())
+			}
+			if !json.Valid(w.Body.Bytes()) {
+				t.Errorf("Expected valid JSON, got %s", w.Body.String())
+			}
+		})
+	}
+
+	req, _ := http.NewRequest("GET", "/api/v1/tiers?pretty=maybe", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for an invalid pretty value, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestPatchTier(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		patch       string
+		wantStatus  int
+		wantCode    string
+		wantField   string
+		check       func(t *testing.T, tier models.Tier)
+	}{
+		{
+			name:        "json patch replace description",
+			contentType: "application/json-patch+json",
+			patch:       `[{"op": "replace", "path": "/description", "value": "Updated"}]`,
+			wantStatus:  http.StatusOK,
+			check: func(t *testing.T, tier models.Tier) {
+				if tier.Description != "Updated" || tier.Level != 1 {
+					t.Errorf("Unexpected patched tier: %+v", tier)
+				}
+			},
+		},
+		{
+			name:        "json patch add group",
+			contentType: "application/json-patch+json",
+			patch:       `[{"op": "add", "path": "/groups/-", "value": "system:authenticated"}]`,
+			wantStatus:  http.StatusOK,
+			check: func(t *testing.T, tier models.Tier) {
+				if len(tier.Groups) != 1 || tier.Groups[0] != "system:authenticated" {
+					t.Errorf("Expected groups [system:authenticated], got %v", tier.Groups)
+				}
+			},
+		},
+		{
+			name:        "merge patch level",
+			contentType: "application/merge-patch+json",
+			patch:       `{"level": 5}`,
+			wantStatus:  http.StatusOK,
+			check: func(t *testing.T, tier models.Tier) {
+				if tier.Level != 5 || tier.Description != "Free tier" {
+					t.Errorf("Unexpected patched tier: %+v", tier)
+				}
+			},
+		},
+		{
+			name:        "json patch changing name",
+			contentType: "application/json-patch+json",
+			patch:       `[{"op": "replace", "path": "/name", "value": "other"}]`,
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantCode:    CodeTierNameImmutable,
+			wantField:   models.FieldName,
+		},
+		{
+			name:        "json patch removing description",
+			contentType: "application/json-patch+json",
+			patch:       `[{"op": "remove", "path": "/description"}]`,
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantCode:    CodeTierDescriptionRequired,
+			wantField:   models.FieldDescription,
+		},
+		{
+			name:        "json patch negative level",
+			contentType: "application/json-patch+json",
+			patch:       `[{"op": "replace", "path": "/level", "value": -1}]`,
+			wantStatus:  http.StatusUnprocessableEntity,
+			wantCode:    CodeTierLevelInvalid,
+			wantField:   models.FieldLevel,
+		},
+		{
+			name:        "json patch failing test op",
+			contentType: "application/json-patch+json",
+			patch:       `[{"op": "test", "path": "/level", "value": 99}]`,
+			wantStatus:  http.StatusBadRequest,
+			wantCode:    CodeInvalidPatch,
+		},
+		{
+			name:        "malformed json patch",
+			contentType: "application/json-patch+json",
+			patch:       `{"op": "replace"}`,
+			wantStatus:  http.StatusBadRequest,
+			wantCode:    CodeInvalidPatch,
+		},
+		{
+			name:        "unsupported content type",
+			contentType: "application/json",
+			patch:       `{"level": 5}`,
+			wantStatus:  http.StatusUnsupportedMediaType,
+			wantCode:    CodeUnsupportedPatchType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, _ := setupTestRouter()
+			createTestTier(t, router, `{"name": "free", "description": "Free tier", "level": 1}`)
+
+			req, _ := http.NewRequest("PATCH", "/api/v1/tiers/free", bytes.NewBufferString(tt.patch))
+			req.Header.Set("Content-Type", tt.contentType)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+
+			if tt.check != nil {
+				var tier models.Tier
+				if err := json.Unmarshal(w.Body.Bytes(), &tier); err != nil {
+					t.Fatalf("Failed to unmarshal response: %v", err)
+				}
+				tt.check(t, tier)
+				return
+			}
+
+			var response ErrorResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if response.Code != tt.wantCode {
+				t.Errorf("Expected code %s, got %s", tt.wantCode, response.Code)
+			}
+			if response.Field != tt.wantField {
+				t.Errorf("Expected field %q, got %q", tt.wantField, response.Field)
+			}
+		})
+	}
+}
+
+func TestPatchTier_NotFound(t *testing.T) {
+	router, _ := setupTestRouter()
+
+	req, _ := http.NewRequest("PATCH", "/api/v1/tiers/missing", bytes.NewBufferString(`[]`))
+	req.Header.Set("Content-Type", "application/json-patch+json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestHeadTier(t *testing.T) {
+	router, _ := setupTestRouter()
+	createTestTier(t, router, `{"name": "free", "description": "Free tier", "level": 1}`)
+
+	req, _ := http.NewRequest("HEAD", "/api/v1/tiers/free", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	if w.Body.Len() != 0 {
+		t.Errorf("Expected empty body, got %q", w.Body.String())
+	}
+	etag := w.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("Expected ETag header")
+	}
+
+	// ETag must match the one returned by GET
+	req, _ = http.NewRequest("GET", "/api/v1/tiers/free", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if got := w.Header().Get("ETag"); got != etag {
+		t.Errorf("Expected GET ETag %s, got %s", etag, got)
+	}
+}
+
+func TestHeadTier_NotFound(t *testing.T) {
+	router, _ := setupTestRouter()
+
+	req, _ := http.NewRequest("HEAD", "/api/v1/tiers/missing", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d, got %d", http.StatusNotFound, w.Code)
+	}
+	if w.Body.Len() != 0 {
+		t.Errorf("Expected empty body, got %q", w.Body.String())
+	}
+}
+
+func TestGetTiers_Fields(t *testing.T) {
+	router, _ := setupTestRouter()
+	createTestTier(t, router, `{"name": "free", "description": "Free tier", "level": 1, "groups": ["system:authenticated"]}`)
+
+	req, _ := http.NewRequest("GET", "/api/v1/tiers?fields=name,level", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var response []map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if len(response) != 1 {
+		t.Fatalf("Expected 1 tier, got %d", len(response))
+	}
+	if len(response[0]) != 2 || response[0]["name"] != "free" || response[0]["level"] != float64(1) {
+		t.Errorf("Expected only name and level, got %v", response[0])
+	}
+}
+
+func TestGetTiers_Paginate(t *testing.T) {
+	router, _ := setupTestRouter()
+	createTestTier(t, router, `{"name": "free", "description": "Free tier", "level": 1}`)
+	createTestTier(t, router, `{"name": "premium", "description": "Premium tier", "level": 10}`)
+
+	tests := []struct {
+		name string
+		url  string
+	}{
+		{"tiers", "/api/v1/tiers?paginate=true&fields=name"},
+		{"llminferenceservices by tier", "/api/v1/tiers/free/llminferenceservices?paginate=true"},
+		{"llminferenceservices by tier with limit", "/api/v1/tiers/free/llminferenceservices?paginate=true&limit=10"},
+		{"llminferenceservices by group", "/api/v1/groups/premium-users/llminferenceservices?paginate=true"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", tt.url, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+			}
+
+			var response struct {
+				Items    []map[string]interface{} `json:"items"`
+				Total    *int                     `json:"total"`
+				Continue string                   `json:"continue"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Expected a list envelope, got %s: %v", w.Body.String(), err)
+			}
+			if response.Items == nil || response.Total == nil || *response.Total != len(response.Items) {
+				t.Errorf("Expected items with a matching total, got %s", w.Body.String())
+			}
+		})
+	}
+
+	// Field projection applies to the items inside the envelope
+	req, _ := http.NewRequest("GET", "/api/v1/tiers?paginate=true&fields=name", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	var response models.ListResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	items, ok := response.Items.([]interface{})
+	if !ok || response.Total != 2 || len(items) != 2 {
+		t.Fatalf("Expected 2 tiers, got %s", w.Body.String())
+	}
+	if first := items[0].(map[string]interface{}); len(first) != 1 || first["name"] != "free" {
+		t.Errorf("Expected only name, got %v", first)
+	}
+}
+
+func TestGetTiers_Unpaginated(t *testing.T) {
+	router, _ := setupTestRouter()
+	createTestTier(t, router, `{"name": "free", "description": "Free tier", "level": 1}`)
+
+	for _, url := range []string{"/api/v1/tiers", "/api/v1/tiers?paginate=false", "/api/v1/tiers/free/llminferenceservices"} {
+		t.Run(url, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", url, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			var response []map[string]interface{}
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Errorf("Expected a bare array, got %s: %v", w.Body.String(), err)
+			}
+		})
+	}
+
+	req, _ := http.NewRequest("GET", "/api/v1/tiers?paginate=maybe", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for an invalid paginate value, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestGetTier_Fields(t *testing.T) {
+	router, _ := setupTestRouter()
+	createTestTier(t, router, `{"name": "free", "description": "Free tier", "level": 1}`)
+
+	req, _ := http.NewRequest("GET", "/api/v1/tiers/free?fields=name&format=yaml", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var response map[string]interface{}
+	if err := yaml.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal YAML response: %v", err)
+	}
+	if len(response) != 1 || response["name"] != "free" {
+		t.Errorf("Expected only name, got %v", response)
+	}
+}
+
+func TestGetTiers_UnknownField(t *testing.T) {
+	router, _ := setupTestRouter()
+
+	tests := []string{
+		"/api/v1/tiers?fields=name,bogus",
+		"/api/v1/tiers/free?fields=serviceCount",
+	}
+
+	for _, url := range tests {
+		t.Run(url, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", url, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+			}
+		})
+	}
+}
+
+func TestResolveTiers(t *testing.T) {
+	router, _ := setupTestRouter()
+	createTestTier(t, router, `{"name": "free", "description": "Free tier", "level": 1, "groups": ["system:authenticated"]}`)
+
+	req, _ := http.NewRequest("POST", "/api/v1/tiers/resolve", bytes.NewBufferString(`{"groups": ["no-tier-users"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var response models.ResolveResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if response.Winner == nil || response.Winner.Name != "free" {
+		t.Errorf("Expected winner 'free', got %+v", response.Winner)
+	}
+	if len(response.Matches) != 1 || !response.Matches[0].Implicit {
+		t.Errorf("Expected one implicit match, got %+v", response.Matches)
+	}
+	if response.Why == nil || response.Why.Winner != "free" || response.Why.Rule != models.TiebreakOnlyMatch {
+		t.Errorf("Expected free to win as the only match, got %+v", response.Why)
+	}
+}
+
+func TestResolveTiers_Tie(t *testing.T) {
+	router, _ := setupTestRouter()
+	createTestTier(t, router, `{"name": "gold", "description": "Gold tier", "level": 10, "groups": ["vip-users"]}`)
+	createTestTier(t, router, `{"name": "platinum", "description": "Platinum tier", "level": 10, "groups": ["vip-users"]}`)
+
+	req, _ := http.NewRequest("POST", "/api/v1/tiers/resolve", bytes.NewBufferString(`{"groups": ["vip-users"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var response models.ResolveResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if response.Why == nil || response.Why.Winner != "gold" || response.Why.Rule != models.TiebreakConfiguredOrder {
+		t.Fatalf("Expected gold to win by configured order, got %+v", response.Why)
+	}
+	if len(response.Why.Candidates) != 2 || !response.Why.Candidates[0].Winner || response.Why.Candidates[1].Winner {
+		t.Errorf("Expected gold marked as the winner of two candidates, got %+v", response.Why.Candidates)
+	}
+}
+
+func TestResolveTiers_InvalidGroup(t *testing.T) {
+	router, _ := setupTestRouter()
+
+	req, _ := http.NewRequest("POST", "/api/v1/tiers/resolve", bytes.NewBufferString(`{"groups": ["Bad_Group"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status %d, got %d", http.StatusUnprocessableEntity, w.Code)
+	}
+}
+
+func TestGetTiersForUser_InvalidUsername(t *testing.T) {
+	router, _ := setupTestRouter()
+
+	req, _ := http.NewRequest("GET", "/api/v1/users/..%25/tiers", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusUnprocessableEntity, w.Code, w.Body.String())
+	}
+
+	var response ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if response.Code != CodeInvalidUsername {
+		t.Errorf("Expected code %s, got %s", CodeInvalidUsername, response.Code)
+	}
+}
+
+func TestGetLLMInferenceServicesByTier_InvalidLimit(t *testing.T) {
+	router, _ := setupTestRouter()
+
+	for _, limit := range []string{"0", "-1", "abc"} {
+		t.Run(limit, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", "/api/v1/tiers/free/llminferenceservices?limit="+limit, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("Expected status %d, got %d", http.StatusBadRequest, w.Code)
+			}
+		})
+	}
+}
+
+func TestCreateTier_InvalidName(t *testing.T) {
+	router, _ := setupTestRouter()
+
+	req, _ := http.NewRequest("POST", "/api/v1/tiers", bytes.NewBufferString(`{"name": "My Tier!", "description": "d", "level": 1}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusUnprocessableEntity, w.Code, w.Body.String())
+	}
+
+	var response ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if response.Code != CodeInvalidK8sName || response.Field != models.FieldName {
+		t.Errorf("Expected %s on field name, got %s on %q", CodeInvalidK8sName, response.Code, response.Field)
+	}
+}
+
+func TestCreateTier_ReservedName(t *testing.T) {
+	tests := []struct {
+		name, method, path, body string
+	}{
+		{"create", "POST", "/api/v1/tiers", `{"name": "stream", "description": "d", "level": 1}`},
+		{"import", "POST", "/api/v1/tiers/import", `{"tiers": [{"name": "stream", "description": "d", "level": 1}]}`},
+		{"ensure", "PUT", "/api/v1/tiers", `{"name": "stream", "description": "d", "level": 1}`},
+		{"create batch", "POST", "/api/v1/tiers", `{"name": "batch", "description": "d", "level": 1}`},
+		{"import batch", "POST", "/api/v1/tiers/import", `{"tiers": [{"name": "batch", "description": "d", "level": 1}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, _ := setupTestRouter()
+			req, _ := http.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusUnprocessableEntity {
+				t.Fatalf("Expected status %d, got %d: %s", http.StatusUnprocessableEntity, w.Code, w.Body.String())
+			}
+			var response ErrorResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if response.Code != CodeTierNameReserved || response.Field != models.FieldName {
+				t.Errorf("Expected %s on field name, got %s on %q", CodeTierNameReserved, response.Code, response.Field)
+			}
+		})
+	}
+}
+
+func TestImportTiers(t *testing.T) {
+	body := `{"tiers": [
+		{"name": "free", "description": "Free tier", "level": 1},
+		{"name": "broken", "description": "", "level": 2}
+	]}`
+
+	t.Run("atomic", func(t *testing.T) {
+		router, _ := setupTestRouter()
+		req, _ := http.NewRequest("POST", "/api/v1/tiers/import", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusUnprocessableEntity {
+			t.Fatalf("Expected status %d, got %d: %s", http.StatusUnprocessableEntity, w.Code, w.Body.String())
+		}
+		var response ErrorResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+		if response.Code != CodeTierDescriptionRequired {
+			t.Errorf("Expected code %s, got %s", CodeTierDescriptionRequired, response.Code)
+		}
+	})
+
+	t.Run("partial", func(t *testing.T) {
+		router, _ := setupTestRouter()
+		req, _ := http.NewRequest("POST", "/api/v1/tiers/import?partial=true", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+		}
+		var response models.ImportResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+		if !response.Partial || response.Applied != 1 || response.Skipped != 1 {
+			t.Errorf("Unexpected import response: %+v", response)
+		}
+	})
+}
+
+func TestTierMetadata(t *testing.T) {
+	store := createEmptyMockK8sStorage()
+	router, _ := setupTestRouterWithStorage(store)
+	createTestTier(t, router, `{"name": "premium", "description": "Premium tier", "level": 10, "metadata": {"costCenter": "cc-1234", "owner": "platform-team"}}`)
+
+	storedMetadata := func() map[string]string {
+		t.Helper()
+		config, err := store.Load(context.Background())
+		if err != nil || len(config.Tiers) != 1 {
+			t.Fatalf("Failed to load the stored tier: %v", err)
+		}
+		return config.Tiers[0].Metadata
+	}
+	if got, want := storedMetadata(), map[string]string{"costCenter": "cc-1234", "owner": "platform-team"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Stored metadata = %v, want %v", got, want)
+	}
+
+	update := func(body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("PUT", "/api/v1/tiers/premium", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// Updates without metadata keep it; metadata replaces it, and {} removes it
+	if w := update(`{"description": "Premium plus"}`); w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"costCenter":"cc-1234"`) {
+		t.Errorf("Expected metadata to be kept, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := update(`{"metadata": {"owner": "ml-team"}}`); w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if got, want := storedMetadata(), map[string]string{"owner": "ml-team"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Stored metadata = %v, want %v", got, want)
+	}
+	if w := update(`{"metadata": {}}`); w.Code != http.StatusOK || strings.Contains(w.Body.String(), "metadata") {
+		t.Errorf("Expected metadata to be removed, got %d: %s", w.Code, w.Body.String())
+	}
+	if got := storedMetadata(); got != nil {
+		t.Errorf("Expected no stored metadata, got %v", got)
+	}
+
+	w := update(`{"metadata": {"owner": "` + strings.Repeat("x", models.MaxTierMetadataValueLength+1) + `"}}`)
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusUnprocessableEntity, w.Code, w.Body.String())
+	}
+	var response ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if response.Code != CodeTierMetadataValueTooLong || response.Field != models.FieldMetadata || response.Value != "owner" || response.MaxLength != models.MaxTierMetadataValueLength {
+		t.Errorf("Unexpected error response: %+v", response)
+	}
+}
+
+func TestYAMLRequestBodies(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		json   string
+		yaml   string
+		status int
+	}{
+		{
+			name:   "create",
+			method: "POST",
+			path:   "/api/v1/tiers",
+			json:   `{"name": "gold", "description": "Gold tier", "level": 5, "groups": ["premium-users"]}`,
+			yaml:   "name: gold\ndescription: Gold tier\nlevel: 5\ngroups:\n  - premium-users\n",
+			status: http.StatusCreated,
+		},
+		{
+			name:   "create missing description",
+			method: "POST",
+			path:   "/api/v1/tiers",
+			json:   `{"name": "gold", "level": 5}`,
+			yaml:   "name: gold\nlevel: 5\n",
+			status: http.StatusUnprocessableEntity,
+		},
+		{
+			name:   "create wrong level type",
+			method: "POST",
+			path:   "/api/v1/tiers",
+			json:   `{"name": "gold", "description": "Gold tier", "level": "high"}`,
+			yaml:   "name: gold\ndescription: Gold tier\nlevel: high\n",
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "update",
+			method: "PUT",
+			path:   "/api/v1/tiers/premium",
+			json:   `{"description": "Premium plus", "level": 11}`,
+			yaml:   "description: Premium plus\nlevel: 11\n",
+			status: http.StatusOK,
+		},
+		{
+			name:   "import",
+			method: "POST",
+			path:   "/api/v1/tiers/import?partial=true",
+			json:   `{"tiers": [{"name": "free", "description": "Free tier", "level": 1}, {"name": "broken", "level": 2}]}`,
+			yaml:   "tiers:\n  - name: free\n    description: Free tier\n    level: 1\n  - name: broken\n    level: 2\n",
+			status: http.StatusOK,
+		},
+		{
+			name:   "empty body",
+			method: "POST",
+			path:   "/api/v1/tiers",
+			json:   "",
+			yaml:   "",
+			status: http.StatusBadRequest,
+		},
+	}
+
+	send := func(method, path, contentType, body string) *httptest.ResponseRecorder {
+		router, _ := setupTestRouter()
+		createTestTier(t, router, `{"name": "premium", "description": "Premium tier", "level": 10}`)
+		req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", contentType)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jsonResp := send(tt.method, tt.path, "application/json", tt.json)
+			if jsonResp.Code != tt.status {
+				t.Fatalf("Expected JSON status %d, got %d: %s", tt.status, jsonResp.Code, jsonResp.Body.String())
+			}
+			for _, contentType := range []string{"application/yaml", "application/x-yaml; charset=utf-8", "text/yaml"} {
+				yamlResp := send(tt.method, tt.path, contentType, tt.yaml)
+				if yamlResp.Code != jsonResp.Code || yamlResp.Body.String() != jsonResp.Body.String() {
+					t.Errorf("%s: expected %d %s, got %d %s", contentType, jsonResp.Code, jsonResp.Body.String(), yamlResp.Code, yamlResp.Body.String())
+				}
+			}
+		})
+	}
+}
+
+func TestStreamTierChanges(t *testing.T) {
+	router, _ := setupTestRouter()
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/api/v1/tiers/stream")
+	if err != nil {
+		t.Fatalf("Failed to open stream: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("Expected Content-Type text/event-stream, got %s", ct)
+	}
+
+	createResp, err := http.Post(server.URL+"/api/v1/tiers", "application/json",
+		bytes.NewBufferString(`{"name":"free","description":"Free tier","level":1,"groups":["system:authenticated"]}`))
+	if err != nil {
+		t.Fatalf("Failed to create tier: %v", err)
+	}
+	createResp.Body.Close()
+
+	lines := make(chan string)
+	go func() {
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+		close(lines)
+	}()
+
+	var eventName, data string
+	timeout := time.After(5 * time.Second)
+	for data == "" {
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				t.Fatal("Stream closed before an event was received")
+			}
+			if strings.HasPrefix(line, "event: ") {
+				eventName = strings.TrimPrefix(line, "event: ")
+			}
+			if strings.HasPrefix(line, "data: ") {
+				data = strings.TrimPrefix(line, "data: ")
+			}
+		case <-timeout:
+			t.Fatal("Timed out waiting for change event")
+		}
+	}
+
+	if eventName != "created" {
+		t.Errorf("Expected event created, got %s", eventName)
+	}
+	var event struct {
+		Action string      `json:"action"`
+		Name   string      `json:"name"`
+		After  models.Tier `json:"after"`
+	}
+	if err := json.Unmarshal([]byte(data), &event); err != nil {
+		t.Fatalf("Failed to unmarshal event: %v", err)
+	}
+	if event.Name != "free" || event.After.Level != 1 {
+		t.Errorf("Unexpected event: %+v", event)
+	}
+}
+
+func TestDeleteTiers_Batch(t *testing.T) {
+	router, _ := setupTestRouter()
+	createTestTier(t, router, `{"name": "test-1", "description": "Test tier", "level": 1}`)
+	createTestTier(t, router, `{"name": "test-2", "description": "Test tier", "level": 2}`)
+	createTestTier(t, router, `{"name": "keep", "description": "Kept tier", "level": 3}`)
+
+	req, _ := http.NewRequest("DELETE", "/api/v1/tiers/batch", bytes.NewBufferString(`{"names": ["test-1", "missing", "test-2"]}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	var response models.BatchDeleteTiersResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	want := []models.BatchDeleteTierResult{
+		{Name: "test-1", Status: models.TierStatusDeleted},
+		{Name: "missing", Status: models.TierStatusNotFound},
+		{Name: "test-2", Status: models.TierStatusDeleted},
+	}
+	if !reflect.DeepEqual(response.Results, want) {
+		t.Errorf("Expected results %+v, got %+v", want, response.Results)
+	}
+	if response.Deleted != 2 || response.NotFound != 1 {
+		t.Errorf("Expected 2 deleted and 1 not found, got %+v", response)
+	}
+
+	req, _ = http.NewRequest("GET", "/api/v1/tiers", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	var tiers []models.Tier
+	if err := json.Unmarshal(w.Body.Bytes(), &tiers); err != nil {
+		t.Fatalf("Failed to unmarshal tiers: %v", err)
+	}
+	if len(tiers) != 1 || tiers[0].Name != "keep" {
+		t.Errorf("Expected only keep to remain, got %+v", tiers)
+	}
+}
+
+func TestDeleteTiers_Validation(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		body     string
+		wantCode int
+		wantErr  string
+	}{
+		{"no names", "/api/v1/tiers/batch", `{"names": []}`, http.StatusUnprocessableEntity, CodeTierNamesRequired},
+		{"invalid tier name", "/api/v1/tiers/batch", `{"names": ["Bad_Tier"]}`, http.StatusUnprocessableEntity, CodeInvalidK8sName},
+		{"malformed body", "/api/v1/tiers/batch", `{"names": `, http.StatusBadRequest, CodeInvalidRequestBody},
+		{"invalid force", "/api/v1/tiers/batch?force=maybe", `{"names": ["free"]}`, http.StatusBadRequest, CodeInvalidQueryParameter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, _ := setupTestRouter()
+			req, _ := http.NewRequest("DELETE", tt.path, bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantCode, w.Code, w.Body.String())
+			}
+			var response ErrorResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if response.Code != tt.wantErr {
+				t.Errorf("Expected code %s, got %s", tt.wantErr, response.Code)
+			}
+		})
+	}
+}
+
+func TestSoftDeleteAndRestore(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tierService := service.NewTierService(createEmptyMockK8sStorage(), service.WithSoftDelete(true))
+	handler := NewTierHandler(tierService, newTestLLMServiceService(tierService))
+	router := gin.New()
+	v1 := router.Group("/api/v1")
+	v1.POST("/tiers", handler.CreateTier)
+	v1.GET("/tiers", handler.GetTiers)
+	v1.POST("/tiers/purge", handler.PurgeTiers)
+	v1.GET("/tiers/:name", handler.GetTier)
+	v1.DELETE("/tiers/:name", handler.DeleteTier)
+	v1.POST("/tiers/:name/restore", handler.RestoreTier)
+
+	createTestTier(t, router, `{"name": "free", "description": "Free tier", "level": 1}`)
+
+	do := func(method, path string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(method, path, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := do("DELETE", "/api/v1/tiers/free"); w.Code != http.StatusNoContent {
+		t.Fatalf("Expected status %d on delete, got %d: %s", http.StatusNoContent, w.Code, w.Body.String())
+	}
+	if w := do("GET", "/api/v1/tiers/free"); w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d for soft-deleted tier, got %d", http.StatusNotFound, w.Code)
+	}
+
+	w := do("GET", "/api/v1/tiers/free?includeDeleted=true")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d with includeDeleted, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var tier models.Tier
+	if err := json.Unmarshal(w.Body.Bytes(), &tier); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if !tier.IsDeleted() {
+		t.Error("Expected deletedAt to be set")
+	}
+
+	var tiers []models.Tier
+	w = do("GET", "/api/v1/tiers")
+	if err := json.Unmarshal(w.Body.Bytes(), &tiers); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if len(tiers) != 0 {
+		t.Errorf("Expected no tiers without includeDeleted, got %d", len(tiers))
+	}
+
+	w = do("POST", "/api/v1/tiers/purge")
+	var purge models.PurgeResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &purge); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if w.Code != http.StatusOK || len(purge.Purged) != 0 {
+		t.Errorf("Expected nothing purged within retention, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if w := do("POST", "/api/v1/tiers/free/restore"); w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d on restore, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if w := do("GET", "/api/v1/tiers/free"); w.Code != http.StatusOK {
+		t.Errorf("Expected status %d for restored tier, got %d", http.StatusOK, w.Code)
+	}
+
+	w = do("POST", "/api/v1/tiers/free/restore")
+	if w.Code != http.StatusConflict {
+		t.Errorf("Expected status %d restoring an active tier, got %d", http.StatusConflict, w.Code)
+	}
+}
+
+func TestValidateGroup(t *testing.T) {
+	router, _ := setupTestRouter()
+
+	tests := []struct {
+		name string
+		body string
+		want models.ValidateGroupResponse
+	}{
+		{"valid", `{"group": "premium-users"}`, models.ValidateGroupResponse{
+			Group: "premium-users", Valid: true, Normalized: "premium-users", NormalizedValid: true,
+		}},
+		{"surrounding whitespace", `{"group": "  premium-users\t"}`, models.ValidateGroupResponse{
+			Group: "  premium-users\t", Normalized: "premium-users", NormalizedValid: true, Code: CodeInvalidK8sName,
+		}},
+		{"uppercase", `{"group": "Premium-Users"}`, models.ValidateGroupResponse{
+			Group: "Premium-Users", Normalized: "premium-users", NormalizedValid: true, Code: CodeInvalidK8sName,
+		}},
+		{"system group", `{"group": "system:authenticated"}`, models.ValidateGroupResponse{
+			Group: "system:authenticated", Valid: true, Normalized: "system:authenticated", NormalizedValid: true,
+		}},
+		{"namespace-scoped group", `{"group": "acme:developers"}`, models.ValidateGroupResponse{
+			Group: "acme:developers", Valid: true, Normalized: "acme:developers", NormalizedValid: true,
+		}},
+		{"invalid colons", `{"group": "a:b:c"}`, models.ValidateGroupResponse{
+			Group: "a:b:c", Normalized: "a:b:c", Code: CodeInvalidGroupColon,
+		}},
+		{"empty", `{"group": "   "}`, models.ValidateGroupResponse{
+			Group: "   ", Code: CodeInvalidK8sName,
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("POST", "/api/v1/groups/validate", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+			}
+			var response models.ValidateGroupResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if (response.Reason == "") != tt.want.Valid {
+				t.Errorf("Expected a reason only for invalid names, got %q", response.Reason)
+			}
+			response.Reason = ""
+			if response != tt.want {
+				t.Errorf("Expected %+v, got %+v", tt.want, response)
+			}
+		})
+	}
+}
+
+func TestRenameGroup(t *testing.T) {
+	router, _ := setupTestRouter()
+	createTestTier(t, router, `{"name": "premium", "description": "Premium tier", "level": 10, "groups": ["premium-users", "vip-users"]}`)
+
+	req, _ := http.NewRequest("POST", "/api/v1/groups/rename", bytes.NewBufferString(`{"from": "premium-users", "to": "vip-users"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var response models.RenameGroupResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if len(response.Tiers) != 1 || response.Tiers[0] != "premium" {
+		t.Errorf("Expected premium to be modified, got %v", response.Tiers)
+	}
+
+	req, _ = http.NewRequest("GET", "/api/v1/tiers/premium", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	var tier models.Tier
+	if err := json.Unmarshal(w.Body.Bytes(), &tier); err != nil {
+		t.Fatalf("Failed to unmarshal tier: %v", err)
+	}
+	if len(tier.Groups) != 1 || tier.Groups[0] != "vip-users" {
+		t.Errorf("Expected groups [vip-users], got %v", tier.Groups)
+	}
+}
+
+func TestPruneTiers_RequiresConfirmation(t *testing.T) {
+	router, _ := setupTestRouter()
+	createTestTier(t, router, `{"name": "free", "description": "Free tier", "level": 1}`)
+
+	tests := []struct {
+		name       string
+		url        string
+		wantStatus int
+		wantCode   string
+	}{
+		{"missing confirm", "/api/v1/admin/prune-tiers", http.StatusBadRequest, CodeConfirmationRequired},
+		{"invalid criteria", "/api/v1/admin/prune-tiers?criteria=all&confirm=true", http.StatusBadRequest, CodeInvalidQueryParameter},
+		{"dry run", "/api/v1/admin/prune-tiers?dryRun=true", http.StatusOK, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("POST", tt.url, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantCode != "" {
+				var response ErrorResponse
+				if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil || response.Code != tt.wantCode {
+					t.Errorf("Expected code %s, got %s", tt.wantCode, w.Body.String())
+				}
+			}
+		})
+	}
+
+	// Neither rejected request nor the dry run deleted the tier
+	req, _ := http.NewRequest("GET", "/api/v1/tiers/free", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected free to remain, got status %d", w.Code)
+	}
+}
+
+func TestReconcileTiers(t *testing.T) {
+	router, _ := setupTestRouter()
+
+	tests := []struct {
+		name       string
+		url        string
+		wantStatus int
+	}{
+		{"dry run", "/api/v1/admin/reconcile?dryRun=true&checkAccess=true", http.StatusOK},
+		{"reconcile", "/api/v1/admin/reconcile", http.StatusOK},
+		{"invalid dryRun", "/api/v1/admin/reconcile?dryRun=maybe", http.StatusBadRequest},
+		{"invalid checkAccess", "/api/v1/admin/reconcile?checkAccess=maybe", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("POST", tt.url, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var response models.ReconcileResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if response.Scanned != 0 || response.Results == nil {
+				t.Errorf("Expected an empty report with no services, got %+v", response)
+			}
+		})
+	}
+}
+
+func TestUpdateTier_OmittedLevelPreserved(t *testing.T) {
+	router, _ := setupTestRouter()
+	createTestTier(t, router, `{"name": "premium", "description": "Premium tier", "level": 10}`)
+
+	req, _ := http.NewRequest("PUT", "/api/v1/tiers/premium", bytes.NewBufferString(`{"description": "Premium plus"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var tier models.Tier
+	if err := json.Unmarshal(w.Body.Bytes(), &tier); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if tier.Description != "Premium plus" || tier.Level != 10 {
+		t.Errorf("Expected level 10 to be preserved, got %+v", tier)
+	}
+}
+
+func TestUpdateTier_IfMatch(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	tierService := service.NewTierService(createEmptyMockK8sStorage(), service.WithMetrics(registry))
+	router := SetupRouter(tierService, newTestLLMServiceService(tierService), WithSwagger(false), WithMetrics(registry))
+	createTestTier(t, router, `{"name": "premium", "description": "Premium tier", "level": 10}`)
+
+	send := func(method, body, contentType, ifMatch string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(method, "/api/v1/tiers/premium", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", contentType)
+		if ifMatch != "" {
+			req.Header.Set("If-Match", ifMatch)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	req, _ := http.NewRequest("GET", "/api/v1/tiers/premium", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	staleETag := w.Header().Get("ETag")
+
+	// Someone else edits the tier after it was read
+	w = send("PUT", `{"level": 11}`, "application/json", staleETag)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d with a current ETag, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	freshETag := w.Header().Get("ETag")
+	if freshETag == "" || freshETag == staleETag {
+		t.Fatalf("Expected a new ETag after the update, got %q", freshETag)
+	}
+
+	for _, tt := range []struct {
+		method, body, contentType string
+	}{
+		{"PUT", `{"description": "Premium plus"}`, "application/json"},
+		{"PATCH", `{"description": "Premium plus"}`, "application/merge-patch+json"},
+	} {
+		w = send(tt.method, tt.body, tt.contentType, staleETag)
+		if w.Code != http.StatusConflict {
+			t.Fatalf("%s with a stale If-Match: expected status %d, got %d: %s", tt.method, http.StatusConflict, w.Code, w.Body.String())
+		}
+		var response ErrorResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+		if response.Code != CodeTierETagMismatch {
+			t.Errorf("%s with a stale If-Match: expected code %s, got %s", tt.method, CodeTierETagMismatch, response.Code)
+		}
+	}
+
+	// Both rejections are counted as conflicts
+	req, _ = http.NewRequest("GET", "/metrics", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if want := service.TierOperationsMetric + `{operation="update",outcome="conflict"} 2`; !strings.Contains(w.Body.String(), want) {
+		t.Errorf("Expected %s in metrics, got:\n%s", want, w.Body.String())
+	}
+
+	// The stale edits were not applied, and the fresh ETag still works
+	w = send("PATCH", `{"description": "Premium plus"}`, "application/merge-patch+json", freshETag)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d with the fresh ETag, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var tier models.Tier
+	if err := json.Unmarshal(w.Body.Bytes(), &tier); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	if tier.Description != "Premium plus" || tier.Level != 11 {
+		t.Errorf("Expected description Premium plus at level 11, got %+v", tier)
+	}
+}
+
+func TestMigrateAnnotations_Validation(t *testing.T) {
+	router, _ := setupTestRouter()
+
+	tests := []struct {
+		name      string
+		body      string
+		wantCode  string
+		wantField string
+	}{
+		{"missing old key", `{"newKey": "maas.opendatahub.io/tiers"}`, CodeAnnotationKeyRequired, models.FieldOldKey},
+		{"invalid new key", `{"oldKey": "alpha.maas.opendatahub.io/tiers", "newKey": "not a key"}`, CodeInvalidAnnotationKey, models.FieldNewKey},
+		{"same keys", `{"oldKey": "maas.opendatahub.io/tiers", "newKey": "maas.opendatahub.io/tiers"}`, CodeAnnotationKeysIdentical, models.FieldNewKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("POST", "/api/v1/admin/migrate-annotations", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusUnprocessableEntity {
+				t.Fatalf("Expected status %d, got %d: %s", http.StatusUnprocessableEntity, w.Code, w.Body.String())
+			}
+			var response ErrorResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil || response.Code != tt.wantCode || response.Field != tt.wantField {
+				t.Errorf("Expected code %s on field %s, got %s", tt.wantCode, tt.wantField, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestWrappedErrorStatus_NamespaceDeleted(t *testing.T) {
+	mockStore := createEmptyMockK8sStorage()
+	router, _ := setupTestRouterWithStorage(mockStore)
+	createTestTier(t, router, `{"name": "free", "description": "Free tier", "level": 1}`)
+
+	// Missing tiers are wrapped on some paths and must still map to 404
+	req, _ := http.NewRequest("GET", "/api/v1/tiers/missing/llminferenceservices", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d for a missing tier, got %d: %s", http.StatusNotFound, w.Code, w.Body.String())
+	}
+
+	// Delete the namespace while the server runs
+	mockStore.Client.(*fake.Clientset).PrependReactor("*", "configmaps", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewNotFound(schema.GroupResource{Resource: "namespaces"}, "test")
+	})
+
+	tests := []struct {
+		method string
+		url    string
+		body   string
+	}{
+		{"GET", "/api/v1/tiers", ""},
+		{"GET", "/api/v1/tiers/free", ""},
+		{"GET", "/api/v1/tiers/free/llminferenceservices", ""},
+		{"POST", "/api/v1/tiers", `{"name": "premium", "description": "Premium tier", "level": 10}`},
+		{"DELETE", "/api/v1/tiers/free", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.url, func(t *testing.T) {
+			req, _ := http.NewRequest(tt.method, tt.url, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusNotFound {
+				t.Fatalf("Expected status %d, got %d: %s", http.StatusNotFound, w.Code, w.Body.String())
+			}
+			var response ErrorResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil || response.Code != CodeNamespaceNotFound {
+				t.Errorf("Expected code %s, got %s", CodeNamespaceNotFound, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestRequestBodyTooLarge(t *testing.T) {
+	router, _ := setupTestRouter()
+	createTestTier(t, router, `{"name": "free", "description": "Free tier", "level": 1}`)
+	oversized := `{"name": "huge", "description": "` + strings.Repeat("x", int(DefaultMaxRequestBodyBytes)) + `", "level": 1}`
+
+	tests := []struct {
+		name          string
+		method        string
+		url           string
+		contentType   string
+		contentLength bool
+	}{
+		{"declared length", "POST", "/api/v1/tiers", "application/json", true},
+		{"streamed body", "POST", "/api/v1/tiers/import", "application/json", false},
+		{"raw patch", "PATCH", "/api/v1/tiers/free", "application/merge-patch+json", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(tt.method, tt.url, strings.NewReader(oversized))
+			if !tt.contentLength {
+				// Hide the length so the limit is enforced while the handler reads
+				req.ContentLength = -1
+			}
+			req.Header.Set("Content-Type", tt.contentType)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusRequestEntityTooLarge {
+				t.Fatalf("Expected status %d, got %d: %s", http.StatusRequestEntityTooLarge, w.Code, w.Body.String())
+			}
+			var response ErrorResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil || response.Code != CodeRequestBodyTooLarge {
+				t.Errorf("Expected code %s, got %s", CodeRequestBodyTooLarge, w.Body.String())
+			}
+		})
+	}
+
+	// Bodies within the limit are unaffected
+	createTestTier(t, router, `{"name": "premium", "description": "Premium tier", "level": 10}`)
+}
+
+func TestInvalidRequestBody(t *testing.T) {
+	router, _ := setupTestRouter()
+	createTestTier(t, router, `{"name": "free", "description": "Free tier", "level": 1}`)
+
+	tests := []struct {
+		name        string
+		method      string
+		url         string
+		body        string
+		wantMessage string
+		wantField   string
+	}{
+		{"trailing comma", "POST", "/api/v1/tiers", `{"name": "premium",}`, "invalid request body: malformed JSON at offset 20: invalid character '}' looking for beginning of object key string", ""},
+		{"truncated", "POST", "/api/v1/tiers", `{"name": "premium"`, "invalid request body: malformed JSON: unexpected end of input", ""},
+		{"empty", "PUT", "/api/v1/tiers/free", ``, "invalid request body: body is empty", ""},
+		{"wrong type", "POST", "/api/v1/tiers", `{"name": "premium", "level": "high"}`, "invalid request body: field level must be int, got JSON string", "level"},
+		{"missing required field", "POST", "/api/v1/tiers/free/groups", `{}`, "invalid request body: missing required fields: group", "group"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(tt.method, tt.url, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("Expected status %d, got %d: %s", http.StatusBadRequest, w.Code, w.Body.String())
+			}
+			var response ErrorResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if response.Code != CodeInvalidRequestBody {
+				t.Errorf("Expected code %s, got %s", CodeInvalidRequestBody, response.Code)
+			}
+			if response.Error != tt.wantMessage {
+				t.Errorf("Expected message %q, got %q", tt.wantMessage, response.Error)
+			}
+			if response.Field != tt.wantField {
+				t.Errorf("Expected field %q, got %q", tt.wantField, response.Field)
+			}
+		})
+	}
+}
+
+func TestMoveTierLevel(t *testing.T) {
+	router, _ := setupTestRouter()
+	createTestTier(t, router, `{"name": "free", "description": "Free tier", "level": 1}`)
+	createTestTier(t, router, `{"name": "premium", "description": "Premium tier", "level": 10}`)
+
+	req, _ := http.NewRequest("POST", "/api/v1/tiers/premium/move-level?cascade=true", bytes.NewBufferString(`{"level": 1}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	var response models.MoveLevelResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	want := []models.LevelChange{{Name: "premium", From: 10, To: 1}, {Name: "free", From: 1, To: 2}}
+	if !response.Cascade || !reflect.DeepEqual(response.Changed, want) {
+		t.Errorf("Expected cascading changes %+v, got %+v", want, response)
+	}
+
+	req, _ = http.NewRequest("POST", "/api/v1/tiers/premium/move-level?cascade=maybe", bytes.NewBufferString(`{"level": 1}`))
+	req.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status %d for an invalid cascade flag, got %d", http.StatusBadRequest, w.Code)
+	}
+}
+
+func TestSetTierLevel(t *testing.T) {
+	router, _ := setupTestRouter()
+	createTestTier(t, router, `{"name": "premium", "description": "Premium tier", "level": 10}`)
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantCode   string
+	}{
+		{"missing level", `{}`, http.StatusUnprocessableEntity, CodeTierLevelRequired},
+		{"negative level", `{"level": -1}`, http.StatusUnprocessableEntity, CodeTierLevelInvalid},
+		{"zero level", `{"level": 0}`, http.StatusOK, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("POST", "/api/v1/tiers/premium/level", bytes.NewBufferString(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantCode != "" {
+				var response ErrorResponse
+				if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil || response.Code != tt.wantCode {
+					t.Errorf("Expected code %s, got %s", tt.wantCode, w.Body.String())
+				}
+				return
+			}
+			var tier models.Tier
+			if err := json.Unmarshal(w.Body.Bytes(), &tier); err != nil || tier.Level != 0 || tier.Description != "Premium tier" {
+				t.Errorf("Expected premium with level 0, got %s", w.Body.String())
+			}
+		})
+	}
+}
+
+func TestEnsureTier(t *testing.T) {
+	router, _ := setupTestRouter()
+
+	ensure := func(body string) (*httptest.ResponseRecorder, models.Tier) {
+		t.Helper()
+		req, _ := http.NewRequest("PUT", "/api/v1/tiers", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		var tier models.Tier
+		if w.Code < http.StatusUnprocessableEntity {
+			if err := json.Unmarshal(w.Body.Bytes(), &tier); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+		}
+		return w, tier
+	}
+
+	desired := `{"name": "premium", "description": "Premium tier", "level": 10, "groups": ["premium-users"]}`
+	w, tier := ensure(desired)
+	if w.Code != http.StatusCreated || w.Header().Get(EnsureTierResultHeader) != service.EnsureCreated {
+		t.Fatalf("Expected 201 created for a missing tier, got %d %q: %s", w.Code, w.Header().Get(EnsureTierResultHeader), w.Body.String())
+	}
+	if tier.Level != 10 || len(tier.Groups) != 1 || w.Header().Get("ETag") != tier.ETag() {
+		t.Errorf("Expected the created tier with its ETag, got %+v", tier)
+	}
+
+	// Ensuring the same state again is a no-op
+	w, _ = ensure(desired)
+	if w.Code != http.StatusOK || w.Header().Get(EnsureTierResultHeader) != service.EnsureUnchanged {
+		t.Errorf("Expected 200 unchanged for a matching tier, got %d %q", w.Code, w.Header().Get(EnsureTierResultHeader))
+	}
+
+	w, tier = ensure(`{"name": "premium", "description": "Premium plus", "level": 11, "groups": ["vip-users"]}`)
+	if w.Code != http.StatusOK || w.Header().Get(EnsureTierResultHeader) != service.EnsureUpdated {
+		t.Fatalf("Expected 200 updated for a changed tier, got %d %q: %s", w.Code, w.Header().Get(EnsureTierResultHeader), w.Body.String())
+	}
+	if tier.Description != "Premium plus" || tier.Level != 11 || len(tier.Groups) != 1 || tier.Groups[0] != "vip-users" {
+		t.Errorf("Expected description, level and groups to be replaced, got %+v", tier)
+	}
+
+	if w, _ = ensure(`{"description": "No name", "level": 1}`); w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Expected status %d without a name, got %d", http.StatusUnprocessableEntity, w.Code)
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Liveness statuses reported by /health
+const (
+	LivenessStatusOK        = "ok"
+	LivenessStatusUnhealthy = "unhealthy"
+)
+
+// LivenessCheck is a named component check reported by /health. A failing check makes
+// /health return 503 so the kubelet restarts the pod, so it should only fail for
+// conditions a restart can fix.
+type LivenessCheck struct {
+	Name  string
+	Check func() error
+}
+
+// LivenessResponse represents the /health response
+// @Description Overall liveness and, when liveness checks are configured, the status of each
+type LivenessResponse struct {
+	Status     string            `json:"status" example:"ok"`                                 // "ok", or "unhealthy" when any check fails
+	Components map[string]string `json:"components,omitempty" example:"kubernetes-client:ok"` // Component name to "ok" or an error message
+}
+
+// livenessHandler returns a handler that runs every check and reports
+// 200 when all pass or 503 when any fails
+func livenessHandler(checks []LivenessCheck) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		response := LivenessResponse{Status: LivenessStatusOK}
+		for _, check := range checks {
+			if response.Components == nil {
+				response.Components = make(map[string]string, len(checks))
+			}
+			if err := check.Check(); err != nil {
+				response.Components[check.Name] = err.Error()
+				response.Status = LivenessStatusUnhealthy
+				continue
+			}
+			response.Components[check.Name] = LivenessStatusOK
+		}
+
+		status := http.StatusOK
+		if response.Status == LivenessStatusUnhealthy {
+			status = http.StatusServiceUnavailable
+		}
+		c.JSON(status, response)
+	}
+}
+
+// Readiness statuses reported by /readyz
+const (
+	ReadinessStatusOK       = "ok"
+	ReadinessStatusNotReady = "not ready"
+	ReadinessStatusDegraded = "degraded"
+)
+
+// ReadinessCheck is a named component check reported by /readyz.
+// A failing Optional check is reported but leaves the server ready, with
+// overall status "degraded", because core tier management does not depend on it.
+type ReadinessCheck struct {
+	Name     string
+	Check    func() error
+	Optional bool
+}
+
+// ReadinessResponse represents the /readyz response
+// @Description Overall readiness and the status of each checked component
+type ReadinessResponse struct {
+	Status     string            `json:"status" example:"ok"`                               // "ok" when every component is ready, "degraded" when only optional components fail
+	Components map[string]string `json:"components" example:"configmap:ok,write-access:ok"` // Component name to "ok" or an error message
+}
+
+// readinessHandler returns a handler that runs every check and reports
+// 200 when all required checks pass or 503 when any fails
+func readinessHandler(checks []ReadinessCheck) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		response := ReadinessResponse{
+			Status:     ReadinessStatusOK,
+			Components: make(map[string]string, len(checks)),
+		}
+		for _, check := range checks {
+			if err := check.Check(); err != nil {
+				response.Components[check.Name] = err.Error()
+				if !check.Optional {
+					response.Status = ReadinessStatusNotReady
+				} else if response.Status == ReadinessStatusOK {
+					response.Status = ReadinessStatusDegraded
+				}
+				continue
+			}
+			response.Components[check.Name] = ReadinessStatusOK
+		}
+
+		status := http.StatusOK
+		if response.Status == ReadinessStatusNotReady {
+			status = http.StatusServiceUnavailable
+		}
+		c.JSON(status, response)
+	}
+}
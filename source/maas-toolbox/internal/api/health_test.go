@@ -0,0 +1,142 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestReadiness(t *testing.T) {
+	ok := func() error { return nil }
+	denied := func() error { return errors.New("write access denied") }
+	missing := func() error { return errors.New("groups is not served") }
+
+	tests := []struct {
+		name           string
+		checks         []ReadinessCheck
+		wantStatus     int
+		wantState      string
+		wantComponents map[string]string
+	}{
+		{
+			name:           "all components ready",
+			checks:         []ReadinessCheck{{Name: "configmap", Check: ok}, {Name: "write-access", Check: ok}},
+			wantStatus:     http.StatusOK,
+			wantState:      ReadinessStatusOK,
+			wantComponents: map[string]string{"configmap": ReadinessStatusOK, "write-access": ReadinessStatusOK},
+		},
+		{
+			name:           "write access denied",
+			checks:         []ReadinessCheck{{Name: "configmap", Check: ok}, {Name: "write-access", Check: denied}},
+			wantStatus:     http.StatusServiceUnavailable,
+			wantState:      ReadinessStatusNotReady,
+			wantComponents: map[string]string{"configmap": ReadinessStatusOK, "write-access": "write access denied"},
+		},
+		{
+			name:           "optional component missing",
+			checks:         []ReadinessCheck{{Name: "configmap", Check: ok}, {Name: "openshift-groups", Check: missing, Optional: true}},
+			wantStatus:     http.StatusOK,
+			wantState:      ReadinessStatusDegraded,
+			wantComponents: map[string]string{"configmap": ReadinessStatusOK, "openshift-groups": "groups is not served"},
+		},
+		{
+			name:           "required and optional components failing",
+			checks:         []ReadinessCheck{{Name: "openshift-groups", Check: missing, Optional: true}, {Name: "write-access", Check: denied}},
+			wantStatus:     http.StatusServiceUnavailable,
+			wantState:      ReadinessStatusNotReady,
+			wantComponents: map[string]string{"openshift-groups": "groups is not served", "write-access": "write access denied"},
+		},
+		{
+			name:           "no checks",
+			wantStatus:     http.StatusOK,
+			wantState:      ReadinessStatusOK,
+			wantComponents: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.GET("/readyz", readinessHandler(tt.checks))
+
+			req, _ := http.NewRequest("GET", "/readyz", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+
+			var response ReadinessResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if response.Status != tt.wantState {
+				t.Errorf("Expected status %q, got %q", tt.wantState, response.Status)
+			}
+			if len(response.Components) != len(tt.wantComponents) {
+				t.Errorf("Expected components %v, got %v", tt.wantComponents, response.Components)
+			}
+			for name, want := range tt.wantComponents {
+				if got := response.Components[name]; got != want {
+					t.Errorf("Component %s = %q, want %q", name, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestLiveness(t *testing.T) {
+	tests := []struct {
+		name       string
+		checks     []LivenessCheck
+		wantStatus int
+		wantState  string
+	}{
+		{"no checks", nil, http.StatusOK, LivenessStatusOK},
+		{"client healthy", []LivenessCheck{{Name: "kubernetes-client", Check: func() error { return nil }}}, http.StatusOK, LivenessStatusOK},
+		{"client wedged", []LivenessCheck{{Name: "kubernetes-client", Check: func() error { return errors.New("5 consecutive failures") }}}, http.StatusServiceUnavailable, LivenessStatusUnhealthy},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.GET("/health", livenessHandler(tt.checks))
+
+			req, _ := http.NewRequest("GET", "/health", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			var response LivenessResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to unmarshal response: %v", err)
+			}
+			if response.Status != tt.wantState || len(response.Components) != len(tt.checks) {
+				t.Errorf("Expected status %q with %d components, got %+v", tt.wantState, len(tt.checks), response)
+			}
+		})
+	}
+}
@@ -18,14 +18,114 @@ import (
 	"maas-toolbox/docs"
 	"maas-toolbox/internal/service"
 	"os"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
+// DefaultBasePath is the path prefix of the API routes
+const DefaultBasePath = "/api/v1"
+
+// routerConfig holds optional router settings
+type routerConfig struct {
+	readinessChecks     []ReadinessCheck
+	livenessChecks      []LivenessCheck
+	maxRequestBodyBytes int64
+	basePath            string
+	disableSwagger      bool
+	enableLLMCreate     bool
+	enableAnnotateAll   bool
+	metrics             prometheus.Gatherer
+}
+
+// RouterOption configures optional router behavior
+type RouterOption func(*routerConfig)
+
+// WithReadinessChecks adds component checks reported by /readyz
+func WithReadinessChecks(checks ...ReadinessCheck) RouterOption {
+	return func(cfg *routerConfig) {
+		cfg.readinessChecks = append(cfg.readinessChecks, checks...)
+	}
+}
+
+// WithLivenessChecks adds component checks reported by /health
+func WithLivenessChecks(checks ...LivenessCheck) RouterOption {
+	return func(cfg *routerConfig) {
+		cfg.livenessChecks = append(cfg.livenessChecks, checks...)
+	}
+}
+
+// WithMaxRequestBodyBytes sets the request body limit for mutating API routes.
+// Zero or less disables the limit; the default is DefaultMaxRequestBodyBytes.
+func WithMaxRequestBodyBytes(limit int64) RouterOption {
+	return func(cfg *routerConfig) {
+		cfg.maxRequestBodyBytes = limit
+	}
+}
+
+// WithBasePath mounts the API routes under path instead of DefaultBasePath, for serving behind
+// a gateway without rewrites. A leading slash is added and trailing slashes are removed.
+// Health, readiness and Swagger UI routes are not prefixed.
+func WithBasePath(path string) RouterOption {
+	return func(cfg *routerConfig) {
+		cfg.basePath = NormalizeBasePath(path)
+	}
+}
+
+// WithSwagger controls whether the Swagger UI is served at /swagger. It is enabled by default;
+// disabling it omits the route entirely so the API description is not published.
+func WithSwagger(enabled bool) RouterOption {
+	return func(cfg *routerConfig) {
+		cfg.disableSwagger = !enabled
+	}
+}
+
+// WithLLMInferenceServiceCreate controls whether POST /llminferenceservices is served. It is
+// disabled by default because creating LLMInferenceServices widens the toolbox's write access
+// beyond annotations.
+func WithLLMInferenceServiceCreate(enabled bool) RouterOption {
+	return func(cfg *routerConfig) {
+		cfg.enableLLMCreate = enabled
+	}
+}
+
+// WithAnnotateAllTiers controls whether POST /llminferenceservices/annotate/all is served. It is
+// disabled by default because granting a service every tier at once is a blunt testing tool.
+func WithAnnotateAllTiers(enabled bool) RouterOption {
+	return func(cfg *routerConfig) {
+		cfg.enableAnnotateAll = enabled
+	}
+}
+
+// WithMetrics serves the metrics gathered by gatherer at /metrics in the Prometheus text format.
+// Without it the route is omitted.
+func WithMetrics(gatherer prometheus.Gatherer) RouterOption {
+	return func(cfg *routerConfig) {
+		cfg.metrics = gatherer
+	}
+}
+
+// NormalizeBasePath returns path with a single leading slash and no trailing slash,
+// or DefaultBasePath when path is empty or only slashes
+func NormalizeBasePath(path string) string {
+	path = strings.Trim(strings.TrimSpace(path), "/")
+	if path == "" {
+		return DefaultBasePath
+	}
+	return "/" + path
+}
+
 // SetupRouter configures and returns the Gin router with all routes
-func SetupRouter(tierService *service.TierService) *gin.Engine {
+func SetupRouter(tierService *service.TierService, llmServiceService *service.LLMInferenceServiceService, opts ...RouterOption) *gin.Engine {
+	cfg := &routerConfig{maxRequestBodyBytes: DefaultMaxRequestBodyBytes, basePath: DefaultBasePath}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
 	// Ensure we're not in release mode (which disables logging)
 	// This must be called before creating the router
 	gin.SetMode(gin.DebugMode)
@@ -34,35 +134,94 @@ func SetupRouter(tierService *service.TierService) *gin.Engine {
 	// Logger middleware logs all HTTP requests
 	router := gin.Default()
 
-	// Create LLMInferenceServiceService
-	llmServiceService := service.NewLLMInferenceServiceService(tierService)
+	// A trailing slash on a known route redirects to the canonical route, 301 for GET and 307
+	// (keeping the method and body) otherwise. Unknown paths and methods get the same JSON
+	// ErrorResponse as every other error, with or without a trailing slash.
+	router.RedirectTrailingSlash = true
+	router.RedirectFixedPath = false
+	router.HandleMethodNotAllowed = true
+	router.NoRoute(noRouteHandler)
+	router.NoMethod(noMethodHandler)
 
 	// Create handler
 	handler := NewTierHandler(tierService, llmServiceService)
 
 	// API v1 routes
-	v1 := router.Group("/api/v1")
+	// API routes, documented in Swagger under the same prefix
+	docs.SwaggerInfo.BasePath = cfg.basePath
+	v1 := router.Group(cfg.basePath, limitRequestBody(cfg.maxRequestBodyBytes))
 	{
 		v1.POST("/tiers", handler.CreateTier)
 		v1.GET("/tiers", handler.GetTiers)
+		v1.PUT("/tiers", handler.EnsureTier)
+		v1.POST("/tiers/resolve", handler.ResolveTiers)
+		v1.POST("/tiers/import", handler.ImportTiers)
+		v1.POST("/tiers/purge", handler.PurgeTiers)
+		v1.DELETE("/tiers/batch", handler.DeleteTiers)
+		v1.GET("/tiers/stream", handler.StreamTierChanges)
 		v1.GET("/tiers/:name", handler.GetTier)
+		v1.HEAD("/tiers/:name", handler.HeadTier)
 		v1.PUT("/tiers/:name", handler.UpdateTier)
+		v1.PATCH("/tiers/:name", handler.PatchTier)
+		v1.POST("/tiers/:name/level", handler.SetTierLevel)
+		v1.POST("/tiers/:name/move-level", handler.MoveTierLevel)
 		v1.DELETE("/tiers/:name", handler.DeleteTier)
+		v1.POST("/tiers/:name/restore", handler.RestoreTier)
 
 		// Group management routes
 		v1.POST("/tiers/:name/groups", handler.AddGroup)
+		v1.POST("/tiers/:name/groups/batch", handler.AddGroups)
 		v1.DELETE("/tiers/:name/groups/:group", handler.RemoveGroup)
+		v1.POST("/tiers/:name/copy-groups-from/:source", handler.CopyGroups)
+		v1.POST("/groups/rename", handler.RenameGroup)
+		v1.POST("/groups/validate", handler.ValidateGroup)
+		v1.GET("/groups/unassigned", handler.GetUnassignedGroups)
 		v1.GET("/groups/:group/tiers", handler.GetTiersByGroup)
+		v1.DELETE("/groups/:group", handler.RemoveGroupFromAllTiers)
+		v1.GET("/users/:user/tiers", handler.GetTiersForUser)
+		v1.GET("/users/:user/models", handler.GetModelsForUser)
 
 		// LLMInferenceService routes
 		v1.GET("/tiers/:name/llminferenceservices", handler.GetLLMInferenceServicesByTier)
+		v1.GET("/tiers/:name/llminferenceservices/summary", handler.GetLLMInferenceServicesSummaryByTier)
+		v1.POST("/tiers/:name/unlink", handler.UnlinkTier)
 		v1.GET("/groups/:group/llminferenceservices", handler.GetLLMInferenceServicesByGroup)
+		v1.GET("/groups/:group/impact", handler.GetGroupImpact)
+		v1.GET("/llminferenceservices/:namespace/:name", handler.GetLLMInferenceService)
+		v1.POST("/llminferenceservices/annotate", handler.AnnotateLLMInferenceService)
+		v1.POST("/llminferenceservices/annotate/batch", handler.BatchAnnotateLLMInferenceServices)
+		v1.DELETE("/llminferenceservices/annotate", handler.RemoveTierFromLLMInferenceService)
+		v1.DELETE("/llminferenceservices/annotate/all", handler.ClearTiersFromLLMInferenceService)
+		if cfg.enableLLMCreate {
+			v1.POST("/llminferenceservices", handler.CreateLLMInferenceService)
+		}
+		if cfg.enableAnnotateAll {
+			v1.POST("/llminferenceservices/annotate/all", handler.AnnotateLLMInferenceServiceWithAllTiers)
+		}
+
+		// Admin routes
+		v1.POST("/admin/prune-tiers", handler.PruneTiers)
+		v1.POST("/admin/migrate-annotations", handler.MigrateAnnotations)
+		v1.POST("/admin/reconcile", handler.ReconcileTiers)
+
+		// Report routes
+		v1.GET("/reports/tiers", handler.GetTierReport)
 	}
 
-	// Health check endpoint
-	router.GET("/health", func(c *gin.Context) {
-		c.JSON(200, gin.H{"status": "ok"})
-	})
+	// Health check endpoint, used as the liveness probe
+	router.GET("/health", livenessHandler(cfg.livenessChecks))
+
+	// Readiness endpoint reporting each configured component
+	router.GET("/readyz", readinessHandler(cfg.readinessChecks))
+
+	// Prometheus metrics, not prefixed so scrape configs don't depend on the base path
+	if cfg.metrics != nil {
+		router.GET("/metrics", gin.WrapH(promhttp.HandlerFor(cfg.metrics, promhttp.HandlerOpts{})))
+	}
+
+	if cfg.disableSwagger {
+		return router
+	}
 
 	// Swagger documentation endpoint with dynamic host detection
 	// Middleware to update Swagger host from request if ROUTE_HOST env var is not set
@@ -0,0 +1,382 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"maas-toolbox/docs"
+	"maas-toolbox/internal/service"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+func TestSetupRouter_BasePath(t *testing.T) {
+	defer func() { docs.SwaggerInfo.BasePath = DefaultBasePath }()
+
+	tierService := service.NewTierService(createEmptyMockK8sStorage())
+	router := SetupRouter(tierService, newTestLLMServiceService(tierService), WithBasePath("maas/tiers/v1/"))
+
+	tests := []struct {
+		url        string
+		wantStatus int
+	}{
+		{"/maas/tiers/v1/tiers", http.StatusOK},
+		{"/api/v1/tiers", http.StatusNotFound},
+		{"/health", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", tt.url, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+		})
+	}
+
+	if docs.SwaggerInfo.BasePath != "/maas/tiers/v1" {
+		t.Errorf("Expected Swagger BasePath /maas/tiers/v1, got %s", docs.SwaggerInfo.BasePath)
+	}
+}
+
+func TestNormalizeBasePath(t *testing.T) {
+	tests := map[string]string{
+		"":              DefaultBasePath,
+		"/":             DefaultBasePath,
+		"/maas/v1":      "/maas/v1",
+		"maas/v1/":      "/maas/v1",
+		" //maas/v1// ": "/maas/v1",
+	}
+	for input, want := range tests {
+		if got := NormalizeBasePath(input); got != want {
+			t.Errorf("NormalizeBasePath(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestSetupRouter_Swagger(t *testing.T) {
+	tierService := service.NewTierService(createEmptyMockK8sStorage())
+
+	for _, enabled := range []bool{true, false} {
+		router := SetupRouter(tierService, newTestLLMServiceService(tierService), WithSwagger(enabled))
+		req, _ := http.NewRequest("GET", "/swagger/index.html", nil)
+		// gin-swagger matches on RequestURI, which only the server sets
+		req.RequestURI = "/swagger/index.html"
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if got := w.Code != http.StatusNotFound; got != enabled {
+			t.Errorf("WithSwagger(%v): expected served = %v, got status %d", enabled, enabled, w.Code)
+		}
+	}
+}
+
+func TestSetupRouter_LLMInferenceServiceCreate(t *testing.T) {
+	tierService := service.NewTierService(createEmptyMockK8sStorage())
+
+	for _, enabled := range []bool{true, false} {
+		router := SetupRouter(tierService, newTestLLMServiceService(tierService), WithSwagger(false), WithLLMInferenceServiceCreate(enabled))
+		// Missing modelUri is rejected when the route is served
+		body := `{"namespace": "models", "name": "llama", "tier": "free"}`
+		req, _ := http.NewRequest("POST", "/api/v1/llminferenceservices", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		wantStatus := http.StatusNotFound
+		if enabled {
+			wantStatus = http.StatusUnprocessableEntity
+		}
+		if w.Code != wantStatus {
+			t.Errorf("WithLLMInferenceServiceCreate(%v): expected status %d, got %d", enabled, wantStatus, w.Code)
+		}
+	}
+}
+
+func TestSetupRouter_AnnotateAllTiers(t *testing.T) {
+	tierService := service.NewTierService(createEmptyMockK8sStorage())
+
+	for _, enabled := range []bool{true, false} {
+		router := SetupRouter(tierService, newTestLLMServiceService(tierService), WithSwagger(false), WithAnnotateAllTiers(enabled))
+		// Missing name is rejected when the route is served; otherwise only DELETE is allowed
+		req, _ := http.NewRequest("POST", "/api/v1/llminferenceservices/annotate/all", strings.NewReader(`{"namespace": "models"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		wantStatus := http.StatusMethodNotAllowed
+		if enabled {
+			wantStatus = http.StatusUnprocessableEntity
+		}
+		if w.Code != wantStatus {
+			t.Errorf("WithAnnotateAllTiers(%v): expected status %d, got %d", enabled, wantStatus, w.Code)
+		}
+	}
+}
+
+func TestSetupRouter_Metrics(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	tierService := service.NewTierService(createEmptyMockK8sStorage(), service.WithMetrics(registry))
+	router := SetupRouter(tierService, newTestLLMServiceService(tierService), WithSwagger(false), WithMetrics(registry))
+
+	createTestTier(t, router, `{"name": "free", "description": "Free", "level": 1, "groups": ["system:authenticated"]}`)
+
+	req, _ := http.NewRequest("GET", "/metrics", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	want := service.TierOperationsMetric + `{operation="create",outcome="success"} 1`
+	if !strings.Contains(w.Body.String(), want) {
+		t.Errorf("Expected %s in metrics, got:\n%s", want, w.Body.String())
+	}
+
+	// Without WithMetrics the route is not served
+	router = SetupRouter(tierService, newTestLLMServiceService(tierService), WithSwagger(false))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected status %d without WithMetrics, got %d", http.StatusNotFound, w.Code)
+	}
+}
+
+func TestSetupRouter_UnknownRouteAndMethod(t *testing.T) {
+	tierService := service.NewTierService(createEmptyMockK8sStorage())
+	router := SetupRouter(tierService, newTestLLMServiceService(tierService), WithSwagger(false))
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		wantStatus int
+		wantCode   string
+	}{
+		{"unknown path", "GET", "/api/v1/no-such-route", http.StatusNotFound, CodeRouteNotFound},
+		{"unknown path outside the API", "GET", "/no-such-route", http.StatusNotFound, CodeRouteNotFound},
+		{"wrong method on a known path", "PATCH", "/api/v1/tiers", http.StatusMethodNotAllowed, CodeMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(tt.method, tt.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if contentType := w.Header().Get("Content-Type"); !strings.HasPrefix(contentType, "application/json") {
+				t.Errorf("Expected a JSON body, got Content-Type %q", contentType)
+			}
+			var response ErrorResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to decode error response %q: %v", w.Body.String(), err)
+			}
+			if response.Code != tt.wantCode || !strings.Contains(response.Error, tt.path) {
+				t.Errorf("Expected code %s mentioning %s, got %+v", tt.wantCode, tt.path, response)
+			}
+		})
+	}
+}
+
+func TestSetupRouter_TrailingSlash(t *testing.T) {
+	tierService := service.NewTierService(createEmptyMockK8sStorage())
+	router := SetupRouter(tierService, newTestLLMServiceService(tierService), WithSwagger(false))
+
+	tests := []struct {
+		name         string
+		method       string
+		path         string
+		wantStatus   int
+		wantLocation string
+	}{
+		{"list", "GET", "/api/v1/tiers", http.StatusOK, ""},
+		{"list with slash", "GET", "/api/v1/tiers/", http.StatusMovedPermanently, "/api/v1/tiers"},
+		{"list with slash keeps the query", "GET", "/api/v1/tiers/?format=yaml", http.StatusMovedPermanently, "/api/v1/tiers?format=yaml"},
+		{"create with slash keeps the method", "POST", "/api/v1/tiers/", http.StatusTemporaryRedirect, "/api/v1/tiers"},
+		{"get", "GET", "/api/v1/tiers/free", http.StatusNotFound, ""},
+		{"get with slash", "GET", "/api/v1/tiers/free/", http.StatusMovedPermanently, "/api/v1/tiers/free"},
+		{"static route", "GET", "/api/v1/groups/unassigned", http.StatusOK, ""},
+		{"static route with slash", "GET", "/api/v1/groups/unassigned/", http.StatusMovedPermanently, "/api/v1/groups/unassigned"},
+		{"health", "GET", "/health", http.StatusOK, ""},
+		{"health with slash", "GET", "/health/", http.StatusMovedPermanently, "/health"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(tt.method, tt.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if location := w.Header().Get("Location"); location != tt.wantLocation {
+				t.Errorf("Expected Location %q, got %q", tt.wantLocation, location)
+			}
+		})
+	}
+
+	// Unknown paths are not redirected and still get the JSON ErrorResponse
+	for _, path := range []string{"/api/v1/no-such-route/", "/api/v1/tiers/free/unknown/"} {
+		req, _ := http.NewRequest("GET", path, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		var response ErrorResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to decode error response %q: %v", w.Body.String(), err)
+		}
+		if w.Code != http.StatusNotFound || response.Code != CodeRouteNotFound {
+			t.Errorf("%s: expected 404 %s, got %d %+v", path, CodeRouteNotFound, w.Code, response)
+		}
+	}
+}
+
+// blockingClient wraps a clientset so that, while blocking is set, ConfigMap Gets wait for
+// their context to be done and report its error on errs
+type blockingClient struct {
+	kubernetes.Interface
+	blocking atomic.Bool
+	errs     chan error
+}
+
+func (c *blockingClient) CoreV1() corev1client.CoreV1Interface {
+	return blockingCoreV1{CoreV1Interface: c.Interface.CoreV1(), client: c}
+}
+
+type blockingCoreV1 struct {
+	corev1client.CoreV1Interface
+	client *blockingClient
+}
+
+func (c blockingCoreV1) ConfigMaps(namespace string) corev1client.ConfigMapInterface {
+	return blockingConfigMaps{ConfigMapInterface: c.CoreV1Interface.ConfigMaps(namespace), client: c.client}
+}
+
+type blockingConfigMaps struct {
+	corev1client.ConfigMapInterface
+	client *blockingClient
+}
+
+func (c blockingConfigMaps) Get(ctx context.Context, name string, opts metav1.GetOptions) (*corev1.ConfigMap, error) {
+	if !c.client.blocking.Load() {
+		return c.ConfigMapInterface.Get(ctx, name, opts)
+	}
+	select {
+	case <-ctx.Done():
+	case <-time.After(5 * time.Second):
+	}
+	c.client.errs <- ctx.Err()
+	return nil, ctx.Err()
+}
+
+func TestTimeoutHandler(t *testing.T) {
+	mockStore := createEmptyMockK8sStorage()
+	client := &blockingClient{Interface: mockStore.Client, errs: make(chan error, 1)}
+	mockStore.Client = client
+	router, _ := setupTestRouterWithStorage(mockStore)
+	handler := TimeoutHandler(router, 20*time.Millisecond)
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(method, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	// Fast reads are served as usual
+	if w := do("GET", "/api/v1/tiers", ""); w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+
+	// Reads and writes that outlast the timeout get a 503, and the storage call sees its
+	// context cancelled
+	client.blocking.Store(true)
+	for _, tt := range []struct{ method, body string }{
+		{"GET", ""},
+		{"POST", `{"name": "free", "description": "Free tier", "level": 1}`},
+	} {
+		w := do(tt.method, "/api/v1/tiers", tt.body)
+		if w.Code != http.StatusServiceUnavailable {
+			t.Fatalf("%s: expected status %d, got %d: %s", tt.method, http.StatusServiceUnavailable, w.Code, w.Body.String())
+		}
+		if contentType := w.Header().Get("Content-Type"); !strings.HasPrefix(contentType, "application/json") {
+			t.Errorf("%s: expected a JSON body, got Content-Type %q", tt.method, contentType)
+		}
+		var response ErrorResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to unmarshal response: %v", err)
+		}
+		if response.Code != CodeRequestTimeout || !strings.Contains(response.Error, "20ms") {
+			t.Errorf("%s: expected %s naming the timeout, got %+v", tt.method, CodeRequestTimeout, response)
+		}
+		if err := <-client.errs; !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("%s: expected the ConfigMap Get to see the request deadline, got %v", tt.method, err)
+		}
+	}
+
+	// The cancelled write was not applied
+	client.blocking.Store(false)
+	w := do("GET", "/api/v1/tiers/free", "")
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Expected the timed-out create not to be applied, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestTimeoutHandler_BodilessResponses(t *testing.T) {
+	handler := TimeoutHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}), time.Second)
+
+	for _, method := range []string{"DELETE", "HEAD"} {
+		req, _ := http.NewRequest(method, "/api/v1/tiers/free", nil)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		if contentType := w.Header().Get("Content-Type"); contentType != "" {
+			t.Errorf("%s: expected no Content-Type on a bodiless response, got %q", method, contentType)
+		}
+	}
+}
+
+func TestTimeoutHandler_Disabled(t *testing.T) {
+	router, _ := setupTestRouter()
+	if handler := TimeoutHandler(router, 0); handler != http.Handler(router) {
+		t.Error("Expected a zero timeout to return the handler unchanged")
+	}
+}
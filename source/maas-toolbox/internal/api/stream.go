@@ -0,0 +1,80 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// streamKeepaliveInterval is how often an idle change stream sends a comment line
+// so proxies do not close the connection
+const streamKeepaliveInterval = 30 * time.Second
+
+// StreamTierChanges handles GET /api/v1/tiers/stream
+// @Summary      Stream tier changes
+// @Description  Hold a Server-Sent Events connection that emits an event for every tier or tier annotation change. The SSE event name is the change action and the data is the JSON change event.
+// @Tags         tiers
+// @Produce      text/event-stream
+// @Success      200  {object}  webhook.Event  "Stream of change events"
+// @Failure      503  {object}  ErrorResponse  "Too many concurrent streams"
+// @Router       /tiers/stream [get]
+func (h *TierHandler) StreamTierChanges(c *gin.Context) {
+	events, unsubscribe, err := h.service.SubscribeChanges()
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	defer unsubscribe()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	keepalive := time.NewTicker(streamKeepaliveInterval)
+	defer keepalive.Stop()
+
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case <-keepalive.C:
+			if _, err := fmt.Fprint(c.Writer, ": keepalive\n\n"); err != nil {
+				return
+			}
+			c.Writer.Flush()
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				log.Printf("Failed to marshal change event: %v", err)
+				continue
+			}
+			if _, err := fmt.Fprintf(c.Writer, "event: %s\ndata: %s\n\n", event.Action, data); err != nil {
+				return
+			}
+			c.Writer.Flush()
+		}
+	}
+}
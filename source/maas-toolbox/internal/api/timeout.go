@@ -0,0 +1,63 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// streamPathSuffix ends the path of the tier change stream, which is held open indefinitely
+const streamPathSuffix = "/tiers/stream"
+
+// TimeoutHandler wraps handler so requests that run longer than timeout get a 503 ErrorResponse
+// with code REQUEST_TIMEOUT, and their request context is cancelled, which aborts the Kubernetes
+// calls made with it. The tier change stream is held open indefinitely and passes through.
+// A timeout of zero or less returns handler unchanged.
+func TimeoutHandler(handler http.Handler, timeout time.Duration) http.Handler {
+	if timeout <= 0 {
+		return handler
+	}
+	body, _ := json.Marshal(ErrorResponse{
+		Error: fmt.Sprintf("request did not complete within %s", timeout),
+		Code:  CodeRequestTimeout,
+	})
+	timed := http.TimeoutHandler(handler, timeout, string(body))
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, streamPathSuffix) {
+			handler.ServeHTTP(w, r)
+			return
+		}
+		timed.ServeHTTP(timeoutBodyWriter{w}, r)
+	})
+}
+
+// timeoutBodyWriter labels the timeout body as JSON. http.TimeoutHandler writes that body
+// without a Content-Type, while handler responses carry their own headers, so only a 503
+// without one is labelled; bodiless responses such as 204 and HEAD are left as they are.
+type timeoutBodyWriter struct {
+	http.ResponseWriter
+}
+
+func (w timeoutBodyWriter) WriteHeader(code int) {
+	if code == http.StatusServiceUnavailable && w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
@@ -0,0 +1,258 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package client is a typed Go client for the maas-toolbox REST API.
+// It marshals the models types, so callers work with models.Tier and friends
+// instead of hand-rolled HTTP requests.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maas-toolbox/internal/models"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// DefaultBasePath is the path prefix of the API routes on a default deployment
+const DefaultBasePath = "/api/v1"
+
+// Client calls the maas-toolbox API. It is safe for concurrent use.
+type Client struct {
+	baseURL    string
+	basePath   string
+	token      string
+	httpClient *http.Client
+}
+
+// Option configures optional Client behavior
+type Option func(*Client)
+
+// WithToken sends token as a bearer token on every request
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+// WithHTTPClient uses httpClient instead of http.DefaultClient, e.g. to set timeouts or TLS
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithBasePath sets the path prefix of the API routes, for servers started with API_BASE_PATH
+func WithBasePath(basePath string) Option {
+	return func(c *Client) {
+		c.basePath = "/" + strings.Trim(basePath, "/")
+	}
+}
+
+// New creates a Client for the server at baseURL, e.g. https://maas-toolbox.example.com
+func New(baseURL string, opts ...Option) (*Client, error) {
+	parsed, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL: %w", err)
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return nil, fmt.Errorf("invalid base URL %q: scheme must be http or https", baseURL)
+	}
+
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		basePath:   DefaultBasePath,
+		httpClient: http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
+
+// Error is a non-2xx response from the API, decoded from its ErrorResponse body
+type Error struct {
+	StatusCode int    // HTTP status of the response
+	Code       string // Stable machine-readable error code, e.g. TIER_NOT_FOUND
+	Message    string // Human-readable error message
+	Field      string // Request field that failed validation, if any
+	Value      string // Offending value within Field, if any
+}
+
+// Error returns the status and message of the response
+func (e *Error) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("maas-toolbox API returned %d: %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("maas-toolbox API returned %d %s: %s", e.StatusCode, e.Code, e.Message)
+}
+
+// CreateTier creates a tier and returns it as stored
+func (c *Client) CreateTier(ctx context.Context, tier *models.Tier) (*models.Tier, error) {
+	var created models.Tier
+	if err := c.do(ctx, http.MethodPost, "/tiers", tier, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// GetTiers returns all tiers
+func (c *Client) GetTiers(ctx context.Context) ([]models.Tier, error) {
+	var tiers []models.Tier
+	if err := c.do(ctx, http.MethodGet, "/tiers", nil, &tiers); err != nil {
+		return nil, err
+	}
+	return tiers, nil
+}
+
+// GetTier returns the named tier
+func (c *Client) GetTier(ctx context.Context, name string) (*models.Tier, error) {
+	var tier models.Tier
+	if err := c.do(ctx, http.MethodGet, "/tiers/"+url.PathEscape(name), nil, &tier); err != nil {
+		return nil, err
+	}
+	return &tier, nil
+}
+
+// UpdateTier updates the named tier and returns the result. Omitted fields of updates are left unchanged.
+func (c *Client) UpdateTier(ctx context.Context, name string, updates *models.TierUpdate) (*models.Tier, error) {
+	var tier models.Tier
+	if err := c.do(ctx, http.MethodPut, "/tiers/"+url.PathEscape(name), updates, &tier); err != nil {
+		return nil, err
+	}
+	return &tier, nil
+}
+
+// DeleteTier deletes the named tier
+func (c *Client) DeleteTier(ctx context.Context, name string) error {
+	return c.do(ctx, http.MethodDelete, "/tiers/"+url.PathEscape(name), nil, nil)
+}
+
+// AddGroup adds a group to the named tier and returns the updated tier
+func (c *Client) AddGroup(ctx context.Context, tierName, group string) (*models.Tier, error) {
+	var tier models.Tier
+	body := map[string]string{"group": group}
+	if err := c.do(ctx, http.MethodPost, "/tiers/"+url.PathEscape(tierName)+"/groups", body, &tier); err != nil {
+		return nil, err
+	}
+	return &tier, nil
+}
+
+// RemoveGroup removes a group from the named tier and returns the updated tier
+func (c *Client) RemoveGroup(ctx context.Context, tierName, group string) (*models.Tier, error) {
+	var tier models.Tier
+	path := "/tiers/" + url.PathEscape(tierName) + "/groups/" + url.PathEscape(group)
+	if err := c.do(ctx, http.MethodDelete, path, nil, &tier); err != nil {
+		return nil, err
+	}
+	return &tier, nil
+}
+
+// GetLLMInferenceServicesByTier returns the LLMInferenceServices annotated with the named tier
+func (c *Client) GetLLMInferenceServicesByTier(ctx context.Context, tierName string) ([]models.LLMInferenceService, error) {
+	var services []models.LLMInferenceService
+	if err := c.do(ctx, http.MethodGet, "/tiers/"+url.PathEscape(tierName)+"/llminferenceservices", nil, &services); err != nil {
+		return nil, err
+	}
+	return services, nil
+}
+
+// AnnotateLLMInferenceService adds a tier to an LLMInferenceService's tiers annotation
+func (c *Client) AnnotateLLMInferenceService(ctx context.Context, req *models.AnnotateRequest) (*models.AnnotateResult, error) {
+	var result models.AnnotateResult
+	if err := c.do(ctx, http.MethodPost, "/llminferenceservices/annotate", req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// RemoveTierFromLLMInferenceService removes a tier from an LLMInferenceService's tiers annotation
+func (c *Client) RemoveTierFromLLMInferenceService(ctx context.Context, req *models.RemoveTierRequest) (*models.AnnotateResult, error) {
+	var result models.AnnotateResult
+	if err := c.do(ctx, http.MethodDelete, "/llminferenceservices/annotate", req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// do sends a request to path under the base path, encoding body as JSON when it is not nil,
+// and decodes a successful response into out when it is not nil.
+// Non-2xx responses are returned as *Error.
+func (c *Client) do(ctx context.Context, method, path string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request body: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+c.basePath+path, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s %s failed: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return decodeError(resp.StatusCode, data)
+	}
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to decode response body: %w", err)
+	}
+	return nil
+}
+
+// decodeError builds an *Error from a non-2xx response. Bodies that are not an
+// ErrorResponse, such as a proxy's HTML error page, become the message as-is.
+func decodeError(status int, data []byte) error {
+	var body struct {
+		Error string `json:"error"`
+		Code  string `json:"code"`
+		Field string `json:"field"`
+		Value string `json:"value"`
+	}
+	if err := json.Unmarshal(data, &body); err != nil || body.Error == "" {
+		message := strings.TrimSpace(string(data))
+		if message == "" {
+			message = http.StatusText(status)
+		}
+		return &Error{StatusCode: status, Message: message}
+	}
+	return &Error{StatusCode: status, Code: body.Code, Message: body.Error, Field: body.Field, Value: body.Value}
+}
@@ -0,0 +1,253 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"maas-toolbox/internal/api"
+	"maas-toolbox/internal/models"
+	"maas-toolbox/internal/service"
+	"maas-toolbox/internal/storage"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// newTestServer starts an httptest server running the real router over a fake cluster holding
+// the premium-users and vip-users groups and a models/llama LLMInferenceService.
+// It returns the server and a function reporting the last Authorization header received.
+func newTestServer(t *testing.T) (*httptest.Server, func() string) {
+	t.Helper()
+
+	objects := []runtime.Object{
+		&unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "serving.kserve.io/v1alpha1",
+			"kind":       "LLMInferenceService",
+			"metadata":   map[string]interface{}{"name": "llama", "namespace": "models"},
+		}},
+	}
+	for _, group := range []string{"premium-users", "vip-users"} {
+		objects = append(objects, &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "user.openshift.io/v1",
+			"kind":       "Group",
+			"metadata":   map[string]interface{}{"name": group},
+		}})
+	}
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		{Group: "user.openshift.io", Version: "v1", Resource: "groups"}:                     "GroupList",
+		{Group: "serving.kserve.io", Version: "v1alpha1", Resource: "llminferenceservices"}: "LLMInferenceServiceList",
+	}, objects...)
+
+	tierStorage := storage.NewK8sTierStorage(fake.NewSimpleClientset(), dynamicClient, "test", "tier-to-group-mapping")
+	tierService := service.NewTierService(tierStorage)
+	llmServiceService := service.NewLLMInferenceServiceService(tierService, storage.NewLLMInferenceServiceStorage(dynamicClient))
+	router := api.SetupRouter(tierService, llmServiceService, api.WithSwagger(false))
+
+	var mu sync.Mutex
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		authorization = r.Header.Get("Authorization")
+		mu.Unlock()
+		router.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, func() string {
+		mu.Lock()
+		defer mu.Unlock()
+		return authorization
+	}
+}
+
+func TestClient_Tiers(t *testing.T) {
+	server, lastAuthorization := newTestServer(t)
+	c, err := New(server.URL, WithToken("secret"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	ctx := context.Background()
+
+	created, err := c.CreateTier(ctx, &models.Tier{Name: "premium", Description: "Premium", Level: 10, Groups: []string{"premium-users"}})
+	if err != nil {
+		t.Fatalf("CreateTier() error = %v", err)
+	}
+	if created.Name != "premium" || created.Level != 10 {
+		t.Errorf("Expected created premium tier at level 10, got %+v", created)
+	}
+	if got := lastAuthorization(); got != "Bearer secret" {
+		t.Errorf("Expected bearer token header, got %q", got)
+	}
+
+	tier, err := c.AddGroup(ctx, "premium", "vip-users")
+	if err != nil {
+		t.Fatalf("AddGroup() error = %v", err)
+	}
+	if !reflect.DeepEqual(tier.Groups, []string{"premium-users", "vip-users"}) {
+		t.Errorf("Expected groups [premium-users vip-users], got %v", tier.Groups)
+	}
+
+	level := 20
+	tier, err = c.UpdateTier(ctx, "premium", &models.TierUpdate{Level: &level})
+	if err != nil {
+		t.Fatalf("UpdateTier() error = %v", err)
+	}
+	if tier.Level != 20 || tier.Description != "Premium" {
+		t.Errorf("Expected level 20 with description kept, got %+v", tier)
+	}
+
+	tier, err = c.RemoveGroup(ctx, "premium", "premium-users")
+	if err != nil {
+		t.Fatalf("RemoveGroup() error = %v", err)
+	}
+	if !reflect.DeepEqual(tier.Groups, []string{"vip-users"}) {
+		t.Errorf("Expected groups [vip-users], got %v", tier.Groups)
+	}
+
+	tiers, err := c.GetTiers(ctx)
+	if err != nil {
+		t.Fatalf("GetTiers() error = %v", err)
+	}
+	if len(tiers) != 1 || tiers[0].Name != "premium" {
+		t.Errorf("Expected [premium], got %+v", tiers)
+	}
+
+	if err := c.DeleteTier(ctx, "premium"); err != nil {
+		t.Fatalf("DeleteTier() error = %v", err)
+	}
+	_, err = c.GetTier(ctx, "premium")
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected *Error after delete, got %v", err)
+	}
+	if apiErr.StatusCode != http.StatusNotFound || apiErr.Code != api.CodeTierNotFound {
+		t.Errorf("Expected 404 %s, got %d %s", api.CodeTierNotFound, apiErr.StatusCode, apiErr.Code)
+	}
+}
+
+func TestClient_ValidationError(t *testing.T) {
+	server, _ := newTestServer(t)
+	c, err := New(server.URL)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	_, err = c.CreateTier(context.Background(), &models.Tier{Name: "free", Description: "Free", Level: 1, Groups: []string{"missing-users"}})
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected *Error, got %v", err)
+	}
+	if apiErr.StatusCode != http.StatusUnprocessableEntity || apiErr.Code != api.CodeGroupNotFoundInCluster {
+		t.Errorf("Expected 422 %s, got %d %s", api.CodeGroupNotFoundInCluster, apiErr.StatusCode, apiErr.Code)
+	}
+	if apiErr.Field != models.FieldGroups || apiErr.Value != "missing-users" {
+		t.Errorf("Expected field groups with value missing-users, got %q %q", apiErr.Field, apiErr.Value)
+	}
+}
+
+func TestClient_LLMInferenceServices(t *testing.T) {
+	server, _ := newTestServer(t)
+	c, err := New(server.URL)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	ctx := context.Background()
+
+	if _, err := c.CreateTier(ctx, &models.Tier{Name: "premium", Description: "Premium", Level: 10, Groups: []string{"premium-users"}}); err != nil {
+		t.Fatalf("CreateTier() error = %v", err)
+	}
+
+	result, err := c.AnnotateLLMInferenceService(ctx, &models.AnnotateRequest{Namespace: "models", Name: "llama", Tier: "premium"})
+	if err != nil {
+		t.Fatalf("AnnotateLLMInferenceService() error = %v", err)
+	}
+	if !reflect.DeepEqual(result.Tiers, []string{"premium"}) {
+		t.Errorf("Expected tiers [premium], got %v", result.Tiers)
+	}
+
+	services, err := c.GetLLMInferenceServicesByTier(ctx, "premium")
+	if err != nil {
+		t.Fatalf("GetLLMInferenceServicesByTier() error = %v", err)
+	}
+	if len(services) != 1 || services[0].Namespace != "models" || services[0].Name != "llama" {
+		t.Errorf("Expected [models/llama], got %+v", services)
+	}
+
+	result, err = c.RemoveTierFromLLMInferenceService(ctx, &models.RemoveTierRequest{Namespace: "models", Name: "llama", Tier: "premium"})
+	if err != nil {
+		t.Fatalf("RemoveTierFromLLMInferenceService() error = %v", err)
+	}
+	if len(result.Tiers) != 0 {
+		t.Errorf("Expected no tiers left, got %v", result.Tiers)
+	}
+}
+
+func TestClient_BasePath(t *testing.T) {
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	c, err := New(server.URL+"/", WithBasePath("/maas/tiers/v1/"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if _, err := c.GetTiers(context.Background()); err != nil {
+		t.Fatalf("GetTiers() error = %v", err)
+	}
+	if path != "/maas/tiers/v1/tiers" {
+		t.Errorf("Expected /maas/tiers/v1/tiers, got %s", path)
+	}
+}
+
+func TestClient_NonJSONError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "upstream unavailable", http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	c, err := New(server.URL)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	_, err = c.GetTiers(context.Background())
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected *Error, got %v", err)
+	}
+	if apiErr.StatusCode != http.StatusBadGateway || apiErr.Code != "" || apiErr.Message != "upstream unavailable" {
+		t.Errorf("Expected 502 with the body as message, got %+v", apiErr)
+	}
+}
+
+func TestNew_InvalidBaseURL(t *testing.T) {
+	for _, baseURL := range []string{"", "maas-toolbox:8080", "ftp://example.com", "http://[::1"} {
+		if _, err := New(baseURL); err == nil {
+			t.Errorf("New(%q) expected an error", baseURL)
+		}
+	}
+}
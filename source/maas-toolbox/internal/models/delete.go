@@ -0,0 +1,59 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+// BatchDeleteTiersRequest represents a request to delete several tiers
+// @Description Names of the tiers to delete
+type BatchDeleteTiersRequest struct {
+	Names []string `json:"names" example:"test-tier-1,test-tier-2"` // Tier names to delete
+}
+
+// Validate validates a BatchDeleteTiersRequest
+func (r *BatchDeleteTiersRequest) Validate() error {
+	if len(r.Names) == 0 {
+		return NewValidationError(FieldNames, ErrTierNamesRequired)
+	}
+	for _, name := range r.Names {
+		if err := ValidateKubernetesName(name); err != nil {
+			return NewValueValidationError(FieldNames, name, err)
+		}
+	}
+	return nil
+}
+
+// Batch delete tier statuses
+const (
+	TierStatusDeleted    = "deleted"
+	TierStatusNotFound   = "not-found"
+	TierStatusReferenced = "referenced"
+)
+
+// BatchDeleteTierResult reports the outcome for a single tier of a batch delete
+// @Description Per-tier result of a batch delete
+type BatchDeleteTierResult struct {
+	Name         string `json:"name" example:"test-tier-1"`         // Tier name from the request
+	Status       string `json:"status" example:"deleted"`           // deleted, not-found or referenced (kept because services use it)
+	ServiceCount int    `json:"serviceCount,omitempty" example:"2"` // LLMInferenceServices annotated with the tier, when referenced
+}
+
+// BatchDeleteTiersResponse reports the outcome of deleting several tiers
+// @Description Per-tier results of a batch delete
+type BatchDeleteTiersResponse struct {
+	Force      bool                    `json:"force"`                  // Whether tiers referenced by LLMInferenceServices were deleted too
+	Deleted    int                     `json:"deleted" example:"2"`    // Number of tiers deleted
+	NotFound   int                     `json:"notFound" example:"1"`   // Number of tiers that do not exist
+	Referenced int                     `json:"referenced" example:"0"` // Number of tiers kept because LLMInferenceServices use them
+	Results    []BatchDeleteTierResult `json:"results"`                // One result per distinct requested name, in request order
+}
@@ -14,20 +14,126 @@
 
 package models
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
-	ErrTierNameRequired        = errors.New("tier name is required")
-	ErrTierDescriptionRequired = errors.New("tier description is required")
-	ErrTierLevelInvalid        = errors.New("tier level must be non-negative")
-	ErrTierNotFound            = errors.New("tier not found")
-	ErrTierAlreadyExists       = errors.New("tier already exists")
-	ErrTierNameImmutable       = errors.New("tier name cannot be changed")
-	ErrGroupRequired           = errors.New("group name is required")
-	ErrGroupAlreadyExists      = errors.New("group already exists in tier")
-	ErrGroupNotFound           = errors.New("group not found in tier")
-	ErrGroupNotFoundInCluster  = errors.New("group not found in cluster")
-	ErrInvalidKubernetesName   = errors.New("invalid Kubernetes name format: must be 1-253 characters, start and end with alphanumeric, and contain only lowercase alphanumeric, hyphens, colons, dots, or underscores")
-	ErrInvalidTierAnnotation   = errors.New("invalid tier annotation format")
+	ErrTierNameRequired         = errors.New("tier name is required")
+	ErrTierDescriptionRequired  = errors.New("tier description is required")
+	ErrTierDescriptionTooLong   = errors.New("tier description is too long")
+	ErrTierLevelInvalid         = errors.New("tier level must be non-negative")
+	ErrTierLevelRequired        = errors.New("tier level is required")
+	ErrTierLevelTooHigh         = errors.New("tier level exceeds the configured maximum")
+	ErrTierNotFound             = errors.New("tier not found")
+	ErrTierAlreadyExists        = errors.New("tier already exists")
+	ErrTierNameImmutable        = errors.New("tier name cannot be changed")
+	ErrGroupRequired            = errors.New("group name is required")
+	ErrGroupAlreadyExists       = errors.New("group already exists in tier")
+	ErrGroupNotFound            = errors.New("group not found in tier")
+	ErrGroupNotFoundInCluster   = errors.New("group not found in cluster")
+	ErrInvalidGroupColon        = errors.New("invalid group name: colons are only allowed in system: groups (e.g. system:authenticated) and namespace-scoped <namespace>:<name> groups")
+	ErrInvalidKubernetesName    = errors.New("invalid Kubernetes name format: must be 1-253 characters, start and end with alphanumeric, and contain only lowercase alphanumeric, hyphens, colons, dots, or underscores")
+	ErrInvalidTierAnnotation    = errors.New("invalid tier annotation format")
+	ErrNamespaceRequired        = errors.New("namespace is required")
+	ErrLLMServiceNameRequired   = errors.New("LLMInferenceService name is required")
+	ErrLLMServiceNotFound       = errors.New("LLMInferenceService not found")
+	ErrTargetsRequired          = errors.New("at least one target is required")
+	ErrTierNotInAnnotation      = errors.New("tier not found in LLMInferenceService annotation")
+	ErrInvalidPatch             = errors.New("invalid patch document")
+	ErrInvalidUsername          = errors.New("invalid user name: must be 1-253 characters without whitespace, '/' or '%', and not '.' or '..'")
+	ErrUserNotFound             = errors.New("user not found")
+	ErrGroupLookupDenied        = errors.New("access to OpenShift users or groups is denied: grant get and list on users and groups in user.openshift.io")
+	ErrInvalidContinueToken     = errors.New("invalid or expired continue token")
+	ErrNamespaceNotFound        = errors.New("tier ConfigMap namespace not found")
+	ErrConfigMapWriteDenied     = errors.New("write access to the tier ConfigMap is denied")
+	ErrUnsupportedPatchType     = errors.New("unsupported patch content type: use application/json-patch+json or application/merge-patch+json")
+	ErrTooManySubscribers       = errors.New("too many concurrent change stream subscribers")
+	ErrTierLevelConflict        = errors.New("another tier already has this level")
+	ErrTierNotDeleted           = errors.New("tier is not deleted")
+	ErrTierSoftDeleted          = errors.New("a soft-deleted tier with this name exists: restore or purge it first")
+	ErrTierGroupsRequired       = errors.New("tier must have at least one group")
+	ErrConfirmationRequired     = errors.New("this operation is destructive: pass confirm=true, or dryRun=true to preview")
+	ErrAnnotationKeyRequired    = errors.New("annotation key is required")
+	ErrInvalidAnnotationKey     = errors.New("invalid annotation key: must be a qualified name such as maas.opendatahub.io/tiers")
+	ErrAnnotationKeysIdentical  = errors.New("oldKey and newKey must differ")
+	ErrInvalidGroupPattern      = errors.New("invalid group pattern: must be a group name prefix followed by a single trailing '*', e.g. team:*")
+	ErrConcurrentModification   = errors.New("the tier configuration was modified concurrently: retry the request")
+	ErrModelURIRequired         = errors.New("model URI is required")
+	ErrLLMServiceAlreadyExists  = errors.New("LLMInferenceService already exists")
+	ErrLLMNamespaceNotFound     = errors.New("LLMInferenceService namespace not found")
+	ErrTierETagMismatch         = errors.New("the tier changed since it was read: get it again for a fresh ETag and retry")
+	ErrTierLimitReached         = errors.New("the maximum number of tiers has been reached: delete or purge tiers first")
+	ErrTierMetadataTooLarge     = errors.New("tier metadata has too many entries")
+	ErrTierMetadataKeyRequired  = errors.New("tier metadata keys must not be empty")
+	ErrTierMetadataKeyTooLong   = errors.New("tier metadata key is too long")
+	ErrTierMetadataValueTooLong = errors.New("tier metadata value is too long")
+	ErrTierNamesRequired        = errors.New("at least one tier name is required")
+	ErrTierNameReserved         = errors.New("tier name is reserved by an API route under /tiers")
+)
+
+// Field names reported by ValidationError
+const (
+	FieldName        = "name"
+	FieldDescription = "description"
+	FieldLevel       = "level"
+	FieldGroups      = "groups"
+	FieldNamespace   = "namespace"
+	FieldTier        = "tier"
+	FieldTargets     = "targets"
+	FieldFrom        = "from"
+	FieldTo          = "to"
+	FieldOldKey      = "oldKey"
+	FieldNewKey      = "newKey"
+	FieldModelURI    = "modelUri"
+	FieldMetadata    = "metadata"
+	FieldNames       = "names"
 )
 
+// LengthError wraps a sentinel for a value that exceeds a length limit,
+// reporting the actual and maximum lengths in characters
+type LengthError struct {
+	Length    int
+	MaxLength int
+	Err       error
+}
+
+// Error returns the wrapped sentinel's message with both lengths
+func (e *LengthError) Error() string {
+	return fmt.Sprintf("%v: %d characters, maximum is %d", e.Err, e.Length, e.MaxLength)
+}
+
+// Unwrap returns the wrapped sentinel
+func (e *LengthError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationError wraps a validation sentinel with the field that failed validation.
+// Value holds the offending value when the field is a list (e.g. the specific group).
+// errors.Is against the wrapped sentinel continues to work.
+type ValidationError struct {
+	Field string
+	Value string
+	Err   error
+}
+
+// NewValidationError returns a ValidationError for field wrapping err
+func NewValidationError(field string, err error) error {
+	return &ValidationError{Field: field, Err: err}
+}
+
+// NewValueValidationError returns a ValidationError for a specific value within field
+func NewValueValidationError(field, value string, err error) error {
+	return &ValidationError{Field: field, Value: value, Err: err}
+}
+
+// Error returns the message of the wrapped sentinel
+func (e *ValidationError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the wrapped sentinel
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
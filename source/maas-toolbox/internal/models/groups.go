@@ -0,0 +1,115 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+// RemoveGroupResponse summarizes removing a group from every tier that lists it
+// @Description The group removed and the tiers it was (or, for a dry run, would be) removed from
+type RemoveGroupResponse struct {
+	Group  string   `json:"group" example:"premium-users"` // Group that was removed
+	DryRun bool     `json:"dryRun" example:"false"`        // True if no changes were made
+	Tiers  []string `json:"tiers" example:"premium,vip"`   // Tiers that listed the group
+}
+
+// BatchAddGroupsRequest represents a request to add several groups to a tier
+// @Description Groups to add to the tier
+type BatchAddGroupsRequest struct {
+	Groups []string `json:"groups" example:"premium-users,vip-users"` // Kubernetes group names or wildcard patterns to add
+}
+
+// Validate validates a BatchAddGroupsRequest. Individual groups are validated per group
+// so that every invalid entry can be reported at once.
+func (r *BatchAddGroupsRequest) Validate() error {
+	if len(r.Groups) == 0 {
+		return NewValidationError(FieldGroups, ErrGroupRequired)
+	}
+	return nil
+}
+
+// Batch add group statuses
+const (
+	GroupStatusAdded          = "added"
+	GroupStatusAlreadyPresent = "already-present"
+	GroupStatusValid          = "valid"
+	GroupStatusInvalid        = "invalid"
+)
+
+// BatchGroupResult reports the outcome for a single group of a batch add
+// @Description Per-group result of a batch add
+type BatchGroupResult struct {
+	Group  string `json:"group" example:"premium-users"`                        // Group from the request
+	Status string `json:"status" example:"added"`                               // added, already-present, valid (checked but not saved) or invalid
+	Error  string `json:"error,omitempty" example:"group not found in cluster"` // Why the group is invalid
+}
+
+// BatchAddGroupsResponse reports the outcome of adding several groups to a tier
+// @Description Per-group results of a batch add and whether any changes were saved
+type BatchAddGroupsResponse struct {
+	Tier    string             `json:"tier" example:"premium"` // Tier the groups were added to
+	Partial bool               `json:"partial"`                // Whether valid groups are saved even when others are invalid
+	Applied bool               `json:"applied"`                // Whether the valid groups were saved
+	Invalid int                `json:"invalid" example:"0"`    // Number of invalid groups
+	Results []BatchGroupResult `json:"results"`                // One result per requested group, in request order
+}
+
+// RenameGroupRequest represents a request to rename a group in every tier that lists it
+// @Description Current and new group name
+type RenameGroupRequest struct {
+	From string `json:"from" example:"old-premium-users"` // Group name to replace
+	To   string `json:"to" example:"premium-users"`       // New group name
+}
+
+// Validate validates a RenameGroupRequest
+func (r *RenameGroupRequest) Validate() error {
+	if err := ValidateTierGroup(r.From); err != nil {
+		return NewValidationError(FieldFrom, err)
+	}
+	if err := ValidateTierGroup(r.To); err != nil {
+		return NewValidationError(FieldTo, err)
+	}
+	return nil
+}
+
+// RenameGroupResponse summarizes renaming a group across tiers
+// @Description The rename applied and the tiers that were modified
+type RenameGroupResponse struct {
+	From  string   `json:"from" example:"old-premium-users"` // Group name that was replaced
+	To    string   `json:"to" example:"premium-users"`       // New group name
+	Tiers []string `json:"tiers" example:"premium,vip"`      // Tiers that listed the old name
+}
+
+// UnassignedGroupsResponse lists the cluster groups that no tier references
+// @Description Cluster groups not matched by any tier group or wildcard pattern
+type UnassignedGroupsResponse struct {
+	IncludeSystem bool     `json:"includeSystem" example:"false"`        // Whether system: groups were considered
+	ClusterGroups int      `json:"clusterGroups" example:"12"`           // Number of cluster groups considered
+	Groups        []string `json:"groups" example:"contractors,interns"` // Groups no tier references, sorted
+}
+
+// ValidateGroupRequest represents a request to validate a group name
+// @Description Raw group name to validate
+type ValidateGroupRequest struct {
+	Group string `json:"group" example:" Premium-Users "` // Group name as entered, untrimmed
+}
+
+// ValidateGroupResponse reports whether a group name is accepted and its normalized form
+// @Description Validation result for a raw and a normalized group name
+type ValidateGroupResponse struct {
+	Group           string `json:"group" example:" Premium-Users "`                           // Group name from the request
+	Valid           bool   `json:"valid" example:"false"`                                     // Whether the group name is accepted as submitted
+	Normalized      string `json:"normalized" example:"premium-users"`                        // Group name trimmed and lowercased
+	NormalizedValid bool   `json:"normalizedValid" example:"true"`                            // Whether the normalized group name is accepted
+	Reason          string `json:"reason,omitempty" example:"invalid Kubernetes name format"` // Why the submitted group name is rejected
+	Code            string `json:"code,omitempty" example:"INVALID_KUBERNETES_NAME"`          // Error code of the rejection, as in an error response
+}
@@ -0,0 +1,45 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+// Import result statuses
+const (
+	ImportStatusApplied = "applied"
+	ImportStatusSkipped = "skipped"
+)
+
+// Import actions for applied tiers
+const (
+	ImportActionCreated = "created"
+	ImportActionUpdated = "updated"
+)
+
+// ImportResult represents the outcome of importing a single tier
+// @Description Outcome of importing a single tier
+type ImportResult struct {
+	Name   string `json:"name" example:"premium"`                                     // Tier name
+	Status string `json:"status" example:"applied"`                                   // "applied" or "skipped"
+	Action string `json:"action,omitempty" example:"created"`                         // "created" or "updated" when applied
+	Reason string `json:"reason,omitempty" example:"tier level must be non-negative"` // Why the tier was skipped
+}
+
+// ImportResponse represents the result of importing a tier configuration
+// @Description Per-tier outcomes of an import
+type ImportResponse struct {
+	Partial bool           `json:"partial" example:"false"` // Whether invalid tiers were skipped instead of failing the import
+	Applied int            `json:"applied" example:"2"`     // Number of tiers created or updated
+	Skipped int            `json:"skipped" example:"0"`     // Number of tiers skipped
+	Results []ImportResult `json:"results"`                 // Per-tier outcomes in request order
+}
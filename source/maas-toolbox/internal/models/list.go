@@ -0,0 +1,24 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+// ListResponse is the envelope list endpoints return when paginate=true.
+// Every list endpoint uses the same shape so clients can share one decoder.
+// @Description A list of items with a total count and the token for the next page
+type ListResponse struct {
+	Items    interface{} `json:"items" yaml:"items" swaggertype:"array,object"`                   // Items in this response
+	Total    int         `json:"total" yaml:"total" example:"2"`                                  // Number of items in the full result, or in this page when continue is set
+	Continue string      `json:"continue,omitempty" yaml:"continue,omitempty" example:"eyJ2Ijoi"` // Token for the next page; empty on the last page
+}
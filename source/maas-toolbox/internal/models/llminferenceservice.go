@@ -17,6 +17,8 @@ package models
 import (
 	"encoding/json"
 	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 // TierAnnotationKey is the annotation key used to store tier information
@@ -25,10 +27,35 @@ const TierAnnotationKey = "alpha.maas.opendatahub.io/tiers"
 // LLMInferenceService represents an LLMInferenceService custom resource
 // @Description LLMInferenceService custom resource from KServe
 type LLMInferenceService struct {
-	Name      string                 `json:"name" example:"acme-dev-model"`                            // Name of the LLMInferenceService
-	Namespace string                 `json:"namespace" example:"acme-inc-models"`                      // Namespace where the service is deployed
-	Tiers     []string               `json:"tiers" example:"acme-dev-users-tier,acme-prod-users-tier"` // List of tiers associated with this service
-	Spec      map[string]interface{} `json:"spec"`                                                     // Full spec of the LLMInferenceService
+	Name      string                 `json:"name" example:"acme-dev-model"`                                      // Name of the LLMInferenceService
+	Namespace string                 `json:"namespace" example:"acme-inc-models"`                                // Namespace where the service is deployed
+	Tiers     []string               `json:"tiers" example:"acme-dev-users-tier,acme-prod-users-tier"`           // List of tiers associated with this service
+	ModelName string                 `json:"modelName,omitempty" example:"llama-3-1-8b"`                         // spec.model.name, if set
+	ModelURI  string                 `json:"modelUri,omitempty" example:"hf://meta-llama/Llama-3.1-8B-Instruct"` // spec.model.uri, if set
+	Replicas  *int32                 `json:"replicas,omitempty" example:"1"`                                     // spec.replicas, if set
+	Spec      map[string]interface{} `json:"spec"`                                                               // Full spec of the LLMInferenceService
+}
+
+// LLMInferenceServicePage represents one page of a paginated LLMInferenceService listing
+// @Description A page of LLMInferenceServices and the token for the next page
+type LLMInferenceServicePage struct {
+	Items    []LLMInferenceService `json:"items"`                                 // Matching services in this page (may be fewer than limit)
+	Continue string                `json:"continue,omitempty" example:"eyJ2Ijoi"` // Token for the next page; empty on the last page
+}
+
+// NamespaceCount is the number of LLMInferenceServices in one namespace
+// @Description A namespace and how many matching LLMInferenceServices it hosts
+type NamespaceCount struct {
+	Namespace string `json:"namespace" example:"acme-inc-models"` // Namespace name
+	Count     int    `json:"count" example:"2"`                   // Number of services in the namespace
+}
+
+// TierServicesSummary summarizes the LLMInferenceServices annotated with a tier
+// @Description Total services with the tier and a breakdown by namespace, largest first
+type TierServicesSummary struct {
+	Tier       string           `json:"tier" example:"premium"` // Tier name
+	Total      int              `json:"total" example:"3"`      // Number of services with the tier
+	Namespaces []NamespaceCount `json:"namespaces"`             // Services per namespace, by count descending then name
 }
 
 // ParseTiersFromAnnotation parses the tiers annotation value (JSON array string) into a slice of tier names
@@ -45,6 +72,170 @@ func ParseTiersFromAnnotation(annotationValue string) ([]string, error) {
 	return tiers, nil
 }
 
+// FormatTiersAnnotation formats a slice of tier names as the tiers annotation value (JSON array string)
+func FormatTiersAnnotation(tiers []string) (string, error) {
+	if tiers == nil {
+		tiers = []string{}
+	}
+	data, err := json.Marshal(tiers)
+	if err != nil {
+		return "", fmt.Errorf("failed to format tiers annotation: %w", err)
+	}
+	return string(data), nil
+}
+
+// LLMInferenceServiceRef identifies a single LLMInferenceService
+// @Description Reference to an LLMInferenceService by namespace and name
+type LLMInferenceServiceRef struct {
+	Namespace string `json:"namespace" example:"acme-inc-models"` // Namespace of the LLMInferenceService
+	Name      string `json:"name" example:"acme-dev-model"`       // Name of the LLMInferenceService
+}
+
+// Validate validates an LLMInferenceServiceRef
+func (r *LLMInferenceServiceRef) Validate() error {
+	if r.Namespace == "" {
+		return NewValidationError(FieldNamespace, ErrNamespaceRequired)
+	}
+	if r.Name == "" {
+		return NewValidationError(FieldName, ErrLLMServiceNameRequired)
+	}
+	return nil
+}
+
+// ResolvedTier is a tier named in an LLMInferenceService's tiers annotation
+// @Description A tier from the annotation and whether it is still defined
+type ResolvedTier struct {
+	Name    string `json:"name" example:"acme-dev-users-tier"` // Tier name from the annotation
+	Defined bool   `json:"defined" example:"true"`             // True if the tier exists in the tier configuration and is not deleted
+}
+
+// LLMInferenceServiceDetail is an LLMInferenceService with its annotated tiers resolved
+// @Description LLMInferenceService with each annotated tier checked against the tier configuration
+type LLMInferenceServiceDetail struct {
+	LLMInferenceService
+	ResolvedTiers []ResolvedTier `json:"resolvedTiers"` // Annotated tiers, in annotation order
+}
+
+// AnnotateRequest represents a request to add a tier to an LLMInferenceService
+// @Description Request body for adding a tier to an LLMInferenceService annotation
+type AnnotateRequest struct {
+	Namespace string `json:"namespace" example:"acme-inc-models"` // Namespace of the LLMInferenceService
+	Name      string `json:"name" example:"acme-dev-model"`       // Name of the LLMInferenceService
+	Tier      string `json:"tier" example:"acme-dev-users-tier"`  // Tier to add to the annotation
+}
+
+// Validate validates an AnnotateRequest
+func (r *AnnotateRequest) Validate() error {
+	ref := LLMInferenceServiceRef{Namespace: r.Namespace, Name: r.Name}
+	if err := ref.Validate(); err != nil {
+		return err
+	}
+	if r.Tier == "" {
+		return NewValidationError(FieldTier, ErrTierNameRequired)
+	}
+	// Reject malformed names before the tier lookup, which would report them as not found
+	if err := ValidateKubernetesName(r.Tier); err != nil {
+		return NewValidationError(FieldTier, err)
+	}
+	return nil
+}
+
+// RemoveTierRequest represents a request to remove a tier from an LLMInferenceService
+// @Description Request body for removing a tier from an LLMInferenceService annotation
+type RemoveTierRequest struct {
+	Namespace string `json:"namespace" example:"acme-inc-models"` // Namespace of the LLMInferenceService
+	Name      string `json:"name" example:"acme-dev-model"`       // Name of the LLMInferenceService
+	Tier      string `json:"tier" example:"acme-dev-users-tier"`  // Tier to remove from the annotation
+}
+
+// Validate validates a RemoveTierRequest
+func (r *RemoveTierRequest) Validate() error {
+	ref := LLMInferenceServiceRef{Namespace: r.Namespace, Name: r.Name}
+	if err := ref.Validate(); err != nil {
+		return err
+	}
+	if r.Tier == "" {
+		return NewValidationError(FieldTier, ErrTierNameRequired)
+	}
+	if err := ValidateKubernetesName(r.Tier); err != nil {
+		return NewValidationError(FieldTier, err)
+	}
+	return nil
+}
+
+// ClearTiersResponse reports the tiers removed from an LLMInferenceService's tiers annotation
+// @Description The LLMInferenceService, the tiers that were removed and the resulting empty tier list
+type ClearTiersResponse struct {
+	Namespace string   `json:"namespace" example:"acme-inc-models"`                        // Namespace of the LLMInferenceService
+	Name      string   `json:"name" example:"acme-dev-model"`                              // Name of the LLMInferenceService
+	Removed   []string `json:"removed" example:"acme-dev-users-tier,acme-prod-users-tier"` // Tiers the annotation held before it was cleared
+	Tiers     []string `json:"tiers" example:""`                                           // Resulting tiers, always empty
+}
+
+// AnnotateAllTiersResponse reports the tiers added to an LLMInferenceService's tiers annotation
+// @Description The LLMInferenceService, the tiers that were added and the resulting tier list
+type AnnotateAllTiersResponse struct {
+	Namespace string   `json:"namespace" example:"acme-inc-models"`                      // Namespace of the LLMInferenceService
+	Name      string   `json:"name" example:"acme-dev-model"`                            // Name of the LLMInferenceService
+	Added     []string `json:"added" example:"acme-prod-users-tier"`                     // Configured tiers the annotation did not already hold
+	Tiers     []string `json:"tiers" example:"acme-dev-users-tier,acme-prod-users-tier"` // Resulting tiers on the LLMInferenceService
+}
+
+// BatchAnnotateRequest represents a request to add a tier to many LLMInferenceServices
+// @Description Request body for adding a tier to multiple LLMInferenceService annotations
+type BatchAnnotateRequest struct {
+	Tier    string                   `json:"tier" example:"acme-dev-users-tier"` // Tier to add to every target
+	Targets []LLMInferenceServiceRef `json:"targets"`                            // LLMInferenceServices to annotate
+}
+
+// Validate validates a BatchAnnotateRequest
+func (r *BatchAnnotateRequest) Validate() error {
+	if r.Tier == "" {
+		return NewValidationError(FieldTier, ErrTierNameRequired)
+	}
+	if len(r.Targets) == 0 {
+		return NewValidationError(FieldTargets, ErrTargetsRequired)
+	}
+	return nil
+}
+
+// Annotation result statuses
+const (
+	AnnotateStatusSucceeded = "succeeded"
+	AnnotateStatusFailed    = "failed"
+	AnnotateStatusDryRun    = "dry-run"
+)
+
+// AnnotateResult reports the outcome of annotating a single LLMInferenceService
+// @Description Per-target result of an annotation change
+type AnnotateResult struct {
+	Namespace string   `json:"namespace" example:"acme-inc-models"`                     // Namespace of the LLMInferenceService
+	Name      string   `json:"name" example:"acme-dev-model"`                           // Name of the LLMInferenceService
+	Status    string   `json:"status" example:"succeeded"`                              // succeeded, failed, or dry-run
+	Error     string   `json:"error,omitempty" example:"LLMInferenceService not found"` // Failure reason when status is failed
+	Tiers     []string `json:"tiers,omitempty" example:"acme-dev-users-tier"`           // Resulting tiers when status is succeeded
+}
+
+// BatchAnnotateResponse summarizes a batch annotation
+// @Description Summary and per-target results of a batch annotation
+type BatchAnnotateResponse struct {
+	Tier      string           `json:"tier" example:"acme-dev-users-tier"` // Tier that was applied
+	Succeeded int              `json:"succeeded" example:"2"`              // Number of targets annotated
+	Failed    int              `json:"failed" example:"1"`                 // Number of targets that failed
+	Results   []AnnotateResult `json:"results"`                            // Per-target results
+}
+
+// UnlinkTierResponse summarizes removing a tier from every LLMInferenceService that references it
+// @Description Summary and per-service results of unlinking a tier
+type UnlinkTierResponse struct {
+	Tier     string           `json:"tier" example:"acme-dev-users-tier"` // Tier that was unlinked
+	DryRun   bool             `json:"dryRun" example:"false"`             // True if no changes were made
+	Matched  int              `json:"matched" example:"3"`                // Number of services annotated with the tier
+	Unlinked int              `json:"unlinked" example:"3"`               // Number of services the tier was removed from
+	Failed   int              `json:"failed" example:"0"`                 // Number of services that could not be updated
+	Results  []AnnotateResult `json:"results"`                            // Per-service results
+}
+
 // HasTier checks if the service has the specified tier in its tiers list
 func (l *LLMInferenceService) HasTier(tierName string) bool {
 	for _, tier := range l.Tiers {
@@ -54,3 +245,79 @@ func (l *LLMInferenceService) HasTier(tierName string) bool {
 	}
 	return false
 }
+
+// CreateLLMInferenceServiceRequest represents a request to create an LLMInferenceService annotated with a tier
+// @Description Minimal LLMInferenceService to create, pre-annotated with a tier
+type CreateLLMInferenceServiceRequest struct {
+	Namespace string `json:"namespace" example:"acme-inc-models"`                      // Namespace to create the LLMInferenceService in
+	Name      string `json:"name" example:"acme-dev-model"`                            // Name of the LLMInferenceService
+	ModelURI  string `json:"modelUri" example:"hf://meta-llama/Llama-3.1-8B-Instruct"` // URI of the model to serve (spec.model.uri)
+	Tier      string `json:"tier" example:"acme-dev-users-tier"`                       // Tier to set in the tiers annotation
+}
+
+// Validate validates a CreateLLMInferenceServiceRequest. Names must be valid for a new
+// resource: a DNS-1123 label for the namespace and a DNS-1123 subdomain for the name.
+func (r *CreateLLMInferenceServiceRequest) Validate() error {
+	ref := LLMInferenceServiceRef{Namespace: r.Namespace, Name: r.Name}
+	if err := ref.Validate(); err != nil {
+		return err
+	}
+	if len(validation.IsDNS1123Label(r.Namespace)) > 0 {
+		return NewValidationError(FieldNamespace, ErrInvalidKubernetesName)
+	}
+	if len(validation.IsDNS1123Subdomain(r.Name)) > 0 {
+		return NewValidationError(FieldName, ErrInvalidKubernetesName)
+	}
+	if r.ModelURI == "" {
+		return NewValidationError(FieldModelURI, ErrModelURIRequired)
+	}
+	if r.Tier == "" {
+		return NewValidationError(FieldTier, ErrTierNameRequired)
+	}
+	return nil
+}
+
+// MigrateAnnotationsRequest represents a request to move tier lists from one annotation key to another
+// @Description Old and new tiers annotation keys and whether to remove the old key
+type MigrateAnnotationsRequest struct {
+	OldKey    string `json:"oldKey" example:"alpha.maas.opendatahub.io/tiers"` // Annotation key to migrate from
+	NewKey    string `json:"newKey" example:"maas.opendatahub.io/tiers"`       // Annotation key to migrate to
+	RemoveOld bool   `json:"removeOld" example:"true"`                         // Remove the old annotation after merging
+}
+
+// Validate validates a MigrateAnnotationsRequest
+func (r *MigrateAnnotationsRequest) Validate() error {
+	if err := validateAnnotationKey(r.OldKey); err != nil {
+		return NewValidationError(FieldOldKey, err)
+	}
+	if err := validateAnnotationKey(r.NewKey); err != nil {
+		return NewValidationError(FieldNewKey, err)
+	}
+	if r.OldKey == r.NewKey {
+		return NewValidationError(FieldNewKey, ErrAnnotationKeysIdentical)
+	}
+	return nil
+}
+
+// validateAnnotationKey checks that key is a valid Kubernetes annotation key
+func validateAnnotationKey(key string) error {
+	if key == "" {
+		return ErrAnnotationKeyRequired
+	}
+	if len(validation.IsQualifiedName(key)) > 0 {
+		return ErrInvalidAnnotationKey
+	}
+	return nil
+}
+
+// MigrateAnnotationsResponse summarizes migrating tiers annotations between keys
+// @Description Summary and per-service results of an annotation key migration
+type MigrateAnnotationsResponse struct {
+	OldKey   string           `json:"oldKey" example:"alpha.maas.opendatahub.io/tiers"` // Annotation key migrated from
+	NewKey   string           `json:"newKey" example:"maas.opendatahub.io/tiers"`       // Annotation key migrated to
+	DryRun   bool             `json:"dryRun" example:"false"`                           // True if no changes were made
+	Matched  int              `json:"matched" example:"3"`                              // Number of services carrying the old key
+	Migrated int              `json:"migrated" example:"3"`                             // Number of services migrated
+	Failed   int              `json:"failed" example:"0"`                               // Number of services that could not be migrated
+	Results  []AnnotateResult `json:"results"`                                          // Per-service results, with the merged tiers
+}
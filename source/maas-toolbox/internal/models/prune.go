@@ -0,0 +1,52 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PruneCriteria selects which tiers a prune removes
+type PruneCriteria string
+
+const (
+	// PruneEmpty prunes tiers with no groups
+	PruneEmpty PruneCriteria = "empty"
+	// PruneUnreferenced prunes tiers no LLMInferenceService is annotated with
+	PruneUnreferenced PruneCriteria = "unreferenced"
+	// PruneBoth prunes tiers that are both empty and unreferenced
+	PruneBoth PruneCriteria = "both"
+)
+
+// ParsePruneCriteria parses a PruneCriteria, defaulting to PruneBoth when empty
+func ParsePruneCriteria(value string) (PruneCriteria, error) {
+	switch criteria := PruneCriteria(strings.ToLower(strings.TrimSpace(value))); criteria {
+	case "":
+		return PruneBoth, nil
+	case PruneEmpty, PruneUnreferenced, PruneBoth:
+		return criteria, nil
+	default:
+		return "", fmt.Errorf("criteria must be one of %s, %s, %s", PruneEmpty, PruneUnreferenced, PruneBoth)
+	}
+}
+
+// PruneTiersResponse lists the tiers removed by a prune
+// @Description The criteria applied and the tiers that were (or, for a dry run, would be) pruned
+type PruneTiersResponse struct {
+	Criteria PruneCriteria `json:"criteria" example:"both"`   // Criteria the pruned tiers matched
+	DryRun   bool          `json:"dryRun" example:"false"`    // True if no changes were made
+	Pruned   []string      `json:"pruned" example:"old-tier"` // Tiers that matched the criteria
+}
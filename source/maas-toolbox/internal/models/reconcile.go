@@ -0,0 +1,48 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+// ReconcileResult reports the orphaned tier references stripped from one LLMInferenceService
+// @Description Per-service result of reconciling tiers annotations against the tier configuration
+type ReconcileResult struct {
+	Namespace string   `json:"namespace" example:"acme-inc-models"`                     // Namespace of the LLMInferenceService
+	Name      string   `json:"name" example:"acme-dev-model"`                           // Name of the LLMInferenceService
+	Status    string   `json:"status" example:"succeeded"`                              // succeeded, failed, or dry-run
+	Error     string   `json:"error,omitempty" example:"LLMInferenceService not found"` // Failure reason when status is failed
+	Orphaned  []string `json:"orphaned" example:"retired-tier"`                         // Annotated tiers missing from the tier configuration
+	Tiers     []string `json:"tiers" example:"acme-dev-users-tier"`                     // Tiers left once the orphaned tiers are removed
+}
+
+// ReconcileWarning flags an LLMInferenceService whose annotated tiers grant no group access
+// @Description An LLMInferenceService annotated only with tiers that have no groups
+type ReconcileWarning struct {
+	Namespace string   `json:"namespace" example:"acme-inc-models"`                            // Namespace of the LLMInferenceService
+	Name      string   `json:"name" example:"acme-dev-model"`                                  // Name of the LLMInferenceService
+	Tiers     []string `json:"tiers" example:"empty-tier"`                                     // Tiers left once the orphaned tiers are removed
+	Message   string   `json:"message" example:"no annotated tier grants access to any group"` // Why the service was flagged
+}
+
+// ReconcileResponse summarizes reconciling tiers annotations against the tier configuration
+// @Description Summary, per-service results and optional access warnings of a reconcile
+type ReconcileResponse struct {
+	DryRun     bool               `json:"dryRun" example:"false"` // True if no changes were made
+	Scanned    int                `json:"scanned" example:"12"`   // Number of LLMInferenceServices scanned
+	Matched    int                `json:"matched" example:"2"`    // Number of services with orphaned tier references
+	Reconciled int                `json:"reconciled" example:"2"` // Number of services whose orphaned references were removed
+	Failed     int                `json:"failed" example:"0"`     // Number of services that could not be updated
+	Skipped    int                `json:"skipped" example:"0"`    // Number of services skipped for an invalid tiers annotation
+	Results    []ReconcileResult  `json:"results"`                // Per-service results for the matched services
+	Warnings   []ReconcileWarning `json:"warnings,omitempty"`     // Services left without group access, when checkAccess is set
+}
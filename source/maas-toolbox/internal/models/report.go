@@ -0,0 +1,59 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+// TierReportRow summarizes one tier in the tier usage report
+// @Description A tier's level and group count, with live service usage when requested
+type TierReportRow struct {
+	Name         string   `json:"name" example:"premium"`                         // Tier name
+	Level        int      `json:"level" example:"10"`                             // Tier level
+	GroupCount   int      `json:"groupCount" example:"2"`                         // Number of groups in the tier
+	ServiceCount *int     `json:"serviceCount,omitempty" example:"3"`             // LLMInferenceServices annotated with the tier, when services are included
+	Namespaces   []string `json:"namespaces,omitempty" example:"acme-inc-models"` // Sorted namespaces of those services, when services are included
+}
+
+// TierReport is the tier usage report, ordered by level descending
+// @Description Per-tier usage rows, highest level first
+type TierReport struct {
+	Tiers            []TierReportRow `json:"tiers"`                                 // One row per active tier
+	ServicesIncluded bool            `json:"servicesIncluded" example:"true"`       // True if live LLMInferenceServices were scanned
+	SkippedServices  int             `json:"skippedServices,omitempty" example:"0"` // Services skipped because their tiers annotation is invalid
+}
+
+// GroupImpactTier is a tier containing a group, in the group impact report
+// @Description A tier the group belongs to and the number of services annotated with it
+type GroupImpactTier struct {
+	Name         string `json:"name" example:"premium"`   // Tier name
+	Level        int    `json:"level" example:"10"`       // Tier level
+	ServiceCount int    `json:"serviceCount" example:"3"` // LLMInferenceServices annotated with the tier
+}
+
+// GroupImpactService is an LLMInferenceService the group reaches through its tiers
+// @Description An affected LLMInferenceService and which of the group's tiers grant it
+type GroupImpactService struct {
+	Namespace string   `json:"namespace" example:"acme-inc-models"` // Namespace of the LLMInferenceService
+	Name      string   `json:"name" example:"acme-dev-model"`       // Name of the LLMInferenceService
+	Tiers     []string `json:"tiers" example:"premium"`             // The group's tiers the service is annotated with, sorted
+}
+
+// GroupImpact reports what removing a group from its tiers would affect
+// @Description Tiers containing the group and the distinct LLMInferenceServices reached through them
+type GroupImpact struct {
+	Group        string               `json:"group" example:"premium-users"` // Group name
+	TierCount    int                  `json:"tierCount" example:"2"`         // Number of tiers containing the group
+	ServiceCount int                  `json:"serviceCount" example:"4"`      // Number of distinct affected services
+	Tiers        []GroupImpactTier    `json:"tiers"`                         // Tiers by level descending, then name
+	Services     []GroupImpactService `json:"services"`                      // Affected services by namespace, then name
+}
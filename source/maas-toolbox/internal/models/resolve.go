@@ -0,0 +1,99 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+// ResolveRequest represents a request to resolve the tiers for a set of groups
+// @Description Groups of an authenticated caller to resolve into tiers
+type ResolveRequest struct {
+	Groups []string `json:"groups" example:"premium-users"` // Groups the caller belongs to
+}
+
+// Validate validates a ResolveRequest
+func (r *ResolveRequest) Validate() error {
+	for _, group := range r.Groups {
+		if err := ValidateGroupName(group); err != nil {
+			return NewValueValidationError(FieldGroups, group, err)
+		}
+	}
+	return nil
+}
+
+// TierMatch represents a tier that matched one or more groups
+// @Description A matching tier and the groups that caused the match
+type TierMatch struct {
+	Tier          Tier     `json:"tier"`                                  // The matching tier
+	MatchedGroups []string `json:"matchedGroups" example:"premium-users"` // Groups that matched the tier
+	Implicit      bool     `json:"implicit,omitempty" example:"false"`    // True when the tier matched only through system:authenticated
+	Wildcard      bool     `json:"wildcard,omitempty" example:"false"`    // True when the tier matched only through wildcard group patterns
+}
+
+// ResolveResponse represents the result of resolving groups into tiers
+// @Description Matching tiers ordered by level (highest first) and the winning tier
+type ResolveResponse struct {
+	Matches []TierMatch         `json:"matches"`          // Matching tiers, highest level first
+	Winner  *Tier               `json:"winner,omitempty"` // Highest-level matching tier, absent when nothing matches
+	Why     *ResolveExplanation `json:"why,omitempty"`    // How the winner was chosen, absent when nothing matches
+}
+
+// Tiebreak rules reported by ResolveExplanation
+const (
+	TiebreakOnlyMatch       = "only-match"       // A single tier matched
+	TiebreakHighestLevel    = "highest-level"    // The winner's level is above every other candidate's
+	TiebreakConfiguredOrder = "configured-order" // Several candidates share the highest level and the first configured wins
+)
+
+// ResolveCandidate represents a tier considered when choosing the winner
+// @Description A matching tier, its level, the groups that matched it and whether it won
+type ResolveCandidate struct {
+	Tier          string   `json:"tier" example:"premium"`                // Tier name
+	Level         int      `json:"level" example:"10"`                    // Tier level
+	MatchedGroups []string `json:"matchedGroups" example:"premium-users"` // Groups that matched the tier
+	Implicit      bool     `json:"implicit,omitempty" example:"false"`    // True when the tier matched only through system:authenticated
+	Wildcard      bool     `json:"wildcard,omitempty" example:"false"`    // True when the tier matched only through wildcard group patterns
+	Winner        bool     `json:"winner" example:"true"`                 // True for the winning tier
+}
+
+// ResolveExplanation explains how the winning tier was chosen from the candidates
+// @Description Every candidate tier, the winner and the tiebreak rule that selected it
+type ResolveExplanation struct {
+	Candidates []ResolveCandidate `json:"candidates"`                                                          // Matching tiers, highest level first
+	Winner     string             `json:"winner" example:"premium"`                                            // Winning tier name
+	Rule       string             `json:"rule" example:"highest-level"`                                        // Tiebreak rule: only-match, highest-level or configured-order
+	Reason     string             `json:"reason" example:"premium has the highest level (10) of 2 candidates"` // Human-readable explanation
+}
+
+// UserTiersResponse represents the tiers a user qualifies for through group membership
+// @Description A user's groups and the tiers they resolve to, highest level first
+type UserTiersResponse struct {
+	User    string      `json:"user" example:"alice"`           // User name
+	Groups  []string    `json:"groups" example:"premium-users"` // Groups whose users include the user
+	Matches []TierMatch `json:"matches"`                        // Matching tiers and the groups that matched each
+}
+
+// UserModel represents an LLMInferenceService a user can access and the tiers that grant it
+// @Description An accessible LLMInferenceService and the user's tiers that grant access, highest level first
+type UserModel struct {
+	LLMInferenceService
+	GrantedBy []string `json:"grantedBy" example:"premium"` // User's tiers on the service, highest level first
+}
+
+// UserModelsResponse represents the LLMInferenceServices a user can access through their tiers
+// @Description A user's groups, matching tiers and the LLMInferenceServices those tiers grant
+type UserModelsResponse struct {
+	User     string      `json:"user" example:"alice"`           // User name
+	Groups   []string    `json:"groups" example:"premium-users"` // Groups whose users include the user
+	Tiers    []string    `json:"tiers" example:"premium"`        // Matching tiers, highest level first
+	Services []UserModel `json:"services"`                       // Accessible services, deduplicated by namespace/name
+}
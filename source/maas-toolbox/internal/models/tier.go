@@ -14,43 +14,191 @@
 
 package models
 
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxTierDescriptionLength is the maximum length of a tier description, in characters
+const MaxTierDescriptionLength = 256
+
+// Limits on tier metadata: the number of entries, and the length of each key and value in characters
+const (
+	MaxTierMetadataEntries     = 32
+	MaxTierMetadataKeyLength   = 63
+	MaxTierMetadataValueLength = 256
+)
+
 // Tier represents a single tier configuration
 // @Description Tier configuration that maps Kubernetes groups to a subscription tier
 type Tier struct {
-	Name        string   `json:"name" yaml:"name" example:"free"`                    // Tier name (immutable after creation)
-	Description string   `json:"description" yaml:"description" example:"Free tier for basic users"` // Tier description
-	Level       int      `json:"level" yaml:"level" example:"1"`                // Tier level (non-negative integer)
-	Groups      []string `json:"groups" yaml:"groups" example:"system:authenticated"`                     // List of Kubernetes groups
+	Name        string            `json:"name" yaml:"name" example:"free"`                                               // Tier name (immutable after creation)
+	Description string            `json:"description" yaml:"description" example:"Free tier for basic users"`            // Tier description
+	Level       int               `json:"level" yaml:"level" example:"1"`                                                // Tier level (non-negative integer)
+	Groups      []string          `json:"groups" yaml:"groups" example:"system:authenticated"`                           // List of Kubernetes groups
+	Metadata    map[string]string `json:"metadata,omitempty" yaml:"metadata,omitempty"`                                  // Optional key/value metadata, e.g. cost center or owner
+	DeletedAt   *time.Time        `json:"deletedAt,omitempty" yaml:"deletedAt,omitempty" example:"2025-01-01T12:00:00Z"` // When the tier was soft-deleted, if it was
+}
+
+// TierFields lists the JSON field names of a Tier
+var TierFields = []string{"name", "description", "level", "groups", "metadata", "deletedAt"}
+
+// TierWithUsageFields lists the JSON field names of a TierWithUsage
+var TierWithUsageFields = append(append([]string{}, TierFields...), "serviceCount")
+
+// TierWithUsage represents a tier augmented with usage information
+// @Description Tier configuration including the number of LLMInferenceServices that reference it
+type TierWithUsage struct {
+	Tier         `yaml:",inline"`
+	ServiceCount int `json:"serviceCount" yaml:"serviceCount" example:"3"` // Number of LLMInferenceServices annotated with this tier
 }
 
 // TierConfig represents the complete tier configuration
 // This matches the structure of the ConfigMap data field
+// ResourceVersion is the ConfigMap version it was loaded from, empty if the ConfigMap did not exist;
+// Save only succeeds if the ConfigMap is unchanged since then. Missing marks a config loaded
+// while the ConfigMap did not exist, so Save fails if it has been created since.
 type TierConfig struct {
-	Tiers []Tier `json:"tiers" yaml:"tiers"`
+	Tiers           []Tier `json:"tiers" yaml:"tiers"`
+	ResourceVersion string `json:"-" yaml:"-"`
+	Missing         bool   `json:"-" yaml:"-"`
+}
+
+// SetLevelRequest represents a request to set only a tier's level
+// @Description The new level for the tier
+type SetLevelRequest struct {
+	Level *int `json:"level" example:"10"` // New level (non-negative integer)
+}
+
+// Validate validates a SetLevelRequest
+func (r *SetLevelRequest) Validate() error {
+	if r.Level == nil {
+		return NewValidationError(FieldLevel, ErrTierLevelRequired)
+	}
+	if *r.Level < 0 {
+		return NewValidationError(FieldLevel, ErrTierLevelInvalid)
+	}
+	return nil
+}
+
+// LevelChange records a tier whose level was changed by a move
+// @Description A tier whose level changed
+type LevelChange struct {
+	Name string `json:"name" example:"premium"` // Tier name
+	From int    `json:"from" example:"10"`      // Level before the move
+	To   int    `json:"to" example:"11"`        // Level after the move
+}
+
+// MoveLevelResponse reports the result of moving a tier to a level
+// @Description Tiers whose levels changed when moving a tier
+type MoveLevelResponse struct {
+	Tier    string        `json:"tier" example:"gold"` // Tier that was moved
+	Level   int           `json:"level" example:"10"`  // Level the tier was moved to
+	Cascade bool          `json:"cascade"`             // Whether tiers at or above the level were shifted up
+	Changed []LevelChange `json:"changed"`             // Every tier whose level changed, the moved tier first
+}
+
+// TierUpdate represents the body of a tier update. Omitted fields are left unchanged;
+// Level is a pointer so an omitted level is distinguishable from an explicit 0.
+// @Description Tier fields to update; omitted fields keep their current values
+type TierUpdate struct {
+	Name        string            `json:"name,omitempty" example:"free"`                             // Ignored unless it differs from the path (name is immutable)
+	Description string            `json:"description,omitempty" example:"Free tier for basic users"` // New description
+	Level       *int              `json:"level,omitempty" example:"1"`                               // New level (non-negative integer)
+	Groups      []string          `json:"groups,omitempty" example:"system:authenticated"`           // New list of Kubernetes groups, replacing the current list
+	Metadata    map[string]string `json:"metadata,omitempty"`                                        // New metadata, replacing the current entries; {} removes them all
 }
 
 // Validate validates a Tier struct
 func (t *Tier) Validate() error {
 	if t.Name == "" {
-		return ErrTierNameRequired
+		return NewValidationError(FieldName, ErrTierNameRequired)
 	}
 	if t.Description == "" {
-		return ErrTierDescriptionRequired
+		return NewValidationError(FieldDescription, ErrTierDescriptionRequired)
+	}
+	if length := utf8.RuneCountInString(t.Description); length > MaxTierDescriptionLength {
+		return NewValidationError(FieldDescription, &LengthError{Length: length, MaxLength: MaxTierDescriptionLength, Err: ErrTierDescriptionTooLong})
 	}
 	if t.Level < 0 {
-		return ErrTierLevelInvalid
+		return NewValidationError(FieldLevel, ErrTierLevelInvalid)
 	}
-	// Validate all groups conform to Kubernetes naming conventions
+	// Validate all groups conform to Kubernetes naming conventions or are prefix patterns
 	for _, group := range t.Groups {
-		if err := ValidateGroupName(group); err != nil {
-			return err
+		if err := ValidateTierGroup(group); err != nil {
+			return NewValueValidationError(FieldGroups, group, err)
+		}
+	}
+	return ValidateTierMetadata(t.Metadata)
+}
+
+// ValidateTierMetadata checks the number of metadata entries and the length of each key and
+// value. Keys must be non-empty. Keys are checked in sorted order so the reported entry is stable.
+func ValidateTierMetadata(metadata map[string]string) error {
+	if len(metadata) > MaxTierMetadataEntries {
+		return NewValidationError(FieldMetadata, fmt.Errorf("%w: %d entries, maximum is %d", ErrTierMetadataTooLarge, len(metadata), MaxTierMetadataEntries))
+	}
+	keys := make([]string, 0, len(metadata))
+	for key := range metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if key == "" {
+			return NewValidationError(FieldMetadata, ErrTierMetadataKeyRequired)
+		}
+		if length := utf8.RuneCountInString(key); length > MaxTierMetadataKeyLength {
+			return NewValueValidationError(FieldMetadata, key, &LengthError{Length: length, MaxLength: MaxTierMetadataKeyLength, Err: ErrTierMetadataKeyTooLong})
+		}
+		if length := utf8.RuneCountInString(metadata[key]); length > MaxTierMetadataValueLength {
+			return NewValueValidationError(FieldMetadata, key, &LengthError{Length: length, MaxLength: MaxTierMetadataValueLength, Err: ErrTierMetadataValueTooLong})
 		}
 	}
 	return nil
 }
 
+// ETag returns a strong entity tag derived from the tier's content.
+// The tag changes whenever any field of the tier changes.
+func (t *Tier) ETag() string {
+	data, _ := json.Marshal(t)
+	sum := sha256.Sum256(data)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// ETagMatches reports whether an If-Match header value matches etag. An empty header
+// always matches, as does "*"; otherwise any of its comma-separated tags must equal etag.
+func ETagMatches(ifMatch, etag string) bool {
+	ifMatch = strings.TrimSpace(ifMatch)
+	if ifMatch == "" || ifMatch == "*" {
+		return true
+	}
+	for _, tag := range strings.Split(ifMatch, ",") {
+		if strings.TrimSpace(tag) == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// IsDeleted returns true if the tier has been soft-deleted
+func (t *Tier) IsDeleted() bool {
+	return t.DeletedAt != nil
+}
+
 // IsValid returns true if the tier is valid
 func (t *Tier) IsValid() bool {
 	return t.Validate() == nil
 }
 
+// PurgeResponse represents the result of purging soft-deleted tiers
+// @Description Names of the soft-deleted tiers that were permanently removed
+type PurgeResponse struct {
+	Purged    []string `json:"purged" example:"old-tier"`    // Names of purged tiers
+	Retention string   `json:"retention" example:"720h0m0s"` // Retention period applied
+}
@@ -0,0 +1,161 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestTierValidate_ValidationError(t *testing.T) {
+	tests := []struct {
+		name      string
+		tier      Tier
+		wantErr   error
+		wantField string
+		wantValue string
+	}{
+		{"missing name", Tier{Description: "d"}, ErrTierNameRequired, FieldName, ""},
+		{"missing description", Tier{Name: "t"}, ErrTierDescriptionRequired, FieldDescription, ""},
+		{"negative level", Tier{Name: "t", Description: "d", Level: -1}, ErrTierLevelInvalid, FieldLevel, ""},
+		{"invalid group", Tier{Name: "t", Description: "d", Groups: []string{"ok", "Bad"}}, ErrInvalidKubernetesName, FieldGroups, "Bad"},
+		{"empty group", Tier{Name: "t", Description: "d", Groups: []string{""}}, ErrGroupRequired, FieldGroups, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.tier.Validate()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Validate() error = %v, want %v", err, tt.wantErr)
+			}
+			if err.Error() != tt.wantErr.Error() {
+				t.Errorf("Validate() message = %q, want %q", err.Error(), tt.wantErr.Error())
+			}
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Validate() error %T is not a *ValidationError", err)
+			}
+			if validationErr.Field != tt.wantField {
+				t.Errorf("Field = %q, want %q", validationErr.Field, tt.wantField)
+			}
+			if validationErr.Value != tt.wantValue {
+				t.Errorf("Value = %q, want %q", validationErr.Value, tt.wantValue)
+			}
+		})
+	}
+}
+
+func TestTierValidate_DescriptionLength(t *testing.T) {
+	// Length is counted in characters, not bytes
+	tier := Tier{Name: "t", Description: strings.Repeat("ü", MaxTierDescriptionLength)}
+	if err := tier.Validate(); err != nil {
+		t.Errorf("Expected a description of exactly the maximum length to be valid, got %v", err)
+	}
+
+	tier.Description += "x"
+	err := tier.Validate()
+	if !errors.Is(err, ErrTierDescriptionTooLong) {
+		t.Fatalf("Validate() error = %v, want %v", err, ErrTierDescriptionTooLong)
+	}
+	var lengthErr *LengthError
+	if !errors.As(err, &lengthErr) || lengthErr.Length != MaxTierDescriptionLength+1 || lengthErr.MaxLength != MaxTierDescriptionLength {
+		t.Errorf("Expected a *LengthError with length %d and max %d, got %v", MaxTierDescriptionLength+1, MaxTierDescriptionLength, err)
+	}
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Field != FieldDescription {
+		t.Errorf("Expected a validation error on field %s, got %v", FieldDescription, err)
+	}
+}
+
+func TestTierValidate_Metadata(t *testing.T) {
+	tooMany := make(map[string]string, MaxTierMetadataEntries+1)
+	for i := 0; i <= MaxTierMetadataEntries; i++ {
+		tooMany[fmt.Sprintf("key-%d", i)] = "value"
+	}
+	longKey := strings.Repeat("k", MaxTierMetadataKeyLength+1)
+
+	tests := []struct {
+		name      string
+		metadata  map[string]string
+		wantErr   error
+		wantValue string
+	}{
+		{"none", nil, nil, ""},
+		{"at the limits", map[string]string{strings.Repeat("k", MaxTierMetadataKeyLength): strings.Repeat("ü", MaxTierMetadataValueLength)}, nil, ""},
+		{"too many entries", tooMany, ErrTierMetadataTooLarge, ""},
+		{"empty key", map[string]string{"": "value"}, ErrTierMetadataKeyRequired, ""},
+		{"key too long", map[string]string{longKey: "value"}, ErrTierMetadataKeyTooLong, longKey},
+		{"value too long", map[string]string{"owner": strings.Repeat("v", MaxTierMetadataValueLength+1)}, ErrTierMetadataValueTooLong, "owner"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tier := Tier{Name: "t", Description: "d", Metadata: tt.metadata}
+			err := tier.Validate()
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Validate() error = %v, want %v", err, tt.wantErr)
+			}
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) || validationErr.Field != FieldMetadata || validationErr.Value != tt.wantValue {
+				t.Errorf("Expected a validation error on field %s with value %q, got %+v", FieldMetadata, tt.wantValue, validationErr)
+			}
+		})
+	}
+}
+
+func TestTierETag(t *testing.T) {
+	tier := Tier{Name: "free", Description: "Free tier", Level: 1, Groups: []string{"a"}}
+	same := tier
+	changed := tier
+	changed.Level = 2
+
+	if tier.ETag() != same.ETag() {
+		t.Error("Expected identical tiers to have the same ETag")
+	}
+	if tier.ETag() == changed.ETag() {
+		t.Error("Expected changed tier to have a different ETag")
+	}
+}
+
+func TestETagMatches(t *testing.T) {
+	etag := `"abc"`
+	tests := []struct {
+		ifMatch string
+		want    bool
+	}{
+		{"", true},
+		{"*", true},
+		{`"abc"`, true},
+		{`"old", "abc"`, true},
+		{`"old"`, false},
+		{`W/"abc"`, false},
+		{"abc", false},
+	}
+
+	for _, tt := range tests {
+		if got := ETagMatches(tt.ifMatch, etag); got != tt.want {
+			t.Errorf("ETagMatches(%q) = %v, want %v", tt.ifMatch, got, tt.want)
+		}
+	}
+}
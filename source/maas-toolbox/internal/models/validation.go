@@ -15,7 +15,10 @@
 package models
 
 import (
+	"fmt"
 	"regexp"
+	"strings"
+	"sync/atomic"
 	"unicode"
 )
 
@@ -26,8 +29,28 @@ var (
 	// Pattern: starts with alphanumeric, optionally followed by middle chars ending with alphanumeric
 	// The regex handles single char (a-z0-9) and multi-char (a-z0-9 followed by optional middle ending with a-z0-9)
 	kubernetesNameRegex = regexp.MustCompile(`^[a-z0-9]([a-z0-9\-:._]*[a-z0-9])?$`)
+
+	// groupSegmentRegex validates one colon-separated segment of a group name
+	groupSegmentRegex = regexp.MustCompile(`^[a-z0-9]([a-z0-9\-._]*[a-z0-9])?$`)
+
+	// namespaceRegex validates the namespace of a namespace-scoped group name (DNS label format)
+	namespaceRegex = regexp.MustCompile(`^[a-z0-9]([a-z0-9\-]*[a-z0-9])?$`)
 )
 
+// SystemGroupPrefix is the prefix of built-in Kubernetes and OpenShift groups,
+// which may contain any number of colon-separated segments
+const SystemGroupPrefix = "system:"
+
+// allowGroupNameColons disables the colon checks in ValidateGroupName; see SetAllowGroupNameColons
+var allowGroupNameColons atomic.Bool
+
+// SetAllowGroupNameColons controls whether ValidateGroupName accepts colons anywhere a
+// Kubernetes name allows them, for clusters whose group names use colons freely.
+// By default colons are only accepted in system: and <namespace>:<name> groups.
+func SetAllowGroupNameColons(allowed bool) {
+	allowGroupNameColons.Store(allowed)
+}
+
 // ValidateKubernetesName validates that a name conforms to Kubernetes naming conventions
 // Kubernetes names must:
 // - Be between 1 and 253 characters
@@ -59,9 +82,119 @@ func ValidateKubernetesName(name string) error {
 	return nil
 }
 
-// ValidateGroupName validates a Kubernetes group name
-// This is an alias for ValidateKubernetesName for clarity
+// reservedTierNames are the tier names shadowed by a static route under /api/v1/tiers,
+// GET /tiers/stream and DELETE /tiers/batch, so a tier with one of them could not be
+// addressed by name
+var reservedTierNames = map[string]bool{
+	"stream": true,
+	"batch":  true,
+}
+
+// ValidateTierName validates the name of a new tier: a Kubernetes name that is not reserved
+func ValidateTierName(name string) error {
+	if err := ValidateKubernetesName(name); err != nil {
+		return err
+	}
+	if reservedTierNames[name] {
+		return fmt.Errorf("%w: %q", ErrTierNameReserved, name)
+	}
+	return nil
+}
+
+// ValidateGroupName validates a Kubernetes group name.
+// In addition to the Kubernetes name rules, a name containing colons must either be a
+// system: group (e.g. system:authenticated, system:serviceaccounts:my-namespace) or a
+// namespace-scoped <namespace>:<name> group, with no empty segments.
+// Ordinary OpenShift group names are plain names without colons.
 func ValidateGroupName(groupName string) error {
-	return ValidateKubernetesName(groupName)
+	if err := ValidateKubernetesName(groupName); err != nil {
+		return err
+	}
+	if !strings.Contains(groupName, ":") || allowGroupNameColons.Load() {
+		return nil
+	}
+
+	segments := strings.Split(groupName, ":")
+	for _, segment := range segments {
+		if !groupSegmentRegex.MatchString(segment) {
+			return ErrInvalidGroupColon
+		}
+	}
+	if strings.HasPrefix(groupName, SystemGroupPrefix) {
+		return nil
+	}
+	if len(segments) == 2 && len(segments[0]) <= 63 && namespaceRegex.MatchString(segments[0]) {
+		return nil
+	}
+	return ErrInvalidGroupColon
+}
+
+// NormalizeGroupName returns groupName with surrounding whitespace trimmed and lowercased.
+// Group names are stored exactly as given, so a name must already be in this form to pass
+// ValidateGroupName; clients apply it to suggest a corrected name.
+func NormalizeGroupName(groupName string) string {
+	return strings.ToLower(strings.TrimSpace(groupName))
 }
 
+// GroupWildcard is the trailing character that turns a tier group entry into a prefix pattern
+const GroupWildcard = "*"
+
+// IsGroupPattern returns true if a tier group entry is a trailing-wildcard prefix pattern such as team:*
+func IsGroupPattern(group string) bool {
+	return strings.HasSuffix(group, GroupWildcard)
+}
+
+// ValidateTierGroup validates an entry in a tier's groups, which is either a concrete group name
+// or a prefix pattern. A pattern must have a non-empty prefix, contain no other wildcard, and
+// be completable into a valid group name (e.g. team:* or team-*).
+func ValidateTierGroup(group string) error {
+	if !IsGroupPattern(group) {
+		return ValidateGroupName(group)
+	}
+
+	prefix := strings.TrimSuffix(group, GroupWildcard)
+	if prefix == "" || strings.Contains(prefix, GroupWildcard) {
+		return ErrInvalidGroupPattern
+	}
+	if err := ValidateGroupName(prefix + "x"); err != nil {
+		return ErrInvalidGroupPattern
+	}
+	return nil
+}
+
+// GroupMatches reports whether a concrete group matches a tier group entry, either exactly
+// or by having the prefix of a wildcard pattern
+func GroupMatches(entry, group string) bool {
+	if entry == group {
+		return true
+	}
+	if !IsGroupPattern(entry) {
+		return false
+	}
+	prefix := strings.TrimSuffix(entry, GroupWildcard)
+	return len(group) > len(prefix) && strings.HasPrefix(group, prefix)
+}
+
+// ValidateUsername validates an OpenShift user name
+// User names must:
+// - Be between 1 and 253 characters
+// - Not be "." or ".."
+// - Not contain "/" or "%", which cannot appear in a resource path segment
+// - Not contain whitespace
+func ValidateUsername(username string) error {
+	if username == "" || len(username) > 253 {
+		return ErrInvalidUsername
+	}
+	if username == "." || username == ".." {
+		return ErrInvalidUsername
+	}
+	if strings.ContainsAny(username, "/%") {
+		return ErrInvalidUsername
+	}
+	for _, r := range username {
+		if unicode.IsSpace(r) {
+			return ErrInvalidUsername
+		}
+	}
+	return nil
+}
@@ -15,6 +15,7 @@
 package models
 
 import (
+	"errors"
 	"strings"
 	"testing"
 )
@@ -63,3 +64,133 @@ func TestValidateKubernetesName(t *testing.T) {
 	}
 }
 
+func TestValidateTierName(t *testing.T) {
+	if err := ValidateTierName("premium"); err != nil {
+		t.Errorf("ValidateTierName(%q) error = %v", "premium", err)
+	}
+	if err := ValidateTierName("Premium"); !errors.Is(err, ErrInvalidKubernetesName) {
+		t.Errorf("ValidateTierName(%q) error = %v, want %v", "Premium", err, ErrInvalidKubernetesName)
+	}
+	for _, name := range []string{"stream", "batch"} {
+		if err := ValidateTierName(name); !errors.Is(err, ErrTierNameReserved) {
+			t.Errorf("ValidateTierName(%q) error = %v, want %v", name, err, ErrTierNameReserved)
+		}
+	}
+}
+
+func TestValidateUsername(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{"simple", "alice", false},
+		{"email style", "alice@example.com", false},
+		{"mixed case", "Alice", false},
+		{"empty", "", true},
+		{"dot", ".", true},
+		{"dot dot", "..", true},
+		{"slash", "alice/bob", true},
+		{"percent", "alice%20", true},
+		{"whitespace", "alice smith", true},
+		{"too long", strings.Repeat("a", 254), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateUsername(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateUsername(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateGroupName_Colons(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr error
+	}{
+		{"plain group", "premium-users", nil},
+		{"system group", "system:authenticated", nil},
+		{"system group with segments", "system:serviceaccounts:my-namespace", nil},
+		{"namespace-scoped group", "my-namespace:ml-team", nil},
+		{"bogus segments", "a:b:c:d", ErrInvalidGroupColon},
+		{"empty segment", "system::authenticated", ErrInvalidGroupColon},
+		{"invalid namespace", "my.namespace:ml-team", ErrInvalidGroupColon},
+		{"invalid kubernetes name", "premium-users:", ErrInvalidKubernetesName},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateGroupName(tt.input); err != tt.wantErr {
+				t.Errorf("ValidateGroupName(%q) error = %v, want %v", tt.input, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateGroupName_AllowColons(t *testing.T) {
+	SetAllowGroupNameColons(true)
+	defer SetAllowGroupNameColons(false)
+
+	for _, group := range []string{"a:b:c:d", "system::authenticated", "my.namespace:ml-team"} {
+		if err := ValidateGroupName(group); err != nil {
+			t.Errorf("ValidateGroupName(%q) error = %v, want nil when colons are allowed", group, err)
+		}
+	}
+	if err := ValidateGroupName("premium-users:"); err != ErrInvalidKubernetesName {
+		t.Errorf("Expected Kubernetes name rules to still apply, got %v", err)
+	}
+}
+
+func TestValidateTierGroup(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr error
+	}{
+		{"concrete group", "premium-users", nil},
+		{"colon prefix pattern", "team:*", nil},
+		{"hyphen prefix pattern", "team-*", nil},
+		{"system prefix pattern", "system:serviceaccounts:*", nil},
+		{"bogus colon prefix pattern", "a:b:c:*", ErrInvalidGroupPattern},
+		{"bare wildcard", "*", ErrInvalidGroupPattern},
+		{"inner wildcard", "te*am:*", ErrInvalidGroupPattern},
+		{"uppercase prefix", "Team:*", ErrInvalidGroupPattern},
+		{"invalid prefix start", "-team*", ErrInvalidGroupPattern},
+		{"invalid concrete group", "Invalid_Group", ErrInvalidKubernetesName},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateTierGroup(tt.input); err != tt.wantErr {
+				t.Errorf("ValidateTierGroup(%q) error = %v, want %v", tt.input, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGroupMatches(t *testing.T) {
+	tests := []struct {
+		entry string
+		group string
+		want  bool
+	}{
+		{"premium-users", "premium-users", true},
+		{"premium-users", "premium-users-2", false},
+		{"team:*", "team:ml", true},
+		{"team:*", "team:", false},
+		{"team:*", "teammates", false},
+		{"team-*", "team-a", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.entry+"/"+tt.group, func(t *testing.T) {
+			if got := GroupMatches(tt.entry, tt.group); got != tt.want {
+				t.Errorf("GroupMatches(%q, %q) = %v, want %v", tt.entry, tt.group, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"log"
+	"maas-toolbox/internal/models"
+	"maas-toolbox/internal/webhook"
+	"sync"
+)
+
+// DefaultMaxChangeSubscribers is the default cap on concurrent change stream subscribers
+const DefaultMaxChangeSubscribers = 10
+
+// changeBufferSize is how many events a slow subscriber may fall behind before events are dropped
+const changeBufferSize = 16
+
+// changeBus fans change events out to in-process subscribers such as SSE streams
+type changeBus struct {
+	mu             sync.Mutex
+	subscribers    map[chan webhook.Event]struct{}
+	maxSubscribers int
+}
+
+// newChangeBus creates a change bus allowing at most maxSubscribers concurrent subscribers
+func newChangeBus(maxSubscribers int) *changeBus {
+	return &changeBus{
+		subscribers:    make(map[chan webhook.Event]struct{}),
+		maxSubscribers: maxSubscribers,
+	}
+}
+
+// subscribe registers a new subscriber and returns its event channel and an unsubscribe func.
+// Returns ErrTooManySubscribers when the subscriber cap is reached.
+func (b *changeBus) subscribe() (<-chan webhook.Event, func(), error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.subscribers) >= b.maxSubscribers {
+		return nil, nil, models.ErrTooManySubscribers
+	}
+
+	ch := make(chan webhook.Event, changeBufferSize)
+	b.subscribers[ch] = struct{}{}
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscribers, ch)
+			b.mu.Unlock()
+			close(ch)
+		})
+	}
+	return ch, unsubscribe, nil
+}
+
+// publish delivers event to every subscriber without blocking; a subscriber whose
+// buffer is full misses the event
+func (b *changeBus) publish(event webhook.Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+			log.Printf("Change stream subscriber is falling behind, dropping %s event for %s", event.Action, event.Name)
+		}
+	}
+}
+
+// WithMaxChangeSubscribers caps the number of concurrent change stream subscribers
+func WithMaxChangeSubscribers(max int) TierServiceOption {
+	return func(s *TierService) {
+		s.changes.maxSubscribers = max
+	}
+}
+
+// SubscribeChanges returns a channel of tier and annotation change events and a func that
+// must be called to stop the subscription. Returns ErrTooManySubscribers when the cap is reached.
+func (s *TierService) SubscribeChanges() (<-chan webhook.Event, func(), error) {
+	return s.changes.subscribe()
+}
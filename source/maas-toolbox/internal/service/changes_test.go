@@ -0,0 +1,80 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"maas-toolbox/internal/models"
+	"maas-toolbox/internal/webhook"
+	"testing"
+)
+
+func TestSubscribeChanges_ReceivesTierEvents(t *testing.T) {
+	s := newTestTierService()
+	events, unsubscribe, err := s.SubscribeChanges()
+	if err != nil {
+		t.Fatalf("SubscribeChanges() error = %v", err)
+	}
+	defer unsubscribe()
+
+	if err := s.CreateTier(context.Background(), &models.Tier{Name: "free", Description: "Free tier", Level: 1, Groups: []string{}}); err != nil {
+		t.Fatalf("CreateTier() error = %v", err)
+	}
+
+	select {
+	case event := <-events:
+		if event.Action != webhook.ActionCreated || event.Name != "free" || event.Timestamp.IsZero() {
+			t.Errorf("Unexpected event: %+v", event)
+		}
+	default:
+		t.Fatal("Expected an event to be published synchronously")
+	}
+}
+
+func TestSubscribeChanges_EnforcesCap(t *testing.T) {
+	s := newTestTierService(WithMaxChangeSubscribers(1))
+
+	_, unsubscribe, err := s.SubscribeChanges()
+	if err != nil {
+		t.Fatalf("SubscribeChanges() error = %v", err)
+	}
+	if _, _, err := s.SubscribeChanges(); !errors.Is(err, models.ErrTooManySubscribers) {
+		t.Fatalf("Expected ErrTooManySubscribers, got %v", err)
+	}
+
+	// Unsubscribing frees the slot and is safe to repeat
+	unsubscribe()
+	unsubscribe()
+	if _, _, err := s.SubscribeChanges(); err != nil {
+		t.Errorf("Expected subscription after unsubscribe, got %v", err)
+	}
+}
+
+func TestChangeBus_DropsForSlowSubscriber(t *testing.T) {
+	bus := newChangeBus(1)
+	events, unsubscribe, err := bus.subscribe()
+	if err != nil {
+		t.Fatalf("subscribe() error = %v", err)
+	}
+	defer unsubscribe()
+
+	for i := 0; i < changeBufferSize+5; i++ {
+		bus.publish(webhook.Event{Action: webhook.ActionUpdated})
+	}
+	if len(events) != changeBufferSize {
+		t.Errorf("Expected %d buffered events, got %d", changeBufferSize, len(events))
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"maas-toolbox/internal/models"
+	"maas-toolbox/internal/webhook"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Outcomes of EnsureTier, reported in the X-Tier-Ensure-Result response header
+const (
+	EnsureCreated   = "created"
+	EnsureUpdated   = "updated"
+	EnsureUnchanged = "unchanged"
+)
+
+// EnsureTier makes the stored tier named tier.Name match tier, creating it when absent and
+// otherwise replacing its description, level and groups. A tier that already matches is not
+// saved, so repeating an ensure is a no-op. tier is validated as by CreateTier and set to the
+// stored tier on success. A soft-deleted tier of the same name must be restored or purged first.
+func (s *TierService) EnsureTier(ctx context.Context, tier *models.Tier) (result string, err error) {
+	defer s.recordOperation(OperationEnsure, &err)
+
+	if err := s.validateImportedTier(ctx, tier); err != nil {
+		return "", err
+	}
+
+	unlock := s.storage.LockWrites()
+	defer unlock()
+
+	// Load existing config
+	config, err := s.storage.Load(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to load config: %w", err)
+	}
+
+	index := -1
+	for i, existingTier := range config.Tiers {
+		if existingTier.Name == tier.Name {
+			if existingTier.IsDeleted() {
+				return "", models.ErrTierSoftDeleted
+			}
+			index = i
+			break
+		}
+		if strings.EqualFold(existingTier.Name, tier.Name) {
+			return "", fmt.Errorf("%w: %q differs only in case", models.ErrTierAlreadyExists, existingTier.Name)
+		}
+	}
+
+	if index >= 0 {
+		existing := config.Tiers[index]
+		if existing.Description == tier.Description && existing.Level == tier.Level && slices.Equal(existing.Groups, tier.Groups) && maps.Equal(existing.Metadata, tier.Metadata) {
+			*tier = existing
+			return EnsureUnchanged, nil
+		}
+	}
+	if err := s.checkLevel(config.Tiers, tier.Name, tier.Level); err != nil {
+		return "", err
+	}
+
+	var before *models.Tier
+	if index >= 0 {
+		before = snapshotTier(config.Tiers[index])
+		config.Tiers[index] = *tier
+	} else {
+		if err := s.checkTierCapacity(config.Tiers); err != nil {
+			return "", err
+		}
+		config.Tiers = append(config.Tiers, *tier)
+	}
+
+	// Save config
+	if err := s.storage.Save(ctx, config); err != nil {
+		return "", fmt.Errorf("failed to save config: %w", err)
+	}
+
+	if before != nil {
+		s.notifyTier(webhook.ActionUpdated, tier.Name, before, snapshotTier(*tier))
+		return EnsureUpdated, nil
+	}
+	s.notifyTier(webhook.ActionCreated, tier.Name, nil, snapshotTier(*tier))
+	return EnsureCreated, nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"maas-toolbox/internal/models"
+	"maas-toolbox/internal/storage"
+	"testing"
+)
+
+func TestEnsureTier(t *testing.T) {
+	s, client := newTestTierServiceWithClient()
+
+	tier := models.Tier{Name: "free", Description: "Free tier", Level: 1, Groups: []string{storage.SystemAuthenticatedGroup}}
+	result, err := s.EnsureTier(context.Background(), &tier)
+	if err != nil || result != EnsureCreated {
+		t.Fatalf("Expected the tier to be created, got %q, %v", result, err)
+	}
+
+	// A matching ensure does not write the ConfigMap
+	writes := countConfigMapWrites(client)
+	again := models.Tier{Name: "free", Description: "Free tier", Level: 1, Groups: []string{storage.SystemAuthenticatedGroup}}
+	if result, err := s.EnsureTier(context.Background(), &again); err != nil || result != EnsureUnchanged {
+		t.Errorf("Expected an unchanged result, got %q, %v", result, err)
+	}
+	if got := countConfigMapWrites(client); got != writes {
+		t.Errorf("Expected no ConfigMap write for a no-op ensure, got %d more", got-writes)
+	}
+
+	changed := models.Tier{Name: "free", Description: "Free tier", Level: 2, Groups: []string{storage.SystemAuthenticatedGroup}}
+	if result, err := s.EnsureTier(context.Background(), &changed); err != nil || result != EnsureUpdated {
+		t.Fatalf("Expected the tier to be updated, got %q, %v", result, err)
+	}
+	stored, err := s.GetTier(context.Background(), "free")
+	if err != nil || stored.Level != 2 {
+		t.Errorf("Expected the stored level to be 2, got %+v, %v", stored, err)
+	}
+
+}
+
+func TestEnsureTier_SoftDeletedConflict(t *testing.T) {
+	s := newTestTierService(WithSoftDelete(true))
+	tier := models.Tier{Name: "free", Description: "Free tier", Level: 1, Groups: []string{storage.SystemAuthenticatedGroup}}
+	if _, err := s.EnsureTier(context.Background(), &tier); err != nil {
+		t.Fatalf("EnsureTier() error = %v", err)
+	}
+	if err := s.DeleteTier(context.Background(), "free"); err != nil {
+		t.Fatalf("DeleteTier() error = %v", err)
+	}
+
+	tier = models.Tier{Name: "free", Description: "Free tier", Level: 1, Groups: []string{storage.SystemAuthenticatedGroup}}
+	if _, err := s.EnsureTier(context.Background(), &tier); !errors.Is(err, models.ErrTierSoftDeleted) {
+		t.Errorf("Expected ErrTierSoftDeleted, got %v", err)
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"maas-toolbox/internal/models"
+	"maas-toolbox/internal/webhook"
+	"maps"
+	"time"
+)
+
+// WithNotifier sets the webhook notifier that receives tier and annotation change events.
+// A nil notifier disables notifications.
+func WithNotifier(notifier *webhook.Notifier) TierServiceOption {
+	return func(s *TierService) {
+		s.notifier = notifier
+	}
+}
+
+// notifyTier emits a tier change event. before and after may be nil for creates and deletes.
+func (s *TierService) notifyTier(action, name string, before, after *models.Tier) {
+	event := webhook.Event{
+		Action:   action,
+		Resource: webhook.ResourceTier,
+		Name:     name,
+	}
+	if before != nil {
+		event.Before = before
+	}
+	if after != nil {
+		event.After = after
+	}
+	s.emit(event)
+}
+
+// notifyAnnotation emits an LLMInferenceService tier annotation change event.
+// before may be nil when the previous annotation was not read.
+func (s *TierService) notifyAnnotation(action, namespace, name, tierName string, before, after []string) {
+	event := webhook.Event{
+		Action:    action,
+		Resource:  webhook.ResourceLLMInferenceService,
+		Name:      name,
+		Namespace: namespace,
+		Tier:      tierName,
+		After:     after,
+	}
+	if before != nil {
+		event.Before = before
+	}
+	s.emit(event)
+}
+
+// emit stamps event and sends it to the webhook notifier and change stream subscribers
+func (s *TierService) emit(event webhook.Event) {
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now().UTC()
+	}
+	s.notifier.Notify(event)
+	s.changes.publish(event)
+}
+
+// snapshotTier returns a copy of tier that does not share its groups slice
+func snapshotTier(tier models.Tier) *models.Tier {
+	tier.Groups = append([]string(nil), tier.Groups...)
+	tier.Metadata = maps.Clone(tier.Metadata)
+	return &tier
+}
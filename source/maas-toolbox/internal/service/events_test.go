@@ -0,0 +1,113 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"maas-toolbox/internal/models"
+	"maas-toolbox/internal/webhook"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// receivedEvent mirrors webhook.Event with tier-typed before/after for decoding
+type receivedEvent struct {
+	Action string       `json:"action"`
+	Name   string       `json:"name"`
+	Before *models.Tier `json:"before"`
+	After  *models.Tier `json:"after"`
+}
+
+// newEventReceiver starts a webhook receiver that forwards decoded events to the returned channel
+func newEventReceiver(t *testing.T) (*webhook.Notifier, <-chan receivedEvent) {
+	events := make(chan receivedEvent, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event receivedEvent
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			t.Errorf("Failed to decode event: %v", err)
+		}
+		events <- event
+	}))
+	t.Cleanup(server.Close)
+	return webhook.NewNotifier(server.URL, ""), events
+}
+
+func waitForEvent(t *testing.T, events <-chan receivedEvent) receivedEvent {
+	t.Helper()
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for event")
+		return receivedEvent{}
+	}
+}
+
+func TestTierEvents_CreateUpdateDelete(t *testing.T) {
+	notifier, events := newEventReceiver(t)
+	s := newTestTierService(WithNotifier(notifier))
+
+	tier := &models.Tier{Name: "free", Description: "Free tier", Level: 1, Groups: []string{}}
+	if err := s.CreateTier(context.Background(), tier); err != nil {
+		t.Fatalf("CreateTier() error = %v", err)
+	}
+	event := waitForEvent(t, events)
+	if event.Action != webhook.ActionCreated || event.Name != "free" || event.Before != nil || event.After == nil {
+		t.Errorf("Unexpected create event: %+v", event)
+	}
+
+	if err := s.UpdateTier(context.Background(), "free", &models.TierUpdate{Description: "Updated"}, ""); err != nil {
+		t.Fatalf("UpdateTier() error = %v", err)
+	}
+	event = waitForEvent(t, events)
+	if event.Action != webhook.ActionUpdated || event.Before.Description != "Free tier" || event.After.Description != "Updated" {
+		t.Errorf("Unexpected update event: %+v", event)
+	}
+
+	if err := s.DeleteTier(context.Background(), "free"); err != nil {
+		t.Fatalf("DeleteTier() error = %v", err)
+	}
+	event = waitForEvent(t, events)
+	if event.Action != webhook.ActionDeleted || event.Before == nil || event.After != nil {
+		t.Errorf("Unexpected delete event: %+v", event)
+	}
+}
+
+func TestTierEvents_NotEmittedOnFailure(t *testing.T) {
+	notifier, events := newEventReceiver(t)
+	s := newTestTierService(WithNotifier(notifier))
+
+	if err := s.DeleteTier(context.Background(), "missing"); err == nil {
+		t.Fatal("Expected DeleteTier() to fail")
+	}
+
+	select {
+	case event := <-events:
+		t.Errorf("Expected no event, got %+v", event)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestSnapshotTier_DoesNotShareGroups(t *testing.T) {
+	tier := models.Tier{Name: "free", Groups: []string{"a", "b"}}
+	snapshot := snapshotTier(tier)
+	tier.Groups[0] = "changed"
+	if snapshot.Groups[0] != "a" {
+		t.Errorf("Expected snapshot to keep original groups, got %v", snapshot.Groups)
+	}
+}
@@ -0,0 +1,451 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"maas-toolbox/internal/models"
+	"maas-toolbox/internal/webhook"
+	"sort"
+	"strings"
+)
+
+// GroupMatchPolicy controls how group names are compared when detecting duplicates
+type GroupMatchPolicy string
+
+const (
+	// GroupMatchExact treats group names as duplicates only when they are identical
+	GroupMatchExact GroupMatchPolicy = "exact"
+	// GroupMatchCaseInsensitive treats group names that differ only by case as duplicates
+	GroupMatchCaseInsensitive GroupMatchPolicy = "case-insensitive"
+)
+
+// ParseGroupMatchPolicy parses a GroupMatchPolicy, defaulting to exact matching when empty
+func ParseGroupMatchPolicy(value string) (GroupMatchPolicy, error) {
+	switch policy := GroupMatchPolicy(strings.ToLower(strings.TrimSpace(value))); policy {
+	case "":
+		return GroupMatchExact, nil
+	case GroupMatchExact, GroupMatchCaseInsensitive:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("invalid group match policy %q: must be %s or %s", value, GroupMatchExact, GroupMatchCaseInsensitive)
+	}
+}
+
+// key returns the comparison key for a group name under the policy
+func (p GroupMatchPolicy) key(group string) string {
+	if p == GroupMatchCaseInsensitive {
+		return strings.ToLower(group)
+	}
+	return group
+}
+
+// matches reports whether two group names are duplicates under the policy
+func (p GroupMatchPolicy) matches(a, b string) bool {
+	return p.key(a) == p.key(b)
+}
+
+// dedupeGroups removes duplicate group names, keeping the first occurrence of each.
+// Collisions are logged so inconsistently-cased directory syncs can be traced.
+func (s *TierService) dedupeGroups(tierName string, groups []string) []string {
+	if groups == nil {
+		return nil
+	}
+
+	seen := make(map[string]string, len(groups))
+	deduped := make([]string, 0, len(groups))
+	for _, group := range groups {
+		key := s.groupMatchPolicy.key(group)
+		if kept, exists := seen[key]; exists {
+			log.Printf("Tier %s: dropping group %q, duplicate of %q under %s matching", tierName, group, kept, s.groupMatchPolicy)
+			continue
+		}
+		seen[key] = group
+		deduped = append(deduped, group)
+	}
+	return deduped
+}
+
+// hasGroup reports whether groups contains group under the group match policy
+func (s *TierService) hasGroup(groups []string, group string) bool {
+	for _, existing := range groups {
+		if s.groupMatchPolicy.matches(existing, group) {
+			return true
+		}
+	}
+	return false
+}
+
+// WithRequireGroups controls whether every tier must have at least one group.
+// It is disabled by default, so tiers with no groups are allowed.
+func WithRequireGroups(enabled bool) TierServiceOption {
+	return func(s *TierService) {
+		s.requireGroups = enabled
+	}
+}
+
+// checkGroupsRequired returns ErrTierGroupsRequired when groups are required and none are set
+func (s *TierService) checkGroupsRequired(groups []string) error {
+	if s.requireGroups && len(groups) == 0 {
+		return models.NewValidationError(models.FieldGroups, models.ErrTierGroupsRequired)
+	}
+	return nil
+}
+
+// RemoveGroupFromAllTiers removes a group or wildcard group pattern from every tier that lists it,
+// in a single save. With dryRun the affected tiers are reported without modifying them.
+func (s *TierService) RemoveGroupFromAllTiers(ctx context.Context, groupName string, dryRun bool) (response *models.RemoveGroupResponse, err error) {
+	if !dryRun {
+		defer s.recordOperation(OperationRemoveGroup, &err)
+	}
+
+	if err := models.ValidateTierGroup(groupName); err != nil {
+		return nil, err
+	}
+
+	unlock := s.storage.LockWrites()
+	defer unlock()
+
+	config, err := s.storage.Load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	response = &models.RemoveGroupResponse{Group: groupName, DryRun: dryRun, Tiers: []string{}}
+	var befores, afters []*models.Tier
+	for i := range config.Tiers {
+		tier := &config.Tiers[i]
+		if tier.IsDeleted() {
+			continue
+		}
+
+		groups := make([]string, 0, len(tier.Groups))
+		for _, group := range tier.Groups {
+			if group != groupName {
+				groups = append(groups, group)
+			}
+		}
+		if len(groups) == len(tier.Groups) {
+			continue
+		}
+		if err := s.checkGroupsRequired(groups); err != nil {
+			return nil, fmt.Errorf("tier %s: %w", tier.Name, err)
+		}
+
+		befores = append(befores, snapshotTier(*tier))
+		tier.Groups = groups
+		afters = append(afters, snapshotTier(*tier))
+		response.Tiers = append(response.Tiers, tier.Name)
+	}
+
+	if dryRun || len(response.Tiers) == 0 {
+		return response, nil
+	}
+
+	if err := s.storage.Save(ctx, config); err != nil {
+		return nil, fmt.Errorf("failed to save config: %w", err)
+	}
+
+	for i := range befores {
+		s.notifyTier(webhook.ActionGroupRemoved, befores[i].Name, befores[i], afters[i])
+	}
+	return response, nil
+}
+
+// RenameGroup replaces a group name in every tier that lists it, in a single save.
+// A tier that already lists the new name keeps a single entry for it.
+// The new name must exist in the cluster unless it is a wildcard pattern.
+func (s *TierService) RenameGroup(ctx context.Context, req *models.RenameGroupRequest) (response *models.RenameGroupResponse, err error) {
+	defer s.recordOperation(OperationRenameGroup, &err)
+
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	response = &models.RenameGroupResponse{From: req.From, To: req.To, Tiers: []string{}}
+	if req.From == req.To {
+		return response, nil
+	}
+
+	if !models.IsGroupPattern(req.To) {
+		exists, err := s.storage.GroupExists(ctx, req.To)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check if group %s exists: %w", req.To, err)
+		}
+		if !exists {
+			return nil, models.NewValueValidationError(models.FieldTo, req.To, models.ErrGroupNotFoundInCluster)
+		}
+	}
+
+	unlock := s.storage.LockWrites()
+	defer unlock()
+
+	config, err := s.storage.Load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	var befores, afters []*models.Tier
+	for i := range config.Tiers {
+		tier := &config.Tiers[i]
+		if tier.IsDeleted() {
+			continue
+		}
+
+		renamed := false
+		groups := make([]string, 0, len(tier.Groups))
+		for _, group := range tier.Groups {
+			if group == req.From {
+				group = req.To
+				renamed = true
+			}
+			groups = append(groups, group)
+		}
+		if !renamed {
+			continue
+		}
+
+		befores = append(befores, snapshotTier(*tier))
+		tier.Groups = s.dedupeGroups(tier.Name, groups)
+		afters = append(afters, snapshotTier(*tier))
+		response.Tiers = append(response.Tiers, tier.Name)
+	}
+
+	if len(response.Tiers) == 0 {
+		return response, nil
+	}
+
+	if err := s.storage.Save(ctx, config); err != nil {
+		return nil, fmt.Errorf("failed to save config: %w", err)
+	}
+
+	for i := range befores {
+		s.notifyTier(webhook.ActionUpdated, befores[i].Name, befores[i], afters[i])
+	}
+	return response, nil
+}
+
+// AddGroups adds several groups to a tier. Every group is validated, including the cluster
+// existence check, before storage is touched, and all failures are reported per group. When any
+// group is invalid nothing is saved unless partial is set, in which case the valid groups are.
+// Groups the tier already lists, or repeated in the request, are reported as already present.
+func (s *TierService) AddGroups(ctx context.Context, tierName string, req *models.BatchAddGroupsRequest, partial bool) (response *models.BatchAddGroupsResponse, err error) {
+	defer s.recordOperation(OperationAddGroup, &err)
+
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	response = &models.BatchAddGroupsResponse{
+		Tier:    tierName,
+		Partial: partial,
+		Results: make([]models.BatchGroupResult, len(req.Groups)),
+	}
+	var valid []string
+	for i, group := range req.Groups {
+		result := &response.Results[i]
+		result.Group = group
+		switch err := s.validateBatchGroup(ctx, group); {
+		case err != nil && !isValidationError(err):
+			// Cluster lookup failures are not a fault of the group
+			return nil, err
+		case err != nil:
+			result.Status = models.GroupStatusInvalid
+			result.Error = err.Error()
+			response.Invalid++
+		case s.hasGroup(valid, group):
+			result.Status = models.GroupStatusAlreadyPresent
+		default:
+			result.Status = models.GroupStatusValid
+			valid = append(valid, group)
+		}
+	}
+	if response.Invalid > 0 && !partial {
+		return response, nil
+	}
+
+	unlock := s.storage.LockWrites()
+	defer unlock()
+
+	config, err := s.storage.Load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	var tier *models.Tier
+	for i := range config.Tiers {
+		if config.Tiers[i].Name == tierName && !config.Tiers[i].IsDeleted() {
+			tier = &config.Tiers[i]
+			break
+		}
+	}
+	if tier == nil {
+		return nil, models.ErrTierNotFound
+	}
+
+	before := snapshotTier(*tier)
+	for i := range response.Results {
+		result := &response.Results[i]
+		if result.Status != models.GroupStatusValid {
+			continue
+		}
+		if s.hasGroup(tier.Groups, result.Group) {
+			result.Status = models.GroupStatusAlreadyPresent
+			continue
+		}
+		tier.Groups = append(tier.Groups, result.Group)
+		result.Status = models.GroupStatusAdded
+	}
+	response.Applied = true
+	if len(tier.Groups) == len(before.Groups) {
+		return response, nil
+	}
+
+	if err := s.storage.Save(ctx, config); err != nil {
+		return nil, fmt.Errorf("failed to save config: %w", err)
+	}
+
+	s.notifyTier(webhook.ActionGroupAdded, tierName, before, snapshotTier(*tier))
+	return response, nil
+}
+
+// validateBatchGroup checks a single group of a batch add, returning a validation error
+// for a malformed name or a group missing from the cluster
+func (s *TierService) validateBatchGroup(ctx context.Context, group string) error {
+	if err := models.ValidateTierGroup(group); err != nil {
+		return models.NewValueValidationError(models.FieldGroups, group, err)
+	}
+	return s.validateGroupsExist(ctx, []string{group})
+}
+
+// isValidationError reports whether err is a *models.ValidationError
+func isValidationError(err error) bool {
+	var validationErr *models.ValidationError
+	return errors.As(err, &validationErr)
+}
+
+// CopyGroups unions the groups of the source tier into the target tier, leaving the
+// source and the target's other fields unchanged, and returns the updated target.
+// Groups the target does not already list must exist in the cluster.
+func (s *TierService) CopyGroups(ctx context.Context, targetName, sourceName string) (updated *models.Tier, err error) {
+	defer s.recordOperation(OperationAddGroup, &err)
+
+	unlock := s.storage.LockWrites()
+	defer unlock()
+
+	config, err := s.storage.Load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	var target, source *models.Tier
+	for i := range config.Tiers {
+		if config.Tiers[i].IsDeleted() {
+			continue
+		}
+		switch config.Tiers[i].Name {
+		case targetName:
+			target = &config.Tiers[i]
+		case sourceName:
+			source = &config.Tiers[i]
+		}
+	}
+	if target == nil {
+		return nil, models.ErrTierNotFound
+	}
+	if source == nil {
+		if sourceName != targetName {
+			return nil, fmt.Errorf("source tier %s: %w", sourceName, models.ErrTierNotFound)
+		}
+		source = target
+	}
+
+	var added []string
+	for _, group := range source.Groups {
+		if s.hasGroup(target.Groups, group) || s.hasGroup(added, group) {
+			continue
+		}
+		if err := models.ValidateTierGroup(group); err != nil {
+			return nil, models.NewValueValidationError(models.FieldGroups, group, err)
+		}
+		added = append(added, group)
+	}
+	if err := s.validateGroupsExist(ctx, added); err != nil {
+		return nil, err
+	}
+	if len(added) == 0 {
+		return snapshotTier(*target), nil
+	}
+
+	before := snapshotTier(*target)
+	target.Groups = append(target.Groups, added...)
+	after := snapshotTier(*target)
+
+	if err := s.storage.Save(ctx, config); err != nil {
+		return nil, fmt.Errorf("failed to save config: %w", err)
+	}
+
+	s.notifyTier(webhook.ActionUpdated, targetName, before, after)
+	return after, nil
+}
+
+// GetUnassignedGroups returns the cluster groups that no active tier references, exactly or
+// through a wildcard pattern. Groups prefixed with system: are skipped unless includeSystem is
+// set, in which case the special groups, which the API does not list, are considered too.
+func (s *TierService) GetUnassignedGroups(ctx context.Context, includeSystem bool) (*models.UnassignedGroupsResponse, error) {
+	groups, err := s.storage.ListGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if includeSystem {
+		groups = append(groups, s.storage.SpecialGroups...)
+	}
+
+	config, err := s.storage.Load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	tiers := activeTiers(config.Tiers)
+
+	response := &models.UnassignedGroupsResponse{IncludeSystem: includeSystem, Groups: []string{}}
+	seen := make(map[string]bool, len(groups))
+	for _, group := range groups {
+		if seen[group] || !includeSystem && strings.HasPrefix(group, "system:") {
+			continue
+		}
+		seen[group] = true
+		response.ClusterGroups++
+		if !tiersReferenceGroup(tiers, group) {
+			response.Groups = append(response.Groups, group)
+		}
+	}
+	sort.Strings(response.Groups)
+	return response, nil
+}
+
+// tiersReferenceGroup reports whether any of tiers lists group or a wildcard pattern matching it
+func tiersReferenceGroup(tiers []models.Tier, group string) bool {
+	for _, tier := range tiers {
+		for _, entry := range tier.Groups {
+			if models.GroupMatches(entry, group) {
+				return true
+			}
+		}
+	}
+	return false
+}
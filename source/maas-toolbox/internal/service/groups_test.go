@@ -0,0 +1,476 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"maas-toolbox/internal/models"
+	"maas-toolbox/internal/storage"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// newTestTierService creates a TierService backed by an empty fake ConfigMap store
+func newTestTierService(opts ...TierServiceOption) *TierService {
+	s, _ := newTestTierServiceWithClient(opts...)
+	return s
+}
+
+// newTestTierServiceWithClient also returns the fake clientset so tests can inspect API calls
+func newTestTierServiceWithClient(opts ...TierServiceOption) (*TierService, *fake.Clientset) {
+	client := fake.NewSimpleClientset()
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		{Group: "user.openshift.io", Version: "v1", Resource: "groups"}: "GroupList",
+	})
+	return NewTierService(storage.NewK8sTierStorage(client, dynamicClient, "test", "tier-to-group-mapping"), opts...), client
+}
+
+func TestParseGroupMatchPolicy(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    GroupMatchPolicy
+		wantErr bool
+	}{
+		{"", GroupMatchExact, false},
+		{"exact", GroupMatchExact, false},
+		{"Case-Insensitive", GroupMatchCaseInsensitive, false},
+		{"fuzzy", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseGroupMatchPolicy(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseGroupMatchPolicy(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseGroupMatchPolicy(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDedupeGroups(t *testing.T) {
+	groups := []string{"premium", "Premium", "free", "premium", "PREMIUM"}
+
+	tests := []struct {
+		policy GroupMatchPolicy
+		want   []string
+	}{
+		{GroupMatchExact, []string{"premium", "Premium", "free", "PREMIUM"}},
+		{GroupMatchCaseInsensitive, []string{"premium", "free"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			s := newTestTierService(WithGroupMatchPolicy(tt.policy))
+			got := s.dedupeGroups("test", groups)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("dedupeGroups() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateTier_DedupesGroups(t *testing.T) {
+	for _, policy := range []GroupMatchPolicy{GroupMatchExact, GroupMatchCaseInsensitive} {
+		t.Run(string(policy), func(t *testing.T) {
+			s := newTestTierService(WithGroupMatchPolicy(policy))
+			tier := &models.Tier{
+				Name:        "free",
+				Description: "Free tier",
+				Groups:      []string{storage.SystemAuthenticatedGroup, storage.SystemAuthenticatedGroup},
+			}
+			if err := s.CreateTier(context.Background(), tier); err != nil {
+				t.Fatalf("CreateTier() error = %v", err)
+			}
+
+			stored, err := s.GetTier(context.Background(), "free")
+			if err != nil {
+				t.Fatalf("GetTier() error = %v", err)
+			}
+			if len(stored.Groups) != 1 {
+				t.Errorf("Expected duplicate group to be dropped, got %v", stored.Groups)
+			}
+		})
+	}
+}
+
+func TestAddGroup_DuplicateDetection(t *testing.T) {
+	s := newTestTierService()
+	tier := &models.Tier{Name: "free", Description: "Free tier", Groups: []string{storage.SystemAuthenticatedGroup}}
+	if err := s.CreateTier(context.Background(), tier); err != nil {
+		t.Fatalf("CreateTier() error = %v", err)
+	}
+
+	err := s.AddGroup(context.Background(), "free", storage.SystemAuthenticatedGroup)
+	if !errors.Is(err, models.ErrGroupAlreadyExists) {
+		t.Errorf("AddGroup() error = %v, want %v", err, models.ErrGroupAlreadyExists)
+	}
+}
+
+func TestCreateTier_RequireGroups(t *testing.T) {
+	tests := []struct {
+		name          string
+		requireGroups bool
+		wantErr       error
+	}{
+		{"disabled", false, nil},
+		{"enabled", true, models.ErrTierGroupsRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestTierService(WithRequireGroups(tt.requireGroups))
+			err := s.CreateTier(context.Background(), &models.Tier{Name: "free", Description: "Free tier", Groups: []string{}})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CreateTier() error = %v, want %v", err, tt.wantErr)
+			}
+			var validationErr *models.ValidationError
+			if tt.wantErr != nil && (!errors.As(err, &validationErr) || validationErr.Field != models.FieldGroups) {
+				t.Errorf("Expected a groups validation error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestRemoveGroup_RequireGroups(t *testing.T) {
+	s := newTestTierService(WithRequireGroups(true))
+	tier := &models.Tier{Name: "free", Description: "Free tier", Groups: []string{storage.SystemAuthenticatedGroup}}
+	if err := s.CreateTier(context.Background(), tier); err != nil {
+		t.Fatalf("CreateTier() error = %v", err)
+	}
+
+	err := s.RemoveGroup(context.Background(), "free", storage.SystemAuthenticatedGroup)
+	if !errors.Is(err, models.ErrTierGroupsRequired) {
+		t.Errorf("RemoveGroup() error = %v, want %v", err, models.ErrTierGroupsRequired)
+	}
+}
+
+func TestRemoveGroupFromAllTiers(t *testing.T) {
+	s := newSeededTierService(t, []models.Tier{
+		{Name: "free", Description: "Free", Level: 1, Groups: []string{storage.SystemAuthenticatedGroup}},
+		{Name: "premium", Description: "Premium", Level: 10, Groups: []string{"premium-users", "legacy-users"}},
+		{Name: "enterprise", Description: "Enterprise", Level: 20, Groups: []string{"legacy-users"}},
+	})
+
+	// A dry run reports the affected tiers without saving
+	response, err := s.RemoveGroupFromAllTiers(context.Background(), "legacy-users", true)
+	if err != nil {
+		t.Fatalf("RemoveGroupFromAllTiers() dry run error = %v", err)
+	}
+	if !response.DryRun || !reflect.DeepEqual(response.Tiers, []string{"premium", "enterprise"}) {
+		t.Errorf("Expected dry run over [premium enterprise], got %+v", response)
+	}
+	if tiers, _ := s.GetTiersByGroup(context.Background(), "legacy-users"); len(tiers) != 2 {
+		t.Errorf("Expected dry run to leave both tiers unchanged, got %d tiers", len(tiers))
+	}
+
+	response, err = s.RemoveGroupFromAllTiers(context.Background(), "legacy-users", false)
+	if err != nil {
+		t.Fatalf("RemoveGroupFromAllTiers() error = %v", err)
+	}
+	if response.DryRun || !reflect.DeepEqual(response.Tiers, []string{"premium", "enterprise"}) {
+		t.Errorf("Expected [premium enterprise] to be modified, got %+v", response)
+	}
+	premium, _ := s.GetTier(context.Background(), "premium")
+	if !reflect.DeepEqual(premium.Groups, []string{"premium-users"}) {
+		t.Errorf("Expected premium to keep premium-users, got %v", premium.Groups)
+	}
+	if tiers, _ := s.GetTiersByGroup(context.Background(), "legacy-users"); len(tiers) != 0 {
+		t.Errorf("Expected no tier to list legacy-users, got %d", len(tiers))
+	}
+
+	// Removing a group listed nowhere modifies nothing
+	response, err = s.RemoveGroupFromAllTiers(context.Background(), "legacy-users", false)
+	if err != nil || len(response.Tiers) != 0 {
+		t.Errorf("Expected no tiers to be modified, got %+v, %v", response, err)
+	}
+
+	if _, err := s.RemoveGroupFromAllTiers(context.Background(), "Invalid_Group", false); !errors.Is(err, models.ErrInvalidKubernetesName) {
+		t.Errorf("Expected %v, got %v", models.ErrInvalidKubernetesName, err)
+	}
+}
+
+func TestRemoveGroupFromAllTiers_RequireGroups(t *testing.T) {
+	s := newSeededTierService(t, []models.Tier{
+		{Name: "premium", Description: "Premium", Level: 10, Groups: []string{"premium-users", "legacy-users"}},
+		{Name: "enterprise", Description: "Enterprise", Level: 20, Groups: []string{"legacy-users"}},
+	}, WithRequireGroups(true))
+
+	if _, err := s.RemoveGroupFromAllTiers(context.Background(), "legacy-users", false); !errors.Is(err, models.ErrTierGroupsRequired) {
+		t.Fatalf("Expected %v, got %v", models.ErrTierGroupsRequired, err)
+	}
+	premium, _ := s.GetTier(context.Background(), "premium")
+	if len(premium.Groups) != 2 {
+		t.Errorf("Expected no tier to be modified, got premium groups %v", premium.Groups)
+	}
+}
+
+// addTestClusterGroup creates an OpenShift group in the fake cluster behind s
+func addTestClusterGroup(t *testing.T, s *TierService, name string) {
+	t.Helper()
+	group := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "user.openshift.io/v1",
+		"kind":       "Group",
+		"metadata":   map[string]interface{}{"name": name},
+	}}
+	groups := s.storage.DynamicClient.Resource(schema.GroupVersionResource{Group: "user.openshift.io", Version: "v1", Resource: "groups"})
+	if _, err := groups.Create(context.Background(), group, metav1.CreateOptions{}); err != nil {
+		t.Fatalf("Failed to create group %s: %v", name, err)
+	}
+}
+
+func TestRenameGroup(t *testing.T) {
+	s := newSeededTierService(t, []models.Tier{
+		{Name: "free", Description: "Free", Level: 1, Groups: []string{storage.SystemAuthenticatedGroup}},
+		{Name: "premium", Description: "Premium", Level: 10, Groups: []string{"old-users", "vip-users"}},
+		{Name: "enterprise", Description: "Enterprise", Level: 20, Groups: []string{"new-users", "old-users"}},
+	})
+	addTestClusterGroup(t, s, "new-users")
+
+	response, err := s.RenameGroup(context.Background(), &models.RenameGroupRequest{From: "old-users", To: "new-users"})
+	if err != nil {
+		t.Fatalf("RenameGroup() error = %v", err)
+	}
+	if !reflect.DeepEqual(response.Tiers, []string{"premium", "enterprise"}) {
+		t.Errorf("Expected [premium enterprise] to be modified, got %v", response.Tiers)
+	}
+
+	premium, _ := s.GetTier(context.Background(), "premium")
+	if !reflect.DeepEqual(premium.Groups, []string{"new-users", "vip-users"}) {
+		t.Errorf("Expected old-users to be renamed in place, got %v", premium.Groups)
+	}
+
+	// A tier that already had both names keeps a single entry
+	enterprise, _ := s.GetTier(context.Background(), "enterprise")
+	if !reflect.DeepEqual(enterprise.Groups, []string{"new-users"}) {
+		t.Errorf("Expected a single new-users entry, got %v", enterprise.Groups)
+	}
+
+	free, _ := s.GetTier(context.Background(), "free")
+	if !reflect.DeepEqual(free.Groups, []string{storage.SystemAuthenticatedGroup}) {
+		t.Errorf("Expected free to be untouched, got %v", free.Groups)
+	}
+}
+
+func TestRenameGroup_Validation(t *testing.T) {
+	s := newSeededTierService(t, []models.Tier{
+		{Name: "premium", Description: "Premium", Level: 10, Groups: []string{"old-users"}},
+	})
+
+	_, err := s.RenameGroup(context.Background(), &models.RenameGroupRequest{From: "old-users", To: "missing-users"})
+	if !errors.Is(err, models.ErrGroupNotFoundInCluster) {
+		t.Errorf("Expected %v, got %v", models.ErrGroupNotFoundInCluster, err)
+	}
+
+	var validationErr *models.ValidationError
+	_, err = s.RenameGroup(context.Background(), &models.RenameGroupRequest{From: "old-users", To: "Invalid_Group"})
+	if !errors.As(err, &validationErr) || validationErr.Field != models.FieldTo {
+		t.Errorf("Expected a validation error on %s, got %v", models.FieldTo, err)
+	}
+
+	premium, _ := s.GetTier(context.Background(), "premium")
+	if !reflect.DeepEqual(premium.Groups, []string{"old-users"}) {
+		t.Errorf("Expected a rejected rename to leave tiers unchanged, got %v", premium.Groups)
+	}
+}
+
+func TestCopyGroups(t *testing.T) {
+	s := newSeededTierService(t, []models.Tier{
+		{Name: "premium", Description: "Premium", Level: 10, Groups: []string{"premium-users", "team:*"}},
+		{Name: "trial", Description: "Trial", Level: 5, Groups: []string{"premium-users", "trial-users"}},
+	})
+	addTestClusterGroup(t, s, "premium-users")
+	addTestClusterGroup(t, s, "trial-users")
+
+	tier, err := s.CopyGroups(context.Background(), "trial", "premium")
+	if err != nil {
+		t.Fatalf("CopyGroups() error = %v", err)
+	}
+	if !reflect.DeepEqual(tier.Groups, []string{"premium-users", "trial-users", "team:*"}) {
+		t.Errorf("Expected the union of groups, got %v", tier.Groups)
+	}
+	if tier.Description != "Trial" || tier.Level != 5 {
+		t.Errorf("Expected description and level to be unchanged, got %+v", tier)
+	}
+
+	premium, _ := s.GetTier(context.Background(), "premium")
+	if !reflect.DeepEqual(premium.Groups, []string{"premium-users", "team:*"}) {
+		t.Errorf("Expected the source to be unchanged, got %v", premium.Groups)
+	}
+
+	if _, err := s.CopyGroups(context.Background(), "trial", "missing"); !errors.Is(err, models.ErrTierNotFound) {
+		t.Errorf("Expected %v for a missing source, got %v", models.ErrTierNotFound, err)
+	}
+	if _, err := s.CopyGroups(context.Background(), "missing", "premium"); !errors.Is(err, models.ErrTierNotFound) {
+		t.Errorf("Expected %v for a missing target, got %v", models.ErrTierNotFound, err)
+	}
+}
+
+func TestCopyGroups_GroupNotInCluster(t *testing.T) {
+	s := newSeededTierService(t, []models.Tier{
+		{Name: "premium", Description: "Premium", Level: 10, Groups: []string{"deleted-users"}},
+		{Name: "trial", Description: "Trial", Level: 5},
+	})
+
+	if _, err := s.CopyGroups(context.Background(), "trial", "premium"); !errors.Is(err, models.ErrGroupNotFoundInCluster) {
+		t.Errorf("Expected %v, got %v", models.ErrGroupNotFoundInCluster, err)
+	}
+}
+
+// batchStatuses returns the status of each group in a batch add response
+func batchStatuses(response *models.BatchAddGroupsResponse) map[string]string {
+	statuses := make(map[string]string, len(response.Results))
+	for _, result := range response.Results {
+		statuses[result.Group] = result.Status
+	}
+	return statuses
+}
+
+func TestAddGroups_MixedValidAndInvalid(t *testing.T) {
+	s := newSeededTierService(t, []models.Tier{
+		{Name: "premium", Description: "Premium", Level: 10, Groups: []string{"premium-users"}},
+	})
+	addTestClusterGroup(t, s, "premium-users")
+	addTestClusterGroup(t, s, "vip-users")
+
+	req := &models.BatchAddGroupsRequest{Groups: []string{"vip-users", "Bad_Group", "missing-users", "team:*", "premium-users"}}
+	response, err := s.AddGroups(context.Background(), "premium", req, false)
+	if err != nil {
+		t.Fatalf("AddGroups() error = %v", err)
+	}
+	if response.Applied || response.Invalid != 2 {
+		t.Errorf("Expected 2 invalid groups and nothing applied, got %+v", response)
+	}
+	want := map[string]string{
+		"vip-users":     models.GroupStatusValid,
+		"Bad_Group":     models.GroupStatusInvalid,
+		"missing-users": models.GroupStatusInvalid,
+		"team:*":        models.GroupStatusValid,
+		"premium-users": models.GroupStatusValid,
+	}
+	if got := batchStatuses(response); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected statuses %v, got %v", want, got)
+	}
+	if response.Results[1].Error != models.ErrInvalidKubernetesName.Error() || response.Results[2].Error != models.ErrGroupNotFoundInCluster.Error() {
+		t.Errorf("Expected per-group reasons, got %+v", response.Results)
+	}
+
+	premium, _ := s.GetTier(context.Background(), "premium")
+	if !reflect.DeepEqual(premium.Groups, []string{"premium-users"}) {
+		t.Errorf("Expected nothing to be saved, got %v", premium.Groups)
+	}
+}
+
+func TestAddGroups_Partial(t *testing.T) {
+	s := newSeededTierService(t, []models.Tier{
+		{Name: "premium", Description: "Premium", Level: 10, Groups: []string{"premium-users"}},
+	})
+	addTestClusterGroup(t, s, "premium-users")
+	addTestClusterGroup(t, s, "vip-users")
+
+	req := &models.BatchAddGroupsRequest{Groups: []string{"vip-users", "Bad_Group", "premium-users", "vip-users"}}
+	response, err := s.AddGroups(context.Background(), "premium", req, true)
+	if err != nil {
+		t.Fatalf("AddGroups() error = %v", err)
+	}
+	if !response.Applied || response.Invalid != 1 {
+		t.Errorf("Expected valid groups applied with 1 invalid, got %+v", response)
+	}
+	wantStatuses := []string{models.GroupStatusAdded, models.GroupStatusInvalid, models.GroupStatusAlreadyPresent, models.GroupStatusAlreadyPresent}
+	for i, result := range response.Results {
+		if result.Status != wantStatuses[i] {
+			t.Errorf("Result %d (%s): expected %s, got %s", i, result.Group, wantStatuses[i], result.Status)
+		}
+	}
+
+	premium, _ := s.GetTier(context.Background(), "premium")
+	if !reflect.DeepEqual(premium.Groups, []string{"premium-users", "vip-users"}) {
+		t.Errorf("Expected vip-users to be saved, got %v", premium.Groups)
+	}
+}
+
+func TestAddGroups_AllValid(t *testing.T) {
+	s := newSeededTierService(t, []models.Tier{
+		{Name: "premium", Description: "Premium", Level: 10, Groups: []string{}},
+	})
+	addTestClusterGroup(t, s, "vip-users")
+
+	response, err := s.AddGroups(context.Background(), "premium", &models.BatchAddGroupsRequest{Groups: []string{"vip-users", "team:*"}}, false)
+	if err != nil {
+		t.Fatalf("AddGroups() error = %v", err)
+	}
+	if !response.Applied || response.Invalid != 0 {
+		t.Errorf("Expected the batch to be applied, got %+v", response)
+	}
+	premium, _ := s.GetTier(context.Background(), "premium")
+	if !reflect.DeepEqual(premium.Groups, []string{"vip-users", "team:*"}) {
+		t.Errorf("Expected both groups to be saved, got %v", premium.Groups)
+	}
+
+	if _, err := s.AddGroups(context.Background(), "missing", &models.BatchAddGroupsRequest{Groups: []string{"team:*"}}, false); !errors.Is(err, models.ErrTierNotFound) {
+		t.Errorf("Expected %v, got %v", models.ErrTierNotFound, err)
+	}
+	if _, err := s.AddGroups(context.Background(), "premium", &models.BatchAddGroupsRequest{}, false); !errors.Is(err, models.ErrGroupRequired) {
+		t.Errorf("Expected %v for an empty batch, got %v", models.ErrGroupRequired, err)
+	}
+}
+
+func TestGetUnassignedGroups(t *testing.T) {
+	deletedAt := time.Now()
+	s := newSeededTierService(t, []models.Tier{
+		{Name: "free", Description: "Free", Level: 1, Groups: []string{storage.SystemAuthenticatedGroup}},
+		{Name: "premium", Description: "Premium", Level: 10, Groups: []string{"premium-users", "team:*"}},
+		{Name: "retired", Description: "Retired", Level: 5, Groups: []string{"retired-users"}, DeletedAt: &deletedAt},
+	})
+	for _, group := range []string{"premium-users", "team:alpha", "retired-users", "interns", "system:cluster-readers"} {
+		addTestClusterGroup(t, s, group)
+	}
+
+	response, err := s.GetUnassignedGroups(context.Background(), false)
+	if err != nil {
+		t.Fatalf("GetUnassignedGroups() error = %v", err)
+	}
+	if response.ClusterGroups != 4 || !reflect.DeepEqual(response.Groups, []string{"interns", "retired-users"}) {
+		t.Errorf("Expected interns and the soft-deleted tier's group out of 4 groups, got %+v", response)
+	}
+
+	response, err = s.GetUnassignedGroups(context.Background(), true)
+	if err != nil {
+		t.Fatalf("GetUnassignedGroups(includeSystem) error = %v", err)
+	}
+	if !response.IncludeSystem || response.ClusterGroups != 5+len(storage.DefaultSpecialGroups)-1 {
+		t.Errorf("Expected every cluster and special group to be considered once, got %+v", response)
+	}
+	for _, group := range response.Groups {
+		if group == storage.SystemAuthenticatedGroup {
+			t.Errorf("Expected %s, referenced by free, to be assigned", group)
+		}
+	}
+	if !slices.Contains(response.Groups, "system:cluster-readers") || !slices.Contains(response.Groups, "system:masters") {
+		t.Errorf("Expected unreferenced system groups to be reported, got %v", response.Groups)
+	}
+}
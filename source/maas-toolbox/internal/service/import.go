@@ -0,0 +1,142 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"maas-toolbox/internal/models"
+	"maas-toolbox/internal/webhook"
+	"strings"
+)
+
+// ImportTiers creates or replaces each imported tier by name; tiers not in the import are kept.
+// By default the import is atomic: the first invalid tier fails the whole import and nothing is saved.
+// With partial set, invalid tiers are skipped and every valid tier is applied.
+// Either way the accepted set is written with a single Save.
+func (s *TierService) ImportTiers(ctx context.Context, tiers []models.Tier, partial bool) (response *models.ImportResponse, err error) {
+	defer s.recordOperation(OperationImport, &err)
+
+	unlock := s.storage.LockWrites()
+	defer unlock()
+
+	// Load existing config
+	config, err := s.storage.Load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	existing := make(map[string]int, len(config.Tiers))
+	// Lowercased names of existing and accepted tiers, for rejecting case variants
+	folded := make(map[string]string, len(config.Tiers))
+	for i, tier := range config.Tiers {
+		existing[tier.Name] = i
+		folded[strings.ToLower(tier.Name)] = tier.Name
+	}
+
+	response = &models.ImportResponse{Partial: partial, Results: []models.ImportResult{}}
+	imported := make(map[string]bool, len(tiers))
+	var events []webhook.Event
+
+	for i := range tiers {
+		tier := tiers[i]
+
+		err := s.validateImportedTier(ctx, &tier)
+		if err == nil && imported[tier.Name] {
+			err = fmt.Errorf("%w: duplicate tier in import", models.ErrTierAlreadyExists)
+		}
+		if other, ok := folded[strings.ToLower(tier.Name)]; err == nil && ok && other != tier.Name {
+			err = fmt.Errorf("%w: %q differs only in case", models.ErrTierAlreadyExists, other)
+		}
+		if err == nil {
+			// Compare against existing tiers and those already accepted from this import
+			err = s.checkLevel(config.Tiers, tier.Name, tier.Level)
+		}
+		if _, ok := existing[tier.Name]; err == nil && !ok {
+			err = s.checkTierCapacity(config.Tiers)
+		}
+		if err != nil {
+			if !partial {
+				return nil, fmt.Errorf("tier %q: %w", tier.Name, err)
+			}
+			log.Printf("Import: skipping tier %q: %v", tier.Name, err)
+			response.Results = append(response.Results, models.ImportResult{
+				Name:   tier.Name,
+				Status: models.ImportStatusSkipped,
+				Reason: err.Error(),
+			})
+			response.Skipped++
+			continue
+		}
+		imported[tier.Name] = true
+
+		action := models.ImportActionCreated
+		event := webhook.Event{Action: webhook.ActionCreated, Resource: webhook.ResourceTier, Name: tier.Name, After: snapshotTier(tier)}
+		if index, ok := existing[tier.Name]; ok {
+			event.Action = webhook.ActionUpdated
+			event.Before = snapshotTier(config.Tiers[index])
+			config.Tiers[index] = tier
+			action = models.ImportActionUpdated
+		} else {
+			existing[tier.Name] = len(config.Tiers)
+			folded[strings.ToLower(tier.Name)] = tier.Name
+			config.Tiers = append(config.Tiers, tier)
+		}
+		events = append(events, event)
+		response.Results = append(response.Results, models.ImportResult{
+			Name:   tier.Name,
+			Status: models.ImportStatusApplied,
+			Action: action,
+		})
+		response.Applied++
+	}
+
+	if response.Applied == 0 {
+		return response, nil
+	}
+
+	// Save the accepted set once
+	if err := s.storage.Save(ctx, config); err != nil {
+		return nil, fmt.Errorf("failed to save config: %w", err)
+	}
+
+	for _, event := range events {
+		s.emit(event)
+	}
+
+	log.Printf("Import: applied %d tiers, skipped %d", response.Applied, response.Skipped)
+	return response, nil
+}
+
+// validateImportedTier applies the same checks as CreateTier to an imported tier
+func (s *TierService) validateImportedTier(ctx context.Context, tier *models.Tier) error {
+	if tier.Groups == nil {
+		tier.Groups = []string{}
+	}
+	// Importing a tier always makes it active, restoring a soft-deleted tier of the same name
+	tier.DeletedAt = nil
+	if err := tier.Validate(); err != nil {
+		return err
+	}
+	if err := s.checkGroupsRequired(tier.Groups); err != nil {
+		return err
+	}
+	if err := models.ValidateTierName(tier.Name); err != nil {
+		return models.NewValidationError(models.FieldName, err)
+	}
+	tier.Groups = s.dedupeGroups(tier.Name, tier.Groups)
+	return s.validateGroupsExist(ctx, tier.Groups)
+}
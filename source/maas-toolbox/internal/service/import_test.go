@@ -0,0 +1,171 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"maas-toolbox/internal/models"
+	"testing"
+
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// countConfigMapWrites returns the number of create and update calls made on ConfigMaps
+func countConfigMapWrites(client *fake.Clientset) int {
+	writes := 0
+	for _, action := range client.Actions() {
+		if action.GetResource().Resource == "configmaps" && (action.GetVerb() == "create" || action.GetVerb() == "update" || action.GetVerb() == "patch") {
+			writes++
+		}
+	}
+	return writes
+}
+
+var importTestTiers = []models.Tier{
+	{Name: "free", Description: "Free tier, updated", Level: 1},
+	{Name: "invalid", Description: "Bad level", Level: -1},
+	{Name: "premium", Description: "Premium tier", Level: 10},
+	{Name: "Bad Name", Description: "Bad name", Level: 2},
+	{Name: "premium", Description: "Duplicate", Level: 11},
+}
+
+func TestImportTiers_AtomicRejectsInvalid(t *testing.T) {
+	s, client := newTestTierServiceWithClient()
+	if err := s.CreateTier(context.Background(), &models.Tier{Name: "free", Description: "Free tier", Level: 1}); err != nil {
+		t.Fatalf("CreateTier() error = %v", err)
+	}
+	writesBefore := countConfigMapWrites(client)
+
+	_, err := s.ImportTiers(context.Background(), importTestTiers, false)
+	if !errors.Is(err, models.ErrTierLevelInvalid) {
+		t.Fatalf("ImportTiers() error = %v, want %v", err, models.ErrTierLevelInvalid)
+	}
+	if writes := countConfigMapWrites(client) - writesBefore; writes != 0 {
+		t.Errorf("Expected no writes for a failed atomic import, got %d", writes)
+	}
+
+	tier, err := s.GetTier(context.Background(), "free")
+	if err != nil {
+		t.Fatalf("GetTier() error = %v", err)
+	}
+	if tier.Description != "Free tier" {
+		t.Errorf("Expected existing tier to be unchanged, got %+v", tier)
+	}
+}
+
+func TestImportTiers_CaseVariantDuplicate(t *testing.T) {
+	// Seed a mixed-case name directly, as if stored before name validation was tightened
+	s := newSeededTierService(t, []models.Tier{{Name: "Premium", Description: "Premium", Level: 10, Groups: []string{}}})
+
+	_, err := s.ImportTiers(context.Background(), []models.Tier{{Name: "premium", Description: "Premium again", Level: 11}}, false)
+	if !errors.Is(err, models.ErrTierAlreadyExists) {
+		t.Fatalf("ImportTiers() error = %v, want %v", err, models.ErrTierAlreadyExists)
+	}
+
+	response, err := s.ImportTiers(context.Background(), []models.Tier{{Name: "premium", Description: "Premium again", Level: 11}}, true)
+	if err != nil {
+		t.Fatalf("ImportTiers() error = %v", err)
+	}
+	if response.Applied != 0 || response.Skipped != 1 {
+		t.Errorf("Expected the case variant to be skipped, got %d applied and %d skipped", response.Applied, response.Skipped)
+	}
+}
+
+func TestImportTiers_MaxTiers(t *testing.T) {
+	s := newTestTierService(WithMaxTiers(2))
+	if err := s.CreateTier(context.Background(), &models.Tier{Name: "free", Description: "Free tier", Level: 1}); err != nil {
+		t.Fatalf("CreateTier() error = %v", err)
+	}
+	tiers := []models.Tier{
+		{Name: "free", Description: "Free tier, replaced", Level: 1},
+		{Name: "premium", Description: "Premium tier", Level: 10},
+		{Name: "enterprise", Description: "Enterprise tier", Level: 20},
+	}
+
+	if _, err := s.ImportTiers(context.Background(), tiers, false); !errors.Is(err, models.ErrTierLimitReached) {
+		t.Fatalf("ImportTiers() error = %v, want %v", err, models.ErrTierLimitReached)
+	}
+
+	// Replacing free does not add a tier, so only enterprise is over the cap
+	response, err := s.ImportTiers(context.Background(), tiers, true)
+	if err != nil {
+		t.Fatalf("ImportTiers() error = %v", err)
+	}
+	if response.Applied != 2 || response.Skipped != 1 || response.Results[2].Status != models.ImportStatusSkipped {
+		t.Errorf("Expected free and premium applied and enterprise skipped, got %+v", response)
+	}
+}
+
+func TestImportTiers_AtomicAppliesAll(t *testing.T) {
+	s, client := newTestTierServiceWithClient()
+
+	response, err := s.ImportTiers(context.Background(), []models.Tier{importTestTiers[0], importTestTiers[2]}, false)
+	if err != nil {
+		t.Fatalf("ImportTiers() error = %v", err)
+	}
+	if response.Applied != 2 || response.Skipped != 0 {
+		t.Errorf("Expected 2 applied and 0 skipped, got %d and %d", response.Applied, response.Skipped)
+	}
+	if writes := countConfigMapWrites(client); writes != 1 {
+		t.Errorf("Expected a single Save, got %d writes", writes)
+	}
+}
+
+func TestImportTiers_Partial(t *testing.T) {
+	s, client := newTestTierServiceWithClient()
+	if err := s.CreateTier(context.Background(), &models.Tier{Name: "free", Description: "Free tier", Level: 1}); err != nil {
+		t.Fatalf("CreateTier() error = %v", err)
+	}
+	writesBefore := countConfigMapWrites(client)
+
+	response, err := s.ImportTiers(context.Background(), importTestTiers, true)
+	if err != nil {
+		t.Fatalf("ImportTiers() error = %v", err)
+	}
+
+	want := []models.ImportResult{
+		{Name: "free", Status: models.ImportStatusApplied, Action: models.ImportActionUpdated},
+		{Name: "invalid", Status: models.ImportStatusSkipped},
+		{Name: "premium", Status: models.ImportStatusApplied, Action: models.ImportActionCreated},
+		{Name: "Bad Name", Status: models.ImportStatusSkipped},
+		{Name: "premium", Status: models.ImportStatusSkipped},
+	}
+	if len(response.Results) != len(want) {
+		t.Fatalf("Expected %d results, got %d: %+v", len(want), len(response.Results), response.Results)
+	}
+	for i, result := range response.Results {
+		if result.Name != want[i].Name || result.Status != want[i].Status || result.Action != want[i].Action {
+			t.Errorf("Result %d = %+v, want %+v", i, result, want[i])
+		}
+		if result.Status == models.ImportStatusSkipped && result.Reason == "" {
+			t.Errorf("Result %d is skipped without a reason", i)
+		}
+	}
+	if response.Applied != 2 || response.Skipped != 3 {
+		t.Errorf("Expected 2 applied and 3 skipped, got %d and %d", response.Applied, response.Skipped)
+	}
+	if writes := countConfigMapWrites(client) - writesBefore; writes != 1 {
+		t.Errorf("Expected a single Save, got %d writes", writes)
+	}
+
+	tiers, err := s.GetTiers(context.Background())
+	if err != nil {
+		t.Fatalf("GetTiers() error = %v", err)
+	}
+	if len(tiers) != 2 || tiers[0].Description != "Free tier, updated" || tiers[1].Name != "premium" || tiers[1].Level != 10 {
+		t.Errorf("Unexpected tiers after import: %+v", tiers)
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"maas-toolbox/internal/models"
+	"maas-toolbox/internal/webhook"
+)
+
+// WithUniqueLevels controls whether two tiers may share a level.
+// It is disabled by default, so duplicate levels are allowed.
+func WithUniqueLevels(enabled bool) TierServiceOption {
+	return func(s *TierService) {
+		s.uniqueLevels = enabled
+	}
+}
+
+// WithMaxLevel rejects tiers whose level is greater than max. Zero, the default, sets no maximum.
+func WithMaxLevel(max int) TierServiceOption {
+	return func(s *TierService) {
+		s.maxLevel = max
+	}
+}
+
+// SetTierLevel sets only the level of an active tier and returns the updated tier.
+// The level is checked against the maximum and, when enforced, level uniqueness.
+func (s *TierService) SetTierLevel(ctx context.Context, name string, level int) (*models.Tier, error) {
+	if err := s.UpdateTier(ctx, name, &models.TierUpdate{Level: &level}, ""); err != nil {
+		return nil, err
+	}
+	return s.GetTier(ctx, name)
+}
+
+// MoveTierLevel moves an active tier to level. With cascade, every other active tier at or
+// above level is first shifted up by one, opening the slot for the moved tier; without it only
+// the moved tier changes, so the move may collide when unique levels are enforced. All changes
+// are saved together and every tier whose level changed is reported.
+func (s *TierService) MoveTierLevel(ctx context.Context, name string, level int, cascade bool) (response *models.MoveLevelResponse, err error) {
+	defer s.recordOperation(OperationUpdate, &err)
+
+	unlock := s.storage.LockWrites()
+	defer unlock()
+
+	config, err := s.storage.Load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	index := -1
+	for i := range config.Tiers {
+		if config.Tiers[i].Name == name && !config.Tiers[i].IsDeleted() {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return nil, models.ErrTierNotFound
+	}
+
+	response = &models.MoveLevelResponse{Tier: name, Level: level, Cascade: cascade, Changed: []models.LevelChange{}}
+	var moved []int
+	var before []*models.Tier
+	move := func(i, to int) {
+		moved = append(moved, i)
+		before = append(before, snapshotTier(config.Tiers[i]))
+		response.Changed = append(response.Changed, models.LevelChange{Name: config.Tiers[i].Name, From: config.Tiers[i].Level, To: to})
+		config.Tiers[i].Level = to
+	}
+
+	if config.Tiers[index].Level != level {
+		move(index, level)
+	}
+	if cascade {
+		for i := range config.Tiers {
+			if i != index && !config.Tiers[i].IsDeleted() && config.Tiers[i].Level >= level {
+				move(i, config.Tiers[i].Level+1)
+			}
+		}
+	}
+
+	for _, i := range moved {
+		if err := config.Tiers[i].Validate(); err != nil {
+			return nil, err
+		}
+		if err := s.checkLevel(config.Tiers, config.Tiers[i].Name, config.Tiers[i].Level); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(response.Changed) == 0 {
+		return response, nil
+	}
+	if err := s.storage.Save(ctx, config); err != nil {
+		return nil, fmt.Errorf("failed to save config: %w", err)
+	}
+
+	for j, i := range moved {
+		s.notifyTier(webhook.ActionUpdated, config.Tiers[i].Name, before[j], snapshotTier(config.Tiers[i]))
+	}
+	return response, nil
+}
+
+// checkLevel returns an error when level is above the configured maximum or, with unique
+// levels enforced, already used by another active tier in tiers
+func (s *TierService) checkLevel(tiers []models.Tier, name string, level int) error {
+	if s.maxLevel > 0 && level > s.maxLevel {
+		return models.NewValueValidationError(models.FieldLevel, fmt.Sprint(level),
+			fmt.Errorf("%w (%d)", models.ErrTierLevelTooHigh, s.maxLevel))
+	}
+	return s.checkLevelUnique(tiers, name, level)
+}
+
+// checkLevelUnique returns ErrTierLevelConflict when unique levels are enforced and an active
+// tier other than name in tiers already uses level
+func (s *TierService) checkLevelUnique(tiers []models.Tier, name string, level int) error {
+	if !s.uniqueLevels {
+		return nil
+	}
+	for _, tier := range tiers {
+		if tier.Name != name && tier.Level == level && !tier.IsDeleted() {
+			return models.NewValueValidationError(models.FieldLevel, fmt.Sprint(level),
+				fmt.Errorf("%w: %s", models.ErrTierLevelConflict, tier.Name))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,227 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"maas-toolbox/internal/models"
+	"reflect"
+	"testing"
+)
+
+var levelTestTiers = []models.Tier{
+	{Name: "free", Description: "Free", Level: 1, Groups: []string{}},
+	{Name: "premium", Description: "Premium", Level: 10, Groups: []string{}},
+}
+
+// levelPtr returns a pointer to level for TierUpdate.Level
+func levelPtr(level int) *int {
+	return &level
+}
+
+func TestUniqueLevels_Disabled(t *testing.T) {
+	s := newSeededTierService(t, levelTestTiers)
+
+	if err := s.CreateTier(context.Background(), &models.Tier{Name: "basic", Description: "Basic", Level: 1, Groups: []string{}}); err != nil {
+		t.Errorf("Expected duplicate level to be allowed on create, got %v", err)
+	}
+	if err := s.UpdateTier(context.Background(), "premium", &models.TierUpdate{Level: levelPtr(1)}, ""); err != nil {
+		t.Errorf("Expected duplicate level to be allowed on update, got %v", err)
+	}
+}
+
+func TestUniqueLevels_Enabled(t *testing.T) {
+	t.Run("create", func(t *testing.T) {
+		s := newSeededTierService(t, levelTestTiers, WithUniqueLevels(true))
+		err := s.CreateTier(context.Background(), &models.Tier{Name: "basic", Description: "Basic", Level: 1, Groups: []string{}})
+		if !errors.Is(err, models.ErrTierLevelConflict) {
+			t.Errorf("Expected ErrTierLevelConflict, got %v", err)
+		}
+		if err := s.CreateTier(context.Background(), &models.Tier{Name: "basic", Description: "Basic", Level: 5, Groups: []string{}}); err != nil {
+			t.Errorf("Expected unique level to be allowed, got %v", err)
+		}
+	})
+
+	t.Run("update to collide with another tier", func(t *testing.T) {
+		s := newSeededTierService(t, levelTestTiers, WithUniqueLevels(true))
+		err := s.UpdateTier(context.Background(), "premium", &models.TierUpdate{Level: levelPtr(1)}, "")
+		if !errors.Is(err, models.ErrTierLevelConflict) {
+			t.Errorf("Expected ErrTierLevelConflict, got %v", err)
+		}
+		var validationErr *models.ValidationError
+		if !errors.As(err, &validationErr) || validationErr.Field != models.FieldLevel {
+			t.Errorf("Expected validation error on field %s, got %v", models.FieldLevel, err)
+		}
+
+		tier, _ := s.GetTier(context.Background(), "premium")
+		if tier.Level != 10 {
+			t.Errorf("Expected level to remain 10, got %d", tier.Level)
+		}
+	})
+
+	t.Run("update keeping own level", func(t *testing.T) {
+		s := newSeededTierService(t, levelTestTiers, WithUniqueLevels(true))
+		if err := s.UpdateTier(context.Background(), "premium", &models.TierUpdate{Description: "Premium plus", Level: levelPtr(10)}, ""); err != nil {
+			t.Errorf("Expected update keeping own level to succeed, got %v", err)
+		}
+	})
+
+	t.Run("import", func(t *testing.T) {
+		s := newSeededTierService(t, levelTestTiers, WithUniqueLevels(true))
+		_, err := s.ImportTiers(context.Background(), []models.Tier{{Name: "basic", Description: "Basic", Level: 10}}, false)
+		if !errors.Is(err, models.ErrTierLevelConflict) {
+			t.Errorf("Expected ErrTierLevelConflict, got %v", err)
+		}
+
+		// Two imported tiers may not share a level with each other either
+		response, err := s.ImportTiers(context.Background(), []models.Tier{
+			{Name: "basic", Description: "Basic", Level: 5},
+			{Name: "plus", Description: "Plus", Level: 5},
+		}, true)
+		if err != nil {
+			t.Fatalf("ImportTiers() error = %v", err)
+		}
+		if response.Applied != 1 || response.Skipped != 1 {
+			t.Errorf("Expected 1 applied and 1 skipped, got %+v", response)
+		}
+	})
+}
+
+func TestMaxLevel(t *testing.T) {
+	s := newSeededTierService(t, levelTestTiers, WithMaxLevel(100))
+
+	if err := s.CreateTier(context.Background(), &models.Tier{Name: "basic", Description: "Basic", Level: 101, Groups: []string{}}); !errors.Is(err, models.ErrTierLevelTooHigh) {
+		t.Errorf("Expected ErrTierLevelTooHigh on create, got %v", err)
+	}
+	if err := s.UpdateTier(context.Background(), "premium", &models.TierUpdate{Level: levelPtr(101)}, ""); !errors.Is(err, models.ErrTierLevelTooHigh) {
+		t.Errorf("Expected ErrTierLevelTooHigh on update, got %v", err)
+	}
+	if _, err := s.ImportTiers(context.Background(), []models.Tier{{Name: "basic", Description: "Basic", Level: 101}}, false); !errors.Is(err, models.ErrTierLevelTooHigh) {
+		t.Errorf("Expected ErrTierLevelTooHigh on import, got %v", err)
+	}
+	if err := s.CreateTier(context.Background(), &models.Tier{Name: "basic", Description: "Basic", Level: 100, Groups: []string{}}); err != nil {
+		t.Errorf("Expected the maximum level itself to be allowed, got %v", err)
+	}
+}
+
+func TestSetTierLevel(t *testing.T) {
+	s := newSeededTierService(t, levelTestTiers, WithMaxLevel(100))
+
+	tier, err := s.SetTierLevel(context.Background(), "premium", 0)
+	if err != nil {
+		t.Fatalf("SetTierLevel() error = %v", err)
+	}
+	if tier.Level != 0 || tier.Description != "Premium" {
+		t.Errorf("Expected only the level to change to 0, got %+v", tier)
+	}
+
+	if _, err := s.SetTierLevel(context.Background(), "premium", 101); !errors.Is(err, models.ErrTierLevelTooHigh) {
+		t.Errorf("Expected ErrTierLevelTooHigh, got %v", err)
+	}
+	if _, err := s.SetTierLevel(context.Background(), "premium", -1); !errors.Is(err, models.ErrTierLevelInvalid) {
+		t.Errorf("Expected ErrTierLevelInvalid, got %v", err)
+	}
+	if _, err := s.SetTierLevel(context.Background(), "missing", 5); !errors.Is(err, models.ErrTierNotFound) {
+		t.Errorf("Expected ErrTierNotFound, got %v", err)
+	}
+}
+
+var ladderTestTiers = []models.Tier{
+	{Name: "free", Description: "Free", Level: 1, Groups: []string{}},
+	{Name: "basic", Description: "Basic", Level: 5, Groups: []string{}},
+	{Name: "premium", Description: "Premium", Level: 10, Groups: []string{}},
+	{Name: "gold", Description: "Gold", Level: 20, Groups: []string{}},
+}
+
+// tierLevels returns the level of every tier by name
+func tierLevels(t *testing.T, s *TierService) map[string]int {
+	t.Helper()
+	tiers, err := s.GetTiers(context.Background())
+	if err != nil {
+		t.Fatalf("GetTiers() error = %v", err)
+	}
+	levels := make(map[string]int, len(tiers))
+	for _, tier := range tiers {
+		levels[tier.Name] = tier.Level
+	}
+	return levels
+}
+
+func TestMoveTierLevel_Cascade(t *testing.T) {
+	s := newSeededTierService(t, ladderTestTiers, WithUniqueLevels(true))
+
+	response, err := s.MoveTierLevel(context.Background(), "gold", 5, true)
+	if err != nil {
+		t.Fatalf("MoveTierLevel() error = %v", err)
+	}
+
+	wantChanged := []models.LevelChange{
+		{Name: "gold", From: 20, To: 5},
+		{Name: "basic", From: 5, To: 6},
+		{Name: "premium", From: 10, To: 11},
+	}
+	if !reflect.DeepEqual(response.Changed, wantChanged) {
+		t.Errorf("Expected changes %+v, got %+v", wantChanged, response.Changed)
+	}
+	wantLevels := map[string]int{"free": 1, "gold": 5, "basic": 6, "premium": 11}
+	if got := tierLevels(t, s); !reflect.DeepEqual(got, wantLevels) {
+		t.Errorf("Expected levels %v, got %v", wantLevels, got)
+	}
+}
+
+func TestMoveTierLevel_NoCascade(t *testing.T) {
+	t.Run("sets only the moved tier", func(t *testing.T) {
+		s := newSeededTierService(t, ladderTestTiers)
+
+		response, err := s.MoveTierLevel(context.Background(), "gold", 5, false)
+		if err != nil {
+			t.Fatalf("MoveTierLevel() error = %v", err)
+		}
+		if !reflect.DeepEqual(response.Changed, []models.LevelChange{{Name: "gold", From: 20, To: 5}}) {
+			t.Errorf("Expected only gold to change, got %+v", response.Changed)
+		}
+		wantLevels := map[string]int{"free": 1, "basic": 5, "premium": 10, "gold": 5}
+		if got := tierLevels(t, s); !reflect.DeepEqual(got, wantLevels) {
+			t.Errorf("Expected levels %v, got %v", wantLevels, got)
+		}
+	})
+
+	t.Run("collides with unique levels", func(t *testing.T) {
+		s := newSeededTierService(t, ladderTestTiers, WithUniqueLevels(true))
+
+		if _, err := s.MoveTierLevel(context.Background(), "gold", 5, false); !errors.Is(err, models.ErrTierLevelConflict) {
+			t.Errorf("Expected ErrTierLevelConflict, got %v", err)
+		}
+		if got := tierLevels(t, s)["gold"]; got != 20 {
+			t.Errorf("Expected gold to stay at 20, got %d", got)
+		}
+	})
+}
+
+func TestMoveTierLevel_Errors(t *testing.T) {
+	s := newSeededTierService(t, ladderTestTiers, WithMaxLevel(20))
+
+	// Cascading would push gold past the maximum, so nothing is saved
+	if _, err := s.MoveTierLevel(context.Background(), "free", 20, true); !errors.Is(err, models.ErrTierLevelTooHigh) {
+		t.Errorf("Expected ErrTierLevelTooHigh, got %v", err)
+	}
+	if got := tierLevels(t, s)["free"]; got != 1 {
+		t.Errorf("Expected free to stay at 1, got %d", got)
+	}
+	if _, err := s.MoveTierLevel(context.Background(), "missing", 5, true); !errors.Is(err, models.ErrTierNotFound) {
+		t.Errorf("Expected ErrTierNotFound, got %v", err)
+	}
+}
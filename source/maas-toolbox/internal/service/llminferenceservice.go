@@ -15,34 +15,141 @@
 package service
 
 import (
+	"context"
 	"fmt"
+	"log"
 	"maas-toolbox/internal/models"
-	"maas-toolbox/internal/storage"
+	"maas-toolbox/internal/webhook"
+	"math"
+	"sort"
+	"sync"
+	"time"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
+// usageCacheTTL is how long a per-tier service count is reused before the
+// cluster-wide LLMInferenceService list is queried again
+const usageCacheTTL = 30 * time.Second
+
+// cachedCount holds a per-tier service count and when it was computed
+type cachedCount struct {
+	count     int
+	expiresAt time.Time
+}
+
+// LLMInferenceServiceStore reads and annotates LLMInferenceService resources.
+// storage.LLMInferenceServiceStorage implements it against the cluster.
+type LLMInferenceServiceStore interface {
+	ListLLMInferenceServices(ctx context.Context) ([]*unstructured.Unstructured, error)
+	GetLLMInferenceServicesByTier(ctx context.Context, tierName string) ([]*unstructured.Unstructured, error)
+	GetLLMInferenceServicesByTierPage(ctx context.Context, tierName string, limit int64, continueToken string) ([]*unstructured.Unstructured, string, error)
+	GetLLMInferenceService(ctx context.Context, namespace, name string) (*unstructured.Unstructured, error)
+	SetLLMInferenceServiceTiers(ctx context.Context, service *unstructured.Unstructured, tiers []string) (*unstructured.Unstructured, error)
+	RemoveLLMInferenceServiceAnnotation(ctx context.Context, namespace, name, tierName string) (*unstructured.Unstructured, error)
+	SetLLMInferenceServiceAnnotations(ctx context.Context, service *unstructured.Unstructured, set map[string]string, remove []string) (*unstructured.Unstructured, error)
+	CreateLLMInferenceService(ctx context.Context, namespace, name, modelURI string, tiers []string) (*unstructured.Unstructured, error)
+}
+
 // LLMInferenceServiceService provides business logic for LLMInferenceService operations
 type LLMInferenceServiceService struct {
-	tierService *TierService
+	tierService      *TierService
+	store            LLMInferenceServiceStore
+	defaultNamespace string
+
+	usageMu    sync.Mutex
+	usageCache map[string]cachedCount
+}
+
+// LLMInferenceServiceOption configures optional LLMInferenceServiceService behavior
+type LLMInferenceServiceOption func(*LLMInferenceServiceService)
+
+// WithDefaultNamespace sets the namespace used by annotate and remove-tier requests that
+// omit one. An explicit namespace always wins; empty (the default) keeps namespace required.
+func WithDefaultNamespace(namespace string) LLMInferenceServiceOption {
+	return func(s *LLMInferenceServiceService) {
+		s.defaultNamespace = namespace
+	}
 }
 
 // NewLLMInferenceServiceService creates a new LLMInferenceServiceService instance
-func NewLLMInferenceServiceService(tierService *TierService) *LLMInferenceServiceService {
-	return &LLMInferenceServiceService{
+func NewLLMInferenceServiceService(tierService *TierService, store LLMInferenceServiceStore, opts ...LLMInferenceServiceOption) *LLMInferenceServiceService {
+	s := &LLMInferenceServiceService{
 		tierService: tierService,
+		store:       store,
+		usageCache:  make(map[string]cachedCount),
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	return s
+}
+
+// resolveNamespace returns namespace, or the configured default when it is empty
+func (s *LLMInferenceServiceService) resolveNamespace(namespace string) string {
+	if namespace == "" {
+		return s.defaultNamespace
+	}
+	return namespace
 }
 
 // GetLLMInferenceServicesByTier returns all LLMInferenceService instances that have the specified tier
-func (s *LLMInferenceServiceService) GetLLMInferenceServicesByTier(tierName string) ([]models.LLMInferenceService, error) {
+func (s *LLMInferenceServiceService) GetLLMInferenceServicesByTier(ctx context.Context, tierName string) ([]models.LLMInferenceService, error) {
 	// Get unstructured objects from storage
-	unstructuredServices, err := storage.GetLLMInferenceServicesByTier(tierName)
+	unstructuredServices, err := s.store.GetLLMInferenceServicesByTier(ctx, tierName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get LLMInferenceServices by tier: %w", err)
 	}
 
-	// Convert to model objects
+	return convertUnstructuredServices(unstructuredServices), nil
+}
+
+// SummarizeLLMInferenceServicesByTier counts the services with the specified tier per namespace,
+// ordered by count descending and then by namespace name
+func (s *LLMInferenceServiceService) SummarizeLLMInferenceServicesByTier(ctx context.Context, tierName string) (*models.TierServicesSummary, error) {
+	services, err := s.GetLLMInferenceServicesByTier(ctx, tierName)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int)
+	for _, service := range services {
+		counts[service.Namespace]++
+	}
+	summary := &models.TierServicesSummary{
+		Tier:       tierName,
+		Total:      len(services),
+		Namespaces: make([]models.NamespaceCount, 0, len(counts)),
+	}
+	for namespace, count := range counts {
+		summary.Namespaces = append(summary.Namespaces, models.NamespaceCount{Namespace: namespace, Count: count})
+	}
+	sort.Slice(summary.Namespaces, func(i, j int) bool {
+		if summary.Namespaces[i].Count != summary.Namespaces[j].Count {
+			return summary.Namespaces[i].Count > summary.Namespaces[j].Count
+		}
+		return summary.Namespaces[i].Namespace < summary.Namespaces[j].Namespace
+	})
+
+	return summary, nil
+}
+
+// GetLLMInferenceServicesByTierPage returns the services with the specified tier from one page of the
+// cluster-wide listing. A page may contain fewer than limit services because filtering follows the list.
+func (s *LLMInferenceServiceService) GetLLMInferenceServicesByTierPage(ctx context.Context, tierName string, limit int64, continueToken string) (*models.LLMInferenceServicePage, error) {
+	unstructuredServices, next, err := s.store.GetLLMInferenceServicesByTierPage(ctx, tierName, limit, continueToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LLMInferenceServices by tier: %w", err)
+	}
+
+	return &models.LLMInferenceServicePage{
+		Items:    convertUnstructuredServices(unstructuredServices),
+		Continue: next,
+	}, nil
+}
+
+// convertUnstructuredServices converts unstructured objects to model objects, skipping any that fail
+func convertUnstructuredServices(unstructuredServices []*unstructured.Unstructured) []models.LLMInferenceService {
 	services := make([]models.LLMInferenceService, 0, len(unstructuredServices))
 	for _, us := range unstructuredServices {
 		service, err := convertUnstructuredToLLMInferenceService(us)
@@ -52,14 +159,35 @@ func (s *LLMInferenceServiceService) GetLLMInferenceServicesByTier(tierName stri
 		}
 		services = append(services, *service)
 	}
+	return services
+}
+
+// CountLLMInferenceServicesByTier returns the number of LLMInferenceService instances that have the specified tier.
+// Counts are cached briefly because each miss requires a cluster-wide list.
+func (s *LLMInferenceServiceService) CountLLMInferenceServicesByTier(ctx context.Context, tierName string) (int, error) {
+	s.usageMu.Lock()
+	cached, ok := s.usageCache[tierName]
+	s.usageMu.Unlock()
+	if ok && time.Now().Before(cached.expiresAt) {
+		return cached.count, nil
+	}
 
-	return services, nil
+	services, err := s.GetLLMInferenceServicesByTier(ctx, tierName)
+	if err != nil {
+		return 0, err
+	}
+
+	s.usageMu.Lock()
+	s.usageCache[tierName] = cachedCount{count: len(services), expiresAt: time.Now().Add(usageCacheTTL)}
+	s.usageMu.Unlock()
+
+	return len(services), nil
 }
 
 // GetLLMInferenceServicesByGroup returns all LLMInferenceService instances associated with the specified group
-func (s *LLMInferenceServiceService) GetLLMInferenceServicesByGroup(groupName string) ([]models.LLMInferenceService, error) {
+func (s *LLMInferenceServiceService) GetLLMInferenceServicesByGroup(ctx context.Context, groupName string) ([]models.LLMInferenceService, error) {
 	// Get tiers for the group
-	tiers, err := s.tierService.GetTiersByGroup(groupName)
+	tiers, err := s.tierService.GetTiersByGroup(ctx, groupName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get tiers by group: %w", err)
 	}
@@ -68,7 +196,7 @@ func (s *LLMInferenceServiceService) GetLLMInferenceServicesByGroup(groupName st
 	serviceMap := make(map[string]models.LLMInferenceService) // Use map to deduplicate by name+namespace
 
 	for _, tier := range tiers {
-		services, err := s.GetLLMInferenceServicesByTier(tier.Name)
+		services, err := s.GetLLMInferenceServicesByTier(ctx, tier.Name)
 		if err != nil {
 			// Log error but continue with other tiers
 			continue
@@ -90,6 +218,850 @@ func (s *LLMInferenceServiceService) GetLLMInferenceServicesByGroup(groupName st
 	return services, nil
 }
 
+// GetGroupImpact reports the tiers containing groupName, exactly or through a wildcard pattern,
+// and the distinct LLMInferenceServices annotated with any of them: what the group would lose if
+// it were removed from those tiers. Unlike GetLLMInferenceServicesByGroup, a tier whose services
+// cannot be listed fails the report rather than understating it.
+func (s *LLMInferenceServiceService) GetGroupImpact(ctx context.Context, groupName string) (*models.GroupImpact, error) {
+	tiers, err := s.tierService.GetTiersByGroup(ctx, groupName)
+	if err != nil {
+		return nil, err
+	}
+
+	impact := &models.GroupImpact{
+		Group:     groupName,
+		TierCount: len(tiers),
+		Tiers:     make([]models.GroupImpactTier, 0, len(tiers)),
+		Services:  []models.GroupImpactService{},
+	}
+	affected := make(map[string]*models.GroupImpactService) // Keyed by namespace/name
+	for _, tier := range tiers {
+		services, err := s.GetLLMInferenceServicesByTier(ctx, tier.Name)
+		if err != nil {
+			return nil, err
+		}
+		impact.Tiers = append(impact.Tiers, models.GroupImpactTier{Name: tier.Name, Level: tier.Level, ServiceCount: len(services)})
+
+		for _, service := range services {
+			key := fmt.Sprintf("%s/%s", service.Namespace, service.Name)
+			if affected[key] == nil {
+				affected[key] = &models.GroupImpactService{Namespace: service.Namespace, Name: service.Name}
+			}
+			affected[key].Tiers = append(affected[key].Tiers, tier.Name)
+		}
+	}
+
+	for _, service := range affected {
+		sort.Strings(service.Tiers)
+		impact.Services = append(impact.Services, *service)
+	}
+	sort.Slice(impact.Services, func(i, j int) bool {
+		if impact.Services[i].Namespace != impact.Services[j].Namespace {
+			return impact.Services[i].Namespace < impact.Services[j].Namespace
+		}
+		return impact.Services[i].Name < impact.Services[j].Name
+	})
+	sort.SliceStable(impact.Tiers, func(i, j int) bool {
+		if impact.Tiers[i].Level != impact.Tiers[j].Level {
+			return impact.Tiers[i].Level > impact.Tiers[j].Level
+		}
+		return impact.Tiers[i].Name < impact.Tiers[j].Name
+	})
+	impact.ServiceCount = len(impact.Services)
+
+	return impact, nil
+}
+
+// GetLLMInferenceService returns one LLMInferenceService, reporting for each tier in its
+// annotation whether that tier is currently defined. An unparseable annotation yields
+// ErrInvalidTierAnnotation.
+func (s *LLMInferenceServiceService) GetLLMInferenceService(ctx context.Context, namespace, name string) (*models.LLMInferenceServiceDetail, error) {
+	ref := models.LLMInferenceServiceRef{Namespace: namespace, Name: name}
+	if err := ref.Validate(); err != nil {
+		return nil, err
+	}
+
+	obj, err := s.store.GetLLMInferenceService(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	tiers, err := tiersFromUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	service, err := convertUnstructuredToLLMInferenceService(obj)
+	if err != nil {
+		return nil, err
+	}
+	service.Tiers = tiers
+
+	definedTiers, err := s.tierService.GetTiers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defined := make(map[string]bool, len(definedTiers))
+	for _, tier := range definedTiers {
+		defined[tier.Name] = true
+	}
+
+	detail := &models.LLMInferenceServiceDetail{
+		LLMInferenceService: *service,
+		ResolvedTiers:       make([]models.ResolvedTier, 0, len(tiers)),
+	}
+	for _, tier := range tiers {
+		detail.ResolvedTiers = append(detail.ResolvedTiers, models.ResolvedTier{Name: tier, Defined: defined[tier]})
+	}
+	return detail, nil
+}
+
+// GetModelsForUser resolves the LLMInferenceServices a user can access: the user's OpenShift groups
+// are resolved into tiers as in GetTiersForUser, and every service annotated with one of those tiers
+// is returned once, with the tiers that grant it ordered by level (highest first).
+func (s *LLMInferenceServiceService) GetModelsForUser(ctx context.Context, username string) (*models.UserModelsResponse, error) {
+	userTiers, err := s.tierService.GetTiersForUser(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.UserModelsResponse{
+		User:     userTiers.User,
+		Groups:   userTiers.Groups,
+		Tiers:    make([]string, 0, len(userTiers.Matches)),
+		Services: []models.UserModel{},
+	}
+	index := make(map[string]int) // namespace/name -> position in response.Services
+
+	// Matches are ordered by level, so GrantedBy is too
+	for _, match := range userTiers.Matches {
+		response.Tiers = append(response.Tiers, match.Tier.Name)
+
+		services, err := s.GetLLMInferenceServicesByTier(ctx, match.Tier.Name)
+		if err != nil {
+			return nil, err
+		}
+		for _, service := range services {
+			key := fmt.Sprintf("%s/%s", service.Namespace, service.Name)
+			if i, ok := index[key]; ok {
+				response.Services[i].GrantedBy = append(response.Services[i].GrantedBy, match.Tier.Name)
+				continue
+			}
+			index[key] = len(response.Services)
+			response.Services = append(response.Services, models.UserModel{LLMInferenceService: service, GrantedBy: []string{match.Tier.Name}})
+		}
+	}
+
+	return response, nil
+}
+
+// AnnotateLLMInferenceServiceWithTier adds a tier to an LLMInferenceService's tiers annotation.
+// The tier must exist in the tier configuration. Adding a tier that is already present is a no-op.
+// An omitted namespace resolves to the configured default namespace, if any.
+// With dryRun the tier and service are still checked but the service is not updated.
+// Returns the resulting list of tiers on the service.
+func (s *LLMInferenceServiceService) AnnotateLLMInferenceServiceWithTier(ctx context.Context, req *models.AnnotateRequest, dryRun bool) ([]string, error) {
+	req.Namespace = s.resolveNamespace(req.Namespace)
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Verify tier exists
+	if _, err := s.tierService.GetTier(ctx, req.Tier); err != nil {
+		return nil, err
+	}
+
+	return s.applyTierAnnotation(ctx, req.Namespace, req.Name, req.Tier, dryRun)
+}
+
+// CreateLLMInferenceService creates a minimal LLMInferenceService annotated with the requested tier.
+// The tier must exist in the tier configuration. An omitted namespace resolves to the configured
+// default namespace, if any.
+func (s *LLMInferenceServiceService) CreateLLMInferenceService(ctx context.Context, req *models.CreateLLMInferenceServiceRequest) (*models.LLMInferenceService, error) {
+	req.Namespace = s.resolveNamespace(req.Namespace)
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Verify tier exists
+	if _, err := s.tierService.GetTier(ctx, req.Tier); err != nil {
+		return nil, err
+	}
+
+	tiers := []string{req.Tier}
+	obj, err := s.store.CreateLLMInferenceService(ctx, req.Namespace, req.Name, req.ModelURI, tiers)
+	if err != nil {
+		return nil, err
+	}
+
+	// Usage counts for this tier are now stale
+	s.usageMu.Lock()
+	delete(s.usageCache, req.Tier)
+	s.usageMu.Unlock()
+
+	created, err := convertUnstructuredToLLMInferenceService(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	s.tierService.notifyAnnotation(webhook.ActionCreated, req.Namespace, req.Name, req.Tier, nil, tiers)
+	return created, nil
+}
+
+// BatchAnnotateLLMInferenceServices adds a tier to every target LLMInferenceService.
+// The tier is validated once up front; individual target failures are reported
+// in the results rather than aborting the batch.
+func (s *LLMInferenceServiceService) BatchAnnotateLLMInferenceServices(ctx context.Context, req *models.BatchAnnotateRequest) (*models.BatchAnnotateResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Verify tier exists once for the whole batch
+	if _, err := s.tierService.GetTier(ctx, req.Tier); err != nil {
+		return nil, err
+	}
+
+	response := &models.BatchAnnotateResponse{
+		Tier:    req.Tier,
+		Results: make([]models.AnnotateResult, 0, len(req.Targets)),
+	}
+
+	for _, target := range req.Targets {
+		result := models.AnnotateResult{Namespace: target.Namespace, Name: target.Name}
+
+		tiers, err := s.annotateTarget(ctx, target, req.Tier)
+		if err != nil {
+			result.Status = models.AnnotateStatusFailed
+			result.Error = err.Error()
+			response.Failed++
+		} else {
+			result.Status = models.AnnotateStatusSucceeded
+			result.Tiers = tiers
+			response.Succeeded++
+		}
+		response.Results = append(response.Results, result)
+	}
+
+	return response, nil
+}
+
+// RemoveTierFromLLMInferenceService removes a tier from an LLMInferenceService's tiers annotation.
+// The tier does not need to exist in the tier configuration, so references to deleted tiers can be cleaned up.
+// An omitted namespace resolves to the configured default namespace, if any.
+// Returns the remaining list of tiers on the service.
+func (s *LLMInferenceServiceService) RemoveTierFromLLMInferenceService(ctx context.Context, req *models.RemoveTierRequest) ([]string, error) {
+	req.Namespace = s.resolveNamespace(req.Namespace)
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.store.RemoveLLMInferenceServiceAnnotation(ctx, req.Namespace, req.Name, req.Tier)
+	if err != nil {
+		return nil, err
+	}
+
+	tiers, err := tiersFromUnstructured(updated)
+	if err != nil {
+		return nil, err
+	}
+
+	// Usage counts for this tier are now stale
+	s.usageMu.Lock()
+	delete(s.usageCache, req.Tier)
+	s.usageMu.Unlock()
+
+	s.tierService.notifyAnnotation(webhook.ActionTierRemoved, req.Namespace, req.Name, req.Tier, nil, tiers)
+	return tiers, nil
+}
+
+// ClearTiersFromLLMInferenceService sets an LLMInferenceService's tiers annotation to an empty
+// list in a single update, removing every tier at once. An omitted namespace resolves to the
+// configured default namespace, if any. A tiers annotation that cannot be parsed is cleared too,
+// and reported as having removed no tiers.
+func (s *LLMInferenceServiceService) ClearTiersFromLLMInferenceService(ctx context.Context, ref *models.LLMInferenceServiceRef) (*models.ClearTiersResponse, error) {
+	ref.Namespace = s.resolveNamespace(ref.Namespace)
+	if err := ref.Validate(); err != nil {
+		return nil, err
+	}
+
+	obj, err := s.store.GetLLMInferenceService(ctx, ref.Namespace, ref.Name)
+	if err != nil {
+		return nil, err
+	}
+	removed, err := tiersFromUnstructured(obj)
+	if err != nil {
+		log.Printf("Clearing unparseable tiers annotation on LLMInferenceService %s/%s: %v", ref.Namespace, ref.Name, err)
+		removed = []string{}
+	}
+
+	// Update the service as read, so a tier added concurrently fails instead of being cleared unreported
+	updated, err := s.store.SetLLMInferenceServiceTiers(ctx, obj, []string{})
+	if err != nil {
+		return nil, err
+	}
+	tiers, err := tiersFromUnstructured(updated)
+	if err != nil {
+		return nil, err
+	}
+
+	// Usage counts for the removed tiers are now stale
+	s.usageMu.Lock()
+	for _, tier := range removed {
+		delete(s.usageCache, tier)
+	}
+	s.usageMu.Unlock()
+
+	s.tierService.notifyAnnotation(webhook.ActionTiersCleared, ref.Namespace, ref.Name, "", removed, tiers)
+	return &models.ClearTiersResponse{Namespace: ref.Namespace, Name: ref.Name, Removed: removed, Tiers: tiers}, nil
+}
+
+// AnnotateLLMInferenceServiceWithAllTiers adds every active tier in the tier configuration to an
+// LLMInferenceService's tiers annotation in a single update. Tiers the service already holds keep
+// their position and the rest are appended in configuration order; a service that already holds
+// every tier is not updated. An omitted namespace resolves to the configured default namespace, if any.
+func (s *LLMInferenceServiceService) AnnotateLLMInferenceServiceWithAllTiers(ctx context.Context, ref *models.LLMInferenceServiceRef) (*models.AnnotateAllTiersResponse, error) {
+	ref.Namespace = s.resolveNamespace(ref.Namespace)
+	if err := ref.Validate(); err != nil {
+		return nil, err
+	}
+
+	configured, err := s.tierService.GetTiers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	obj, err := s.store.GetLLMInferenceService(ctx, ref.Namespace, ref.Name)
+	if err != nil {
+		return nil, err
+	}
+	before, err := tiersFromUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	present := make(map[string]bool, len(before))
+	for _, tier := range before {
+		present[tier] = true
+	}
+	tiers := append([]string{}, before...)
+	added := []string{}
+	for _, tier := range configured {
+		if !present[tier.Name] {
+			present[tier.Name] = true
+			tiers = append(tiers, tier.Name)
+			added = append(added, tier.Name)
+		}
+	}
+
+	response := &models.AnnotateAllTiersResponse{Namespace: ref.Namespace, Name: ref.Name, Added: added, Tiers: tiers}
+	if len(added) == 0 {
+		// Already annotated with every tier, nothing to update
+		return response, nil
+	}
+	// Update the service as read, so a concurrent annotation change fails instead of being lost
+	if _, err := s.store.SetLLMInferenceServiceTiers(ctx, obj, tiers); err != nil {
+		return nil, err
+	}
+
+	// Usage counts for the added tiers are now stale
+	s.usageMu.Lock()
+	for _, tier := range added {
+		delete(s.usageCache, tier)
+	}
+	s.usageMu.Unlock()
+
+	s.tierService.notifyAnnotation(webhook.ActionAllTiersAnnotated, ref.Namespace, ref.Name, "", before, tiers)
+	return response, nil
+}
+
+// UnlinkTier removes a tier from every LLMInferenceService annotated with it.
+// With dryRun set, the matching services are reported but not modified.
+// Individual failures are reported per service and do not abort the operation.
+func (s *LLMInferenceServiceService) UnlinkTier(ctx context.Context, tierName string, dryRun bool) (*models.UnlinkTierResponse, error) {
+	services, err := s.GetLLMInferenceServicesByTier(ctx, tierName)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.UnlinkTierResponse{
+		Tier:    tierName,
+		DryRun:  dryRun,
+		Matched: len(services),
+		Results: make([]models.AnnotateResult, 0, len(services)),
+	}
+
+	for _, service := range services {
+		result := models.AnnotateResult{Namespace: service.Namespace, Name: service.Name}
+
+		if dryRun {
+			result.Status = models.AnnotateStatusDryRun
+			result.Tiers = withoutTier(service.Tiers, tierName)
+			response.Results = append(response.Results, result)
+			continue
+		}
+
+		updated, err := s.store.RemoveLLMInferenceServiceAnnotation(ctx, service.Namespace, service.Name, tierName)
+		if err == nil {
+			result.Tiers, err = tiersFromUnstructured(updated)
+		}
+		if err != nil {
+			result.Status = models.AnnotateStatusFailed
+			result.Error = err.Error()
+			response.Failed++
+		} else {
+			result.Status = models.AnnotateStatusSucceeded
+			response.Unlinked++
+			s.tierService.notifyAnnotation(webhook.ActionTierRemoved, service.Namespace, service.Name, tierName, service.Tiers, result.Tiers)
+		}
+		response.Results = append(response.Results, result)
+	}
+
+	// Usage counts for this tier are now stale
+	s.usageMu.Lock()
+	delete(s.usageCache, tierName)
+	s.usageMu.Unlock()
+
+	return response, nil
+}
+
+// PruneTiers deletes the active tiers matching criteria: tiers with no groups, tiers no
+// LLMInferenceService is annotated with, or (PruneBoth) tiers that are both.
+// With dryRun set, the matching tiers are reported but not deleted. The matching tiers are
+// deleted in a single save through DeleteTiers, so either all of them are pruned or none are,
+// and they are soft-deleted when soft delete is enabled.
+func (s *LLMInferenceServiceService) PruneTiers(ctx context.Context, criteria models.PruneCriteria, dryRun bool) (*models.PruneTiersResponse, error) {
+	tiers, err := s.tierService.GetTiers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// A service whose annotation cannot be parsed may reference any tier, so nothing is pruned
+	referenced := make(map[string]bool)
+	if criteria != models.PruneEmpty {
+		services, err := s.store.ListLLMInferenceServices(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list LLMInferenceServices: %w", err)
+		}
+		for _, service := range services {
+			serviceTiers, err := tiersFromUnstructured(service)
+			if err != nil {
+				return nil, fmt.Errorf("LLMInferenceService %s/%s: %w", service.GetNamespace(), service.GetName(), err)
+			}
+			for _, tier := range serviceTiers {
+				referenced[tier] = true
+			}
+		}
+	}
+
+	matched := []string{}
+	for _, tier := range tiers {
+		empty := len(tier.Groups) == 0
+		unreferenced := !referenced[tier.Name]
+
+		var prune bool
+		switch criteria {
+		case models.PruneEmpty:
+			prune = empty
+		case models.PruneUnreferenced:
+			prune = unreferenced
+		default:
+			prune = empty && unreferenced
+		}
+		if prune {
+			matched = append(matched, tier.Name)
+		}
+	}
+
+	response := &models.PruneTiersResponse{Criteria: criteria, DryRun: dryRun, Pruned: matched}
+	if dryRun || len(matched) == 0 {
+		return response, nil
+	}
+
+	deleted, err := s.tierService.DeleteTiers(ctx, matched, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prune tiers %v: %w", matched, err)
+	}
+	// A tier deleted by another writer since it was listed was not pruned here
+	response.Pruned = []string{}
+	for _, result := range deleted.Results {
+		if result.Status == models.TierStatusDeleted {
+			response.Pruned = append(response.Pruned, result.Name)
+		}
+	}
+	return response, nil
+}
+
+// DeleteTiers deletes the tiers named in req in a single save. Unless force is set, tiers
+// annotated on any LLMInferenceService are kept and reported as referenced with their service
+// counts. A service whose annotation cannot be parsed may reference any tier, so without force
+// nothing is deleted.
+func (s *LLMInferenceServiceService) DeleteTiers(ctx context.Context, req *models.BatchDeleteTiersRequest, force bool) (*models.BatchDeleteTiersResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	var referenced map[string]int
+	if !force {
+		services, err := s.store.ListLLMInferenceServices(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list LLMInferenceServices: %w", err)
+		}
+		referenced = make(map[string]int)
+		for _, service := range services {
+			serviceTiers, err := tiersFromUnstructured(service)
+			if err != nil {
+				return nil, fmt.Errorf("LLMInferenceService %s/%s: %w", service.GetNamespace(), service.GetName(), err)
+			}
+			for _, tier := range serviceTiers {
+				referenced[tier]++
+			}
+		}
+	}
+
+	response, err := s.tierService.DeleteTiers(ctx, req.Names, referenced)
+	if err != nil {
+		return nil, err
+	}
+	response.Force = force
+	return response, nil
+}
+
+// ReconcileTiers removes tier references that are missing from the tier configuration from
+// every LLMInferenceService. Soft-deleted tiers are still configured and are kept, so a restore
+// finds its services intact. Each orphaned tier is removed separately and a failing service does
+// not stop the rest; services with an invalid tiers annotation are skipped and counted. With
+// checkAccess set, services whose remaining tiers grant no group access are reported as
+// warnings. With dryRun set, the orphaned tiers are reported but nothing is updated.
+func (s *LLMInferenceServiceService) ReconcileTiers(ctx context.Context, dryRun, checkAccess bool) (*models.ReconcileResponse, error) {
+	tiers, err := s.tierService.GetAllTiers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	configured := make(map[string]*models.Tier, len(tiers))
+	for i := range tiers {
+		configured[tiers[i].Name] = &tiers[i]
+	}
+
+	services, err := s.store.ListLLMInferenceServices(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list LLMInferenceServices: %w", err)
+	}
+
+	response := &models.ReconcileResponse{DryRun: dryRun, Scanned: len(services), Results: []models.ReconcileResult{}}
+	for _, service := range services {
+		namespace, name := service.GetNamespace(), service.GetName()
+		serviceTiers, err := tiersFromUnstructured(service)
+		if err != nil {
+			log.Printf("Skipping LLMInferenceService %s/%s in reconcile: %v", namespace, name, err)
+			response.Skipped++
+			continue
+		}
+
+		var orphaned, remaining []string
+		for _, tier := range serviceTiers {
+			if configured[tier] == nil {
+				orphaned = append(orphaned, tier)
+			} else {
+				remaining = append(remaining, tier)
+			}
+		}
+		if remaining == nil {
+			remaining = []string{}
+		}
+
+		if len(orphaned) > 0 {
+			response.Matched++
+			result := models.ReconcileResult{Namespace: namespace, Name: name, Orphaned: orphaned, Tiers: remaining}
+			if dryRun {
+				result.Status = models.AnnotateStatusDryRun
+			} else if err := s.removeOrphanedTiers(ctx, namespace, name, serviceTiers, orphaned); err != nil {
+				result.Status = models.AnnotateStatusFailed
+				result.Error = err.Error()
+				response.Failed++
+			} else {
+				result.Status = models.AnnotateStatusSucceeded
+				response.Reconciled++
+			}
+			response.Results = append(response.Results, result)
+		}
+
+		if checkAccess && len(serviceTiers) > 0 && !grantsGroupAccess(remaining, configured) {
+			response.Warnings = append(response.Warnings, models.ReconcileWarning{
+				Namespace: namespace,
+				Name:      name,
+				Tiers:     remaining,
+				Message:   "no annotated tier grants access to any group",
+			})
+		}
+	}
+
+	return response, nil
+}
+
+// removeOrphanedTiers removes each orphaned tier from the service's tiers annotation, notifying
+// subscribers of every removal. It stops at the first failure.
+func (s *LLMInferenceServiceService) removeOrphanedTiers(ctx context.Context, namespace, name string, tiers, orphaned []string) error {
+	for _, tier := range orphaned {
+		updated, err := s.store.RemoveLLMInferenceServiceAnnotation(ctx, namespace, name, tier)
+		if err != nil {
+			return fmt.Errorf("failed to remove tier %s: %w", tier, err)
+		}
+		after, err := tiersFromUnstructured(updated)
+		if err != nil {
+			return err
+		}
+		s.tierService.notifyAnnotation(webhook.ActionTierRemoved, namespace, name, tier, tiers, after)
+		tiers = after
+
+		s.usageMu.Lock()
+		delete(s.usageCache, tier)
+		s.usageMu.Unlock()
+	}
+	return nil
+}
+
+// grantsGroupAccess reports whether any of tiers is an active configured tier with at least one group
+func grantsGroupAccess(tiers []string, configured map[string]*models.Tier) bool {
+	for _, name := range tiers {
+		if tier := configured[name]; tier != nil && !tier.IsDeleted() && len(tier.Groups) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// MigrateAnnotations copies the tier list of every LLMInferenceService carrying req.OldKey to
+// req.NewKey. Services that already carry NewKey keep their tiers, followed by any old-key tiers
+// they lack. With req.RemoveOld set, OldKey is deleted once merged. Services are processed
+// independently and a failure on one, including a change since the list, does not stop the
+// rest; with dryRun set, the merged tiers are reported but nothing is updated.
+func (s *LLMInferenceServiceService) MigrateAnnotations(ctx context.Context, req *models.MigrateAnnotationsRequest, dryRun bool) (*models.MigrateAnnotationsResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	services, err := s.store.ListLLMInferenceServices(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list LLMInferenceServices: %w", err)
+	}
+
+	response := &models.MigrateAnnotationsResponse{
+		OldKey:  req.OldKey,
+		NewKey:  req.NewKey,
+		DryRun:  dryRun,
+		Results: []models.AnnotateResult{},
+	}
+	for _, service := range services {
+		annotations := service.GetAnnotations()
+		oldValue, ok := annotations[req.OldKey]
+		if !ok {
+			continue
+		}
+		response.Matched++
+
+		result := models.AnnotateResult{Namespace: service.GetNamespace(), Name: service.GetName()}
+		tiers, err := migrateTiers(oldValue, annotations[req.NewKey])
+		if err == nil && !dryRun {
+			err = s.writeMigratedTiers(ctx, service, req, tiers)
+		}
+		switch {
+		case err != nil:
+			result.Status = models.AnnotateStatusFailed
+			result.Error = err.Error()
+			response.Failed++
+		case dryRun:
+			result.Status = models.AnnotateStatusDryRun
+			result.Tiers = tiers
+		default:
+			result.Status = models.AnnotateStatusSucceeded
+			result.Tiers = tiers
+			response.Migrated++
+		}
+		response.Results = append(response.Results, result)
+	}
+
+	return response, nil
+}
+
+// migrateTiers merges the tiers of the old annotation value into the new one, keeping the
+// new key's order first and dropping duplicates
+func migrateTiers(oldValue, newValue string) ([]string, error) {
+	oldTiers, err := models.ParseTiersFromAnnotation(oldValue)
+	if err != nil {
+		return nil, fmt.Errorf("%w: old key: %v", models.ErrInvalidTierAnnotation, err)
+	}
+	newTiers, err := models.ParseTiersFromAnnotation(newValue)
+	if err != nil {
+		return nil, fmt.Errorf("%w: new key: %v", models.ErrInvalidTierAnnotation, err)
+	}
+
+	seen := make(map[string]bool, len(oldTiers)+len(newTiers))
+	merged := make([]string, 0, len(oldTiers)+len(newTiers))
+	for _, tier := range append(newTiers, oldTiers...) {
+		if !seen[tier] {
+			seen[tier] = true
+			merged = append(merged, tier)
+		}
+	}
+	return merged, nil
+}
+
+// writeMigratedTiers stores tiers under req.NewKey on service as listed, removing req.OldKey
+// when requested. A service changed since it was listed fails with ErrConcurrentModification
+// rather than losing the change.
+func (s *LLMInferenceServiceService) writeMigratedTiers(ctx context.Context, service *unstructured.Unstructured, req *models.MigrateAnnotationsRequest, tiers []string) error {
+	value, err := models.FormatTiersAnnotation(tiers)
+	if err != nil {
+		return err
+	}
+	var remove []string
+	if req.RemoveOld {
+		remove = []string{req.OldKey}
+	}
+	if _, err := s.store.SetLLMInferenceServiceAnnotations(ctx, service, map[string]string{req.NewKey: value}, remove); err != nil {
+		return err
+	}
+
+	// Usage counts for the migrated tiers are now stale when the tiers annotation changed
+	if req.NewKey == models.TierAnnotationKey || (req.RemoveOld && req.OldKey == models.TierAnnotationKey) {
+		s.usageMu.Lock()
+		for _, tier := range tiers {
+			delete(s.usageCache, tier)
+		}
+		s.usageMu.Unlock()
+	}
+	return nil
+}
+
+// TierReport returns one row per active tier with its level and group count, ordered by level
+// descending and then by name. With includeServices set, a single scan of all LLMInferenceServices
+// adds the number of services annotated with each tier and their namespaces; services with an
+// invalid tiers annotation are skipped and counted.
+func (s *LLMInferenceServiceService) TierReport(ctx context.Context, includeServices bool) (*models.TierReport, error) {
+	tiers, err := s.tierService.GetTiers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.TierReport{Tiers: make([]models.TierReportRow, 0, len(tiers)), ServicesIncluded: includeServices}
+	serviceCounts := make(map[string]int)
+	namespaces := make(map[string]map[string]bool)
+	if includeServices {
+		services, err := s.store.ListLLMInferenceServices(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list LLMInferenceServices: %w", err)
+		}
+		for _, service := range services {
+			serviceTiers, err := tiersFromUnstructured(service)
+			if err != nil {
+				log.Printf("Skipping LLMInferenceService %s/%s in tier report: %v", service.GetNamespace(), service.GetName(), err)
+				report.SkippedServices++
+				continue
+			}
+			for _, tier := range serviceTiers {
+				serviceCounts[tier]++
+				if namespaces[tier] == nil {
+					namespaces[tier] = make(map[string]bool)
+				}
+				namespaces[tier][service.GetNamespace()] = true
+			}
+		}
+	}
+
+	for _, tier := range tiers {
+		row := models.TierReportRow{Name: tier.Name, Level: tier.Level, GroupCount: len(tier.Groups)}
+		if includeServices {
+			count := serviceCounts[tier.Name]
+			row.ServiceCount = &count
+			row.Namespaces = make([]string, 0, len(namespaces[tier.Name]))
+			for namespace := range namespaces[tier.Name] {
+				row.Namespaces = append(row.Namespaces, namespace)
+			}
+			sort.Strings(row.Namespaces)
+		}
+		report.Tiers = append(report.Tiers, row)
+	}
+	sort.SliceStable(report.Tiers, func(i, j int) bool {
+		if report.Tiers[i].Level != report.Tiers[j].Level {
+			return report.Tiers[i].Level > report.Tiers[j].Level
+		}
+		return report.Tiers[i].Name < report.Tiers[j].Name
+	})
+
+	return report, nil
+}
+
+// withoutTier returns a copy of tiers with tierName removed
+func withoutTier(tiers []string, tierName string) []string {
+	remaining := make([]string, 0, len(tiers))
+	for _, tier := range tiers {
+		if tier != tierName {
+			remaining = append(remaining, tier)
+		}
+	}
+	return remaining
+}
+
+// annotateTarget validates a single batch target and applies the tier annotation to it
+func (s *LLMInferenceServiceService) annotateTarget(ctx context.Context, target models.LLMInferenceServiceRef, tierName string) ([]string, error) {
+	if err := target.Validate(); err != nil {
+		return nil, err
+	}
+	return s.applyTierAnnotation(ctx, target.Namespace, target.Name, tierName, false)
+}
+
+// applyTierAnnotation adds tierName to the service's tiers annotation without validating the tier.
+// Callers are responsible for verifying the tier exists. With dryRun the resulting tiers are
+// returned without updating the service.
+func (s *LLMInferenceServiceService) applyTierAnnotation(ctx context.Context, namespace, name, tierName string, dryRun bool) ([]string, error) {
+	obj, err := s.store.GetLLMInferenceService(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	tiers, err := tiersFromUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tier := range tiers {
+		if tier == tierName {
+			// Already annotated, nothing to update
+			return tiers, nil
+		}
+	}
+	before := append([]string(nil), tiers...)
+	tiers = append(tiers, tierName)
+	if dryRun {
+		return tiers, nil
+	}
+
+	// Update the service as read, so a concurrent annotation change fails instead of being lost
+	if _, err := s.store.SetLLMInferenceServiceTiers(ctx, obj, tiers); err != nil {
+		return nil, err
+	}
+
+	// Usage counts for this tier are now stale
+	s.usageMu.Lock()
+	delete(s.usageCache, tierName)
+	s.usageMu.Unlock()
+
+	s.tierService.notifyAnnotation(webhook.ActionTierAnnotated, namespace, name, tierName, before, tiers)
+	return tiers, nil
+}
+
+// tiersFromUnstructured extracts the tier list from an LLMInferenceService's tiers annotation.
+// A missing annotation yields an empty list; an unparseable one yields ErrInvalidTierAnnotation.
+func tiersFromUnstructured(obj *unstructured.Unstructured) ([]string, error) {
+	annotationValue, exists := obj.GetAnnotations()[models.TierAnnotationKey]
+	if !exists {
+		return []string{}, nil
+	}
+
+	tiers, err := models.ParseTiersFromAnnotation(annotationValue)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", models.ErrInvalidTierAnnotation, err)
+	}
+	return tiers, nil
+}
+
 // convertUnstructuredToLLMInferenceService converts an unstructured object to LLMInferenceService model
 func convertUnstructuredToLLMInferenceService(obj *unstructured.Unstructured) (*models.LLMInferenceService, error) {
 	// Extract metadata
@@ -124,11 +1096,27 @@ func convertUnstructuredToLLMInferenceService(obj *unstructured.Unstructured) (*
 		spec = make(map[string]interface{})
 	}
 
-	return &models.LLMInferenceService{
+	service := &models.LLMInferenceService{
 		Name:      name,
 		Namespace: namespace,
 		Tiers:     tiers,
 		Spec:      spec,
-	}, nil
+	}
+	extractSpecFields(service, spec)
+	return service, nil
 }
 
+// extractSpecFields copies well-known spec fields into the typed fields of service.
+// Missing fields, fields of an unexpected type and out-of-range replicas leave the typed field empty.
+func extractSpecFields(service *models.LLMInferenceService, spec map[string]interface{}) {
+	if modelName, found, err := unstructured.NestedString(spec, "model", "name"); err == nil && found {
+		service.ModelName = modelName
+	}
+	if modelURI, found, err := unstructured.NestedString(spec, "model", "uri"); err == nil && found {
+		service.ModelURI = modelURI
+	}
+	if replicas, found, err := unstructured.NestedInt64(spec, "replicas"); err == nil && found && replicas >= 0 && replicas <= math.MaxInt32 {
+		count := int32(replicas)
+		service.Replicas = &count
+	}
+}
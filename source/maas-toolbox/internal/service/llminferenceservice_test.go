@@ -0,0 +1,1108 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maas-toolbox/internal/models"
+	"maas-toolbox/internal/storage"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// newTestLLMService builds an unstructured LLMInferenceService annotated with tiersAnnotation
+func newTestLLMService(namespace, name, tiersAnnotation string) *unstructured.Unstructured {
+	metadata := map[string]interface{}{"name": name, "namespace": namespace}
+	if tiersAnnotation != "" {
+		metadata["annotations"] = map[string]interface{}{models.TierAnnotationKey: tiersAnnotation}
+	}
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "serving.kserve.io/v1alpha1",
+		"kind":       "LLMInferenceService",
+		"metadata":   metadata,
+		"spec":       map[string]interface{}{"model": map[string]interface{}{"name": name}},
+	}}
+}
+
+// newTestLLMServiceService creates an LLMInferenceServiceService over seeded tiers and a fake
+// dynamic client holding the given services
+func newTestLLMServiceService(t *testing.T, services ...runtime.Object) *LLMInferenceServiceService {
+	t.Helper()
+	tierService := newSeededTierService(t, []models.Tier{
+		{Name: "free", Description: "Free", Level: 1, Groups: []string{"free-users"}},
+		{Name: "premium", Description: "Premium", Level: 10, Groups: []string{"premium-users"}},
+	})
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		{Group: "serving.kserve.io", Version: "v1alpha1", Resource: "llminferenceservices"}: "LLMInferenceServiceList",
+	}, services...)
+	return NewLLMInferenceServiceService(tierService, storage.NewLLMInferenceServiceStorage(dynamicClient))
+}
+
+func serviceNames(services []models.LLMInferenceService) []string {
+	names := make([]string, 0, len(services))
+	for _, service := range services {
+		names = append(names, service.Namespace+"/"+service.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestAnnotateLLMInferenceServiceWithTier(t *testing.T) {
+	s := newTestLLMServiceService(t, newTestLLMService("models", "llama", `["free"]`))
+	if count, err := s.CountLLMInferenceServicesByTier(context.Background(), "premium"); err != nil || count != 0 {
+		t.Fatalf("Expected 0 premium services before annotating, got %d, %v", count, err)
+	}
+
+	tiers, err := s.AnnotateLLMInferenceServiceWithTier(context.Background(), &models.AnnotateRequest{Namespace: "models", Name: "llama", Tier: "premium"}, false)
+	if err != nil {
+		t.Fatalf("AnnotateLLMInferenceServiceWithTier() error = %v", err)
+	}
+	if !reflect.DeepEqual(tiers, []string{"free", "premium"}) {
+		t.Errorf("Expected tiers [free premium], got %v", tiers)
+	}
+	// The cached usage count is invalidated
+	if count, err := s.CountLLMInferenceServicesByTier(context.Background(), "premium"); err != nil || count != 1 {
+		t.Errorf("Expected 1 premium service after annotating, got %d, %v", count, err)
+	}
+
+	// The annotation is persisted
+	services, err := s.GetLLMInferenceServicesByTier(context.Background(), "premium")
+	if err != nil {
+		t.Fatalf("GetLLMInferenceServicesByTier() error = %v", err)
+	}
+	if len(services) != 1 || services[0].Name != "llama" {
+		t.Errorf("Expected llama to carry premium, got %v", serviceNames(services))
+	}
+
+	// Annotating again is a no-op
+	tiers, err = s.AnnotateLLMInferenceServiceWithTier(context.Background(), &models.AnnotateRequest{Namespace: "models", Name: "llama", Tier: "premium"}, false)
+	if err != nil || !reflect.DeepEqual(tiers, []string{"free", "premium"}) {
+		t.Errorf("Expected idempotent annotate, got %v, %v", tiers, err)
+	}
+}
+
+func TestAnnotateLLMInferenceServiceWithTier_Errors(t *testing.T) {
+	s := newTestLLMServiceService(t, newTestLLMService("models", "llama", ""))
+
+	_, err := s.AnnotateLLMInferenceServiceWithTier(context.Background(), &models.AnnotateRequest{Namespace: "models", Name: "llama", Tier: "missing"}, false)
+	if !errors.Is(err, models.ErrTierNotFound) {
+		t.Errorf("Expected ErrTierNotFound, got %v", err)
+	}
+
+	_, err = s.AnnotateLLMInferenceServiceWithTier(context.Background(), &models.AnnotateRequest{Namespace: "models", Name: "missing", Tier: "free"}, false)
+	if !errors.Is(err, models.ErrLLMServiceNotFound) {
+		t.Errorf("Expected ErrLLMServiceNotFound, got %v", err)
+	}
+
+	_, err = s.AnnotateLLMInferenceServiceWithTier(context.Background(), &models.AnnotateRequest{Namespace: "models", Name: "llama", Tier: "Not_A_Tier"}, false)
+	if !errors.Is(err, models.ErrInvalidKubernetesName) {
+		t.Errorf("Expected ErrInvalidKubernetesName for a malformed tier, got %v", err)
+	}
+	_, err = s.RemoveTierFromLLMInferenceService(context.Background(), &models.RemoveTierRequest{Namespace: "models", Name: "llama", Tier: "-free"})
+	if !errors.Is(err, models.ErrInvalidKubernetesName) {
+		t.Errorf("Expected ErrInvalidKubernetesName for a malformed tier, got %v", err)
+	}
+}
+
+// newConcurrentlyUpdatedLLMServiceService returns a service over models/llama annotated with
+// tiers, which another writer changes to changedTiers right after the first read
+func newConcurrentlyUpdatedLLMServiceService(t *testing.T, tiers, changedTiers string) *LLMInferenceServiceService {
+	t.Helper()
+	tierService := newSeededTierService(t, []models.Tier{
+		{Name: "free", Description: "Free", Level: 1, Groups: []string{"free-users"}},
+		{Name: "premium", Description: "Premium", Level: 10, Groups: []string{"premium-users"}},
+	})
+	service := newTestLLMService("models", "llama", tiers)
+	service.SetResourceVersion("5")
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), service)
+	// The fake client ignores resourceVersion, so emulate the API server's optimistic
+	// concurrency check
+	serverVersion := "5"
+	dynamicClient.PrependReactor("get", "llminferenceservices", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if serverVersion == "5" {
+			serverVersion = "6"
+			return false, nil, nil
+		}
+		changed := newTestLLMService("models", "llama", changedTiers)
+		changed.SetResourceVersion(serverVersion)
+		return true, changed, nil
+	})
+	dynamicClient.PrependReactor("update", "llminferenceservices", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		obj := action.(k8stesting.UpdateAction).GetObject().(*unstructured.Unstructured)
+		if obj.GetResourceVersion() != serverVersion {
+			return true, nil, apierrors.NewConflict(schema.GroupResource{Group: "serving.kserve.io", Resource: "llminferenceservices"}, obj.GetName(), errors.New("the object has been modified"))
+		}
+		return false, nil, nil
+	})
+	return NewLLMInferenceServiceService(tierService, storage.NewLLMInferenceServiceStorage(dynamicClient))
+}
+
+func TestAnnotateLLMInferenceServiceWithTier_ConcurrentUpdate(t *testing.T) {
+	s := newConcurrentlyUpdatedLLMServiceService(t, `["free"]`, `["free","enterprise"]`)
+
+	_, err := s.AnnotateLLMInferenceServiceWithTier(context.Background(), &models.AnnotateRequest{Namespace: "models", Name: "llama", Tier: "premium"}, false)
+	if !errors.Is(err, models.ErrConcurrentModification) {
+		t.Errorf("Expected ErrConcurrentModification, got %v", err)
+	}
+}
+
+func TestRemoveTierFromLLMInferenceService(t *testing.T) {
+	s := newTestLLMServiceService(t, newTestLLMService("models", "llama", `["free","premium"]`))
+	if count, err := s.CountLLMInferenceServicesByTier(context.Background(), "free"); err != nil || count != 1 {
+		t.Fatalf("Expected 1 free service before the removal, got %d, %v", count, err)
+	}
+
+	tiers, err := s.RemoveTierFromLLMInferenceService(context.Background(), &models.RemoveTierRequest{Namespace: "models", Name: "llama", Tier: "free"})
+	if err != nil {
+		t.Fatalf("RemoveTierFromLLMInferenceService() error = %v", err)
+	}
+	if !reflect.DeepEqual(tiers, []string{"premium"}) {
+		t.Errorf("Expected tiers [premium], got %v", tiers)
+	}
+	// The cached usage count is invalidated
+	if count, err := s.CountLLMInferenceServicesByTier(context.Background(), "free"); err != nil || count != 0 {
+		t.Errorf("Expected 0 free services after the removal, got %d, %v", count, err)
+	}
+
+	_, err = s.RemoveTierFromLLMInferenceService(context.Background(), &models.RemoveTierRequest{Namespace: "models", Name: "llama", Tier: "free"})
+	if !errors.Is(err, models.ErrTierNotInAnnotation) {
+		t.Errorf("Expected ErrTierNotInAnnotation, got %v", err)
+	}
+}
+
+func TestAnnotateLLMInferenceServiceWithAllTiers(t *testing.T) {
+	s := newTestLLMServiceService(t,
+		newTestLLMService("models", "llama", `["premium","retired"]`),
+		newTestLLMService("models", "granite", ""),
+		newTestLLMService("models", "broken", `not-json`),
+	)
+	if count, err := s.CountLLMInferenceServicesByTier(context.Background(), "free"); err != nil || count != 0 {
+		t.Fatalf("Expected 0 free services before annotating, got %d, %v", count, err)
+	}
+
+	response, err := s.AnnotateLLMInferenceServiceWithAllTiers(context.Background(), &models.LLMInferenceServiceRef{Namespace: "models", Name: "llama"})
+	if err != nil {
+		t.Fatalf("AnnotateLLMInferenceServiceWithAllTiers() error = %v", err)
+	}
+	// Existing tiers keep their position and only missing tiers are appended
+	if !reflect.DeepEqual(response.Added, []string{"free"}) || !reflect.DeepEqual(response.Tiers, []string{"premium", "retired", "free"}) {
+		t.Errorf("Expected free to be added after premium and retired, got %+v", response)
+	}
+	obj, err := s.store.GetLLMInferenceService(context.Background(), "models", "llama")
+	if err != nil {
+		t.Fatalf("GetLLMInferenceService() error = %v", err)
+	}
+	if tiers, _ := tiersFromUnstructured(obj); !reflect.DeepEqual(tiers, response.Tiers) {
+		t.Errorf("Expected the annotation to hold %v, got %v", response.Tiers, tiers)
+	}
+	// The cached usage count of the added tier is invalidated
+	if count, err := s.CountLLMInferenceServicesByTier(context.Background(), "free"); err != nil || count != 1 {
+		t.Errorf("Expected 1 free service after annotating, got %d, %v", count, err)
+	}
+
+	// Annotating again adds nothing
+	if response, err := s.AnnotateLLMInferenceServiceWithAllTiers(context.Background(), &models.LLMInferenceServiceRef{Namespace: "models", Name: "llama"}); err != nil || len(response.Added) != 0 {
+		t.Errorf("Expected nothing to be added again, got %+v, %v", response, err)
+	}
+
+	response, err = s.AnnotateLLMInferenceServiceWithAllTiers(context.Background(), &models.LLMInferenceServiceRef{Namespace: "models", Name: "granite"})
+	if err != nil || !reflect.DeepEqual(response.Tiers, []string{"free", "premium"}) {
+		t.Errorf("Expected an unannotated service to get every tier, got %+v, %v", response, err)
+	}
+
+	if _, err := s.AnnotateLLMInferenceServiceWithAllTiers(context.Background(), &models.LLMInferenceServiceRef{Namespace: "models", Name: "broken"}); !errors.Is(err, models.ErrInvalidTierAnnotation) {
+		t.Errorf("Expected ErrInvalidTierAnnotation, got %v", err)
+	}
+	if _, err := s.AnnotateLLMInferenceServiceWithAllTiers(context.Background(), &models.LLMInferenceServiceRef{Namespace: "models", Name: "missing"}); !errors.Is(err, models.ErrLLMServiceNotFound) {
+		t.Errorf("Expected ErrLLMServiceNotFound, got %v", err)
+	}
+	if _, err := s.AnnotateLLMInferenceServiceWithAllTiers(context.Background(), &models.LLMInferenceServiceRef{Name: "llama"}); !errors.Is(err, models.ErrNamespaceRequired) {
+		t.Errorf("Expected ErrNamespaceRequired, got %v", err)
+	}
+}
+
+func TestClearTiersFromLLMInferenceService(t *testing.T) {
+	s := newTestLLMServiceService(t,
+		newTestLLMService("models", "llama", `["free","premium","retired"]`),
+		newTestLLMService("models", "broken", `not-json`),
+	)
+
+	if count, err := s.CountLLMInferenceServicesByTier(context.Background(), "free"); err != nil || count != 1 {
+		t.Fatalf("Expected 1 free service before clearing, got %d, %v", count, err)
+	}
+
+	response, err := s.ClearTiersFromLLMInferenceService(context.Background(), &models.LLMInferenceServiceRef{Namespace: "models", Name: "llama"})
+	if err != nil {
+		t.Fatalf("ClearTiersFromLLMInferenceService() error = %v", err)
+	}
+	if !reflect.DeepEqual(response.Removed, []string{"free", "premium", "retired"}) || response.Tiers == nil || len(response.Tiers) != 0 {
+		t.Errorf("Expected all three tiers removed and an empty list left, got %+v", response)
+	}
+	obj, err := s.store.GetLLMInferenceService(context.Background(), "models", "llama")
+	if err != nil {
+		t.Fatalf("GetLLMInferenceService() error = %v", err)
+	}
+	if annotation := obj.GetAnnotations()[models.TierAnnotationKey]; annotation != "[]" {
+		t.Errorf("Expected the tiers annotation to be [], got %q", annotation)
+	}
+	// The cached usage counts are invalidated
+	if count, err := s.CountLLMInferenceServicesByTier(context.Background(), "free"); err != nil || count != 0 {
+		t.Errorf("Expected 0 free services after clearing, got %d, %v", count, err)
+	}
+
+	// Clearing is idempotent, and also clears an unparseable annotation
+	if response, err := s.ClearTiersFromLLMInferenceService(context.Background(), &models.LLMInferenceServiceRef{Namespace: "models", Name: "llama"}); err != nil || len(response.Removed) != 0 {
+		t.Errorf("Expected clearing again to remove nothing, got %+v, %v", response, err)
+	}
+	if _, err := s.ClearTiersFromLLMInferenceService(context.Background(), &models.LLMInferenceServiceRef{Namespace: "models", Name: "broken"}); err != nil {
+		t.Errorf("Expected an unparseable annotation to be cleared, got %v", err)
+	}
+
+	if _, err := s.ClearTiersFromLLMInferenceService(context.Background(), &models.LLMInferenceServiceRef{Namespace: "models", Name: "missing"}); !errors.Is(err, models.ErrLLMServiceNotFound) {
+		t.Errorf("Expected ErrLLMServiceNotFound, got %v", err)
+	}
+	if _, err := s.ClearTiersFromLLMInferenceService(context.Background(), &models.LLMInferenceServiceRef{Namespace: "missing-namespace", Name: "llama"}); !errors.Is(err, models.ErrLLMServiceNotFound) {
+		t.Errorf("Expected ErrLLMServiceNotFound for a missing namespace, got %v", err)
+	}
+	if _, err := s.ClearTiersFromLLMInferenceService(context.Background(), &models.LLMInferenceServiceRef{Namespace: "models"}); !errors.Is(err, models.ErrLLMServiceNameRequired) {
+		t.Errorf("Expected ErrLLMServiceNameRequired, got %v", err)
+	}
+}
+
+func TestClearTiersFromLLMInferenceService_ConcurrentUpdate(t *testing.T) {
+	s := newConcurrentlyUpdatedLLMServiceService(t, `["free"]`, `["free","premium"]`)
+
+	_, err := s.ClearTiersFromLLMInferenceService(context.Background(), &models.LLMInferenceServiceRef{Namespace: "models", Name: "llama"})
+	if !errors.Is(err, models.ErrConcurrentModification) {
+		t.Errorf("Expected ErrConcurrentModification, got %v", err)
+	}
+}
+
+func TestAnnotateLLMInferenceServiceWithAllTiers_ConcurrentUpdate(t *testing.T) {
+	s := newConcurrentlyUpdatedLLMServiceService(t, `["free"]`, `["free","enterprise"]`)
+
+	_, err := s.AnnotateLLMInferenceServiceWithAllTiers(context.Background(), &models.LLMInferenceServiceRef{Namespace: "models", Name: "llama"})
+	if !errors.Is(err, models.ErrConcurrentModification) {
+		t.Errorf("Expected ErrConcurrentModification, got %v", err)
+	}
+}
+
+func TestAnnotateLLMInferenceServiceWithTier_DryRun(t *testing.T) {
+	s := newTestLLMServiceService(t, newTestLLMService("models", "llama", `["free"]`))
+
+	tiers, err := s.AnnotateLLMInferenceServiceWithTier(context.Background(), &models.AnnotateRequest{Namespace: "models", Name: "llama", Tier: "premium"}, true)
+	if err != nil {
+		t.Fatalf("AnnotateLLMInferenceServiceWithTier() error = %v", err)
+	}
+	if !reflect.DeepEqual(tiers, []string{"free", "premium"}) {
+		t.Errorf("Expected resulting tiers [free premium], got %v", tiers)
+	}
+
+	// The CR is not modified
+	detail, err := s.GetLLMInferenceService(context.Background(), "models", "llama")
+	if err != nil {
+		t.Fatalf("GetLLMInferenceService() error = %v", err)
+	}
+	if !reflect.DeepEqual(detail.Tiers, []string{"free"}) {
+		t.Errorf("Expected annotation to stay [free] under dry-run, got %v", detail.Tiers)
+	}
+
+	// The tier and service checks still run
+	_, err = s.AnnotateLLMInferenceServiceWithTier(context.Background(), &models.AnnotateRequest{Namespace: "models", Name: "llama", Tier: "missing"}, true)
+	if !errors.Is(err, models.ErrTierNotFound) {
+		t.Errorf("Expected ErrTierNotFound, got %v", err)
+	}
+	_, err = s.AnnotateLLMInferenceServiceWithTier(context.Background(), &models.AnnotateRequest{Namespace: "models", Name: "missing", Tier: "free"}, true)
+	if !errors.Is(err, models.ErrLLMServiceNotFound) {
+		t.Errorf("Expected ErrLLMServiceNotFound, got %v", err)
+	}
+}
+
+func TestDefaultNamespace(t *testing.T) {
+	// Without a default, namespace stays required
+	s := newTestLLMServiceService(t, newTestLLMService("models", "llama", `["free"]`))
+	_, err := s.AnnotateLLMInferenceServiceWithTier(context.Background(), &models.AnnotateRequest{Name: "llama", Tier: "premium"}, false)
+	if !errors.Is(err, models.ErrNamespaceRequired) {
+		t.Errorf("Expected ErrNamespaceRequired without a default, got %v", err)
+	}
+	_, err = s.RemoveTierFromLLMInferenceService(context.Background(), &models.RemoveTierRequest{Name: "llama", Tier: "free"})
+	if !errors.Is(err, models.ErrNamespaceRequired) {
+		t.Errorf("Expected ErrNamespaceRequired without a default, got %v", err)
+	}
+
+	// With a default, an omitted namespace resolves to it
+	s = newTestLLMServiceService(t,
+		newTestLLMService("models", "llama", `["free"]`),
+		newTestLLMService("other", "llama", `["free"]`),
+	)
+	WithDefaultNamespace("models")(s)
+
+	req := &models.AnnotateRequest{Name: "llama", Tier: "premium"}
+	tiers, err := s.AnnotateLLMInferenceServiceWithTier(context.Background(), req, false)
+	if err != nil {
+		t.Fatalf("AnnotateLLMInferenceServiceWithTier() error = %v", err)
+	}
+	if req.Namespace != "models" || !reflect.DeepEqual(tiers, []string{"free", "premium"}) {
+		t.Errorf("Expected models/llama annotated with [free premium], got %s with %v", req.Namespace, tiers)
+	}
+
+	// An explicit namespace wins over the default
+	tiers, err = s.RemoveTierFromLLMInferenceService(context.Background(), &models.RemoveTierRequest{Namespace: "other", Name: "llama", Tier: "free"})
+	if err != nil || len(tiers) != 0 {
+		t.Errorf("Expected free removed from other/llama, got %v, %v", tiers, err)
+	}
+	services, err := s.GetLLMInferenceServicesByTier(context.Background(), "free")
+	if err != nil {
+		t.Fatalf("GetLLMInferenceServicesByTier() error = %v", err)
+	}
+	if names := serviceNames(services); !reflect.DeepEqual(names, []string{"models/llama"}) {
+		t.Errorf("Expected only models/llama to keep free, got %v", names)
+	}
+
+	tiers, err = s.RemoveTierFromLLMInferenceService(context.Background(), &models.RemoveTierRequest{Name: "llama", Tier: "free"})
+	if err != nil || !reflect.DeepEqual(tiers, []string{"premium"}) {
+		t.Errorf("Expected free removed from models/llama, got %v, %v", tiers, err)
+	}
+}
+
+func TestGetLLMInferenceService(t *testing.T) {
+	s := newTestLLMServiceService(t,
+		newTestLLMService("models", "llama", `["premium","retired","free"]`),
+		newTestLLMService("models", "broken", `not-json`),
+	)
+
+	detail, err := s.GetLLMInferenceService(context.Background(), "models", "llama")
+	if err != nil {
+		t.Fatalf("GetLLMInferenceService() error = %v", err)
+	}
+	if detail.Namespace != "models" || detail.Name != "llama" || !reflect.DeepEqual(detail.Tiers, []string{"premium", "retired", "free"}) {
+		t.Errorf("Expected models/llama with its annotated tiers, got %+v", detail.LLMInferenceService)
+	}
+	want := []models.ResolvedTier{
+		{Name: "premium", Defined: true},
+		{Name: "retired", Defined: false},
+		{Name: "free", Defined: true},
+	}
+	if !reflect.DeepEqual(detail.ResolvedTiers, want) {
+		t.Errorf("ResolvedTiers = %+v, want %+v", detail.ResolvedTiers, want)
+	}
+
+	if _, err := s.GetLLMInferenceService(context.Background(), "models", "missing"); !errors.Is(err, models.ErrLLMServiceNotFound) {
+		t.Errorf("Expected ErrLLMServiceNotFound, got %v", err)
+	}
+	if _, err := s.GetLLMInferenceService(context.Background(), "missing-namespace", "llama"); !errors.Is(err, models.ErrLLMServiceNotFound) {
+		t.Errorf("Expected ErrLLMServiceNotFound for a missing namespace, got %v", err)
+	}
+	if _, err := s.GetLLMInferenceService(context.Background(), "models", "broken"); !errors.Is(err, models.ErrInvalidTierAnnotation) {
+		t.Errorf("Expected ErrInvalidTierAnnotation, got %v", err)
+	}
+}
+
+func TestConvertUnstructuredToLLMInferenceService_SpecFields(t *testing.T) {
+	full := newTestLLMService("models", "llama", `["premium"]`)
+	full.Object["spec"] = map[string]interface{}{
+		"model":    map[string]interface{}{"name": "llama-3-1-8b", "uri": "hf://meta-llama/Llama-3.1-8B-Instruct"},
+		"replicas": int64(2),
+	}
+	service, err := convertUnstructuredToLLMInferenceService(full)
+	if err != nil {
+		t.Fatalf("convertUnstructuredToLLMInferenceService() error = %v", err)
+	}
+	if service.ModelName != "llama-3-1-8b" || service.ModelURI != "hf://meta-llama/Llama-3.1-8B-Instruct" {
+		t.Errorf("Expected model name and URI from the spec, got %+v", service)
+	}
+	if service.Replicas == nil || *service.Replicas != 2 {
+		t.Errorf("Expected 2 replicas, got %v", service.Replicas)
+	}
+	if _, ok := service.Spec["model"]; !ok {
+		t.Errorf("Expected the raw spec to be kept, got %v", service.Spec)
+	}
+
+	for name, spec := range map[string]interface{}{
+		"missing spec": nil,
+		"empty spec":   map[string]interface{}{},
+		"wrong types":  map[string]interface{}{"model": "llama", "replicas": "two"},
+	} {
+		obj := newTestLLMService("models", "bare", "")
+		if spec == nil {
+			delete(obj.Object, "spec")
+		} else {
+			obj.Object["spec"] = spec
+		}
+		service, err := convertUnstructuredToLLMInferenceService(obj)
+		if err != nil {
+			t.Fatalf("%s: convertUnstructuredToLLMInferenceService() error = %v", name, err)
+		}
+		if service.ModelName != "" || service.ModelURI != "" || service.Replicas != nil {
+			t.Errorf("%s: expected empty typed fields, got %+v", name, service)
+		}
+	}
+}
+
+func TestGetLLMInferenceServicesByTier_Filters(t *testing.T) {
+	s := newTestLLMServiceService(t,
+		newTestLLMService("models", "llama", `["free"]`),
+		newTestLLMService("models", "mistral", `["free","premium"]`),
+		newTestLLMService("other", "granite", `["premium"]`),
+		newTestLLMService("other", "unannotated", ""),
+	)
+
+	services, err := s.GetLLMInferenceServicesByTier(context.Background(), "free")
+	if err != nil {
+		t.Fatalf("GetLLMInferenceServicesByTier() error = %v", err)
+	}
+	if got, want := serviceNames(services), []string{"models/llama", "models/mistral"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetLLMInferenceServicesByTier(free) = %v, want %v", got, want)
+	}
+}
+
+func TestGetLLMInferenceServicesByGroup(t *testing.T) {
+	s := newTestLLMServiceService(t,
+		newTestLLMService("models", "llama", `["free"]`),
+		newTestLLMService("models", "mistral", `["free","premium"]`),
+		newTestLLMService("other", "granite", `["premium"]`),
+	)
+
+	services, err := s.GetLLMInferenceServicesByGroup(context.Background(), "premium-users")
+	if err != nil {
+		t.Fatalf("GetLLMInferenceServicesByGroup() error = %v", err)
+	}
+	if got, want := serviceNames(services), []string{"models/mistral", "other/granite"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetLLMInferenceServicesByGroup(premium-users) = %v, want %v", got, want)
+	}
+
+	services, err = s.GetLLMInferenceServicesByGroup(context.Background(), "nobody")
+	if err != nil || len(services) != 0 {
+		t.Errorf("Expected no services for unmatched group, got %v, %v", serviceNames(services), err)
+	}
+}
+
+func TestGetGroupImpact(t *testing.T) {
+	s := newTestLLMServiceService(t,
+		newTestLLMService("models", "llama", `["free"]`),
+		newTestLLMService("models", "mistral", `["free","premium"]`),
+		newTestLLMService("other", "granite", `["premium"]`),
+	)
+	addTestClusterGroup(t, s.tierService, "premium-users")
+	if err := s.tierService.AddGroup(context.Background(), "free", "premium-users"); err != nil {
+		t.Fatalf("AddGroup() error = %v", err)
+	}
+
+	impact, err := s.GetGroupImpact(context.Background(), "premium-users")
+	if err != nil {
+		t.Fatalf("GetGroupImpact() error = %v", err)
+	}
+	wantTiers := []models.GroupImpactTier{{Name: "premium", Level: 10, ServiceCount: 2}, {Name: "free", Level: 1, ServiceCount: 2}}
+	if impact.TierCount != 2 || !reflect.DeepEqual(impact.Tiers, wantTiers) {
+		t.Errorf("Expected tiers %+v, got %+v", wantTiers, impact.Tiers)
+	}
+	// mistral is in both tiers but counted once
+	wantServices := []models.GroupImpactService{
+		{Namespace: "models", Name: "llama", Tiers: []string{"free"}},
+		{Namespace: "models", Name: "mistral", Tiers: []string{"free", "premium"}},
+		{Namespace: "other", Name: "granite", Tiers: []string{"premium"}},
+	}
+	if impact.ServiceCount != 3 || !reflect.DeepEqual(impact.Services, wantServices) {
+		t.Errorf("Expected services %+v, got %+v", wantServices, impact.Services)
+	}
+
+	impact, err = s.GetGroupImpact(context.Background(), "nobody")
+	if err != nil || impact.TierCount != 0 || impact.ServiceCount != 0 || len(impact.Services) != 0 {
+		t.Errorf("Expected no impact for an unused group, got %+v, %v", impact, err)
+	}
+}
+
+func TestGetModelsForUser(t *testing.T) {
+	objects := []runtime.Object{
+		&unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "user.openshift.io/v1",
+			"kind":       "User",
+			"metadata":   map[string]interface{}{"name": "alice"},
+		}},
+		&unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "user.openshift.io/v1",
+			"kind":       "Group",
+			"metadata":   map[string]interface{}{"name": "premium-users"},
+			"users":      []interface{}{"alice"},
+		}},
+		newTestLLMService("models", "llama", `["free","premium"]`),
+		newTestLLMService("models", "mistral", `["premium"]`),
+		newTestLLMService("models", "granite", `["enterprise"]`),
+		newTestLLMService("models", "phi", ""),
+	}
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		{Group: "user.openshift.io", Version: "v1", Resource: "groups"}:                     "GroupList",
+		{Group: "user.openshift.io", Version: "v1", Resource: "users"}:                      "UserList",
+		{Group: "serving.kserve.io", Version: "v1alpha1", Resource: "llminferenceservices"}: "LLMInferenceServiceList",
+	}, objects...)
+	tierStorage := storage.NewK8sTierStorage(fake.NewSimpleClientset(), dynamicClient, "test", "tier-to-group-mapping")
+	if err := tierStorage.Save(context.Background(), &models.TierConfig{Tiers: resolveTestTiers}); err != nil {
+		t.Fatalf("Failed to seed tiers: %v", err)
+	}
+	s := NewLLMInferenceServiceService(NewTierService(tierStorage), storage.NewLLMInferenceServiceStorage(dynamicClient))
+
+	response, err := s.GetModelsForUser(context.Background(), "alice")
+	if err != nil {
+		t.Fatalf("GetModelsForUser() error = %v", err)
+	}
+	if !reflect.DeepEqual(response.Tiers, []string{"enterprise", "premium", "free"}) {
+		t.Errorf("Expected tiers [enterprise premium free], got %v", response.Tiers)
+	}
+
+	grantedBy := make(map[string][]string)
+	for _, service := range response.Services {
+		grantedBy[service.Name] = service.GrantedBy
+	}
+	want := map[string][]string{
+		"llama":   {"premium", "free"},
+		"mistral": {"premium"},
+		"granite": {"enterprise"},
+	}
+	if !reflect.DeepEqual(grantedBy, want) {
+		t.Errorf("Expected services %v, got %v", want, grantedBy)
+	}
+
+	if _, err := s.GetModelsForUser(context.Background(), "dave"); !errors.Is(err, models.ErrUserNotFound) {
+		t.Errorf("GetModelsForUser(dave) error = %v, want %v", err, models.ErrUserNotFound)
+	}
+}
+
+func TestPruneTiers(t *testing.T) {
+	tests := []struct {
+		criteria models.PruneCriteria
+		want     []string
+	}{
+		{models.PruneEmpty, []string{"empty-referenced", "empty-unreferenced"}},
+		{models.PruneUnreferenced, []string{"empty-unreferenced", "grouped-unreferenced"}},
+		{models.PruneBoth, []string{"empty-unreferenced"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.criteria), func(t *testing.T) {
+			s := newTestLLMServiceService(t, newTestLLMService("models", "llama", `["empty-referenced","grouped-referenced"]`))
+			if err := s.tierService.storage.Save(context.Background(), &models.TierConfig{Tiers: []models.Tier{
+				{Name: "empty-referenced", Description: "Empty, referenced"},
+				{Name: "empty-unreferenced", Description: "Empty, unreferenced"},
+				{Name: "grouped-referenced", Description: "Grouped, referenced", Groups: []string{"premium-users"}},
+				{Name: "grouped-unreferenced", Description: "Grouped, unreferenced", Groups: []string{"premium-users"}},
+			}}); err != nil {
+				t.Fatalf("Failed to seed tiers: %v", err)
+			}
+
+			// A dry run deletes nothing
+			response, err := s.PruneTiers(context.Background(), tt.criteria, true)
+			if err != nil {
+				t.Fatalf("PruneTiers() dry run error = %v", err)
+			}
+			if !reflect.DeepEqual(response.Pruned, tt.want) {
+				t.Errorf("Expected dry run to match %v, got %v", tt.want, response.Pruned)
+			}
+			if tiers, _ := s.tierService.GetTiers(context.Background()); len(tiers) != 4 {
+				t.Errorf("Expected dry run to keep all 4 tiers, got %d", len(tiers))
+			}
+
+			response, err = s.PruneTiers(context.Background(), tt.criteria, false)
+			if err != nil {
+				t.Fatalf("PruneTiers() error = %v", err)
+			}
+			if !reflect.DeepEqual(response.Pruned, tt.want) {
+				t.Errorf("Expected %v to be pruned, got %v", tt.want, response.Pruned)
+			}
+			if tiers, _ := s.tierService.GetTiers(context.Background()); len(tiers) != 4-len(tt.want) {
+				t.Errorf("Expected %d tiers to remain, got %d", 4-len(tt.want), len(tiers))
+			}
+		})
+	}
+}
+
+func TestDeleteTiers(t *testing.T) {
+	tests := []struct {
+		name  string
+		force bool
+		want  map[string]string
+		left  []string
+	}{
+		{"referenced tiers are kept", false, map[string]string{
+			"free": models.TierStatusDeleted, "premium": models.TierStatusReferenced, "missing": models.TierStatusNotFound,
+		}, []string{"premium"}},
+		{"force deletes referenced tiers", true, map[string]string{
+			"free": models.TierStatusDeleted, "premium": models.TierStatusDeleted, "missing": models.TierStatusNotFound,
+		}, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestLLMServiceService(t,
+				newTestLLMService("models", "llama", `["premium"]`),
+				newTestLLMService("models", "mistral", `["premium"]`),
+			)
+
+			req := &models.BatchDeleteTiersRequest{Names: []string{"free", "missing", "premium", "free"}}
+			response, err := s.DeleteTiers(context.Background(), req, tt.force)
+			if err != nil {
+				t.Fatalf("DeleteTiers() error = %v", err)
+			}
+			if response.Force != tt.force {
+				t.Errorf("Expected force %v, got %v", tt.force, response.Force)
+			}
+			if len(response.Results) != len(tt.want) {
+				t.Fatalf("Expected one result per distinct name, got %+v", response.Results)
+			}
+			for _, result := range response.Results {
+				if result.Status != tt.want[result.Name] {
+					t.Errorf("Expected %s to be %s, got %s", result.Name, tt.want[result.Name], result.Status)
+				}
+				if result.Status == models.TierStatusReferenced && result.ServiceCount != 2 {
+					t.Errorf("Expected %s to report 2 services, got %d", result.Name, result.ServiceCount)
+				}
+			}
+			if response.NotFound != 1 {
+				t.Errorf("Expected 1 not found, got %d", response.NotFound)
+			}
+
+			tiers, err := s.tierService.GetTiers(context.Background())
+			if err != nil {
+				t.Fatalf("GetTiers() error = %v", err)
+			}
+			left := []string{}
+			for _, tier := range tiers {
+				left = append(left, tier.Name)
+			}
+			if !reflect.DeepEqual(left, tt.left) {
+				t.Errorf("Expected remaining tiers %v, got %v", tt.left, left)
+			}
+		})
+	}
+}
+
+func TestDeleteTiers_Errors(t *testing.T) {
+	s := newTestLLMServiceService(t)
+
+	if _, err := s.DeleteTiers(context.Background(), &models.BatchDeleteTiersRequest{}, false); !errors.Is(err, models.ErrTierNamesRequired) {
+		t.Errorf("Expected %v, got %v", models.ErrTierNamesRequired, err)
+	}
+	if _, err := s.DeleteTiers(context.Background(), &models.BatchDeleteTiersRequest{Names: []string{"free", "Bad_Tier"}}, false); !errors.Is(err, models.ErrInvalidKubernetesName) {
+		t.Errorf("Expected %v, got %v", models.ErrInvalidKubernetesName, err)
+	}
+	if tiers, _ := s.tierService.GetTiers(context.Background()); len(tiers) != 2 {
+		t.Errorf("Expected invalid requests to delete nothing, got %d tiers", len(tiers))
+	}
+
+	s = newTestLLMServiceService(t, newTestLLMService("models", "llama", `not-json`))
+	if _, err := s.DeleteTiers(context.Background(), &models.BatchDeleteTiersRequest{Names: []string{"free"}}, false); !errors.Is(err, models.ErrInvalidTierAnnotation) {
+		t.Errorf("Expected %v, got %v", models.ErrInvalidTierAnnotation, err)
+	}
+	if _, err := s.DeleteTiers(context.Background(), &models.BatchDeleteTiersRequest{Names: []string{"free"}}, true); err != nil {
+		t.Errorf("Expected force to skip the annotation check, got %v", err)
+	}
+}
+
+func TestPruneTiers_InvalidAnnotation(t *testing.T) {
+	s := newTestLLMServiceService(t, newTestLLMService("models", "llama", `not-json`))
+
+	if _, err := s.PruneTiers(context.Background(), models.PruneBoth, false); !errors.Is(err, models.ErrInvalidTierAnnotation) {
+		t.Errorf("Expected %v, got %v", models.ErrInvalidTierAnnotation, err)
+	}
+	if tiers, _ := s.tierService.GetTiers(context.Background()); len(tiers) != 2 {
+		t.Errorf("Expected no tier to be pruned, got %d tiers", len(tiers))
+	}
+}
+
+func TestPruneTiers_SingleSave(t *testing.T) {
+	tierService, client := newTestTierServiceWithClient()
+	if err := tierService.storage.Save(context.Background(), &models.TierConfig{Tiers: []models.Tier{
+		{Name: "free", Description: "Free", Level: 1, Groups: []string{"free-users"}},
+		{Name: "empty-a", Description: "Empty"},
+		{Name: "empty-b", Description: "Empty"},
+	}}); err != nil {
+		t.Fatalf("Failed to seed tiers: %v", err)
+	}
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		{Group: "serving.kserve.io", Version: "v1alpha1", Resource: "llminferenceservices"}: "LLMInferenceServiceList",
+	})
+	s := NewLLMInferenceServiceService(tierService, storage.NewLLMInferenceServiceStorage(dynamicClient))
+
+	// Only the first write succeeds, so pruning tier by tier would stop part-way
+	var writes int
+	client.PrependReactor("*", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetVerb() == "get" {
+			return false, nil, nil
+		}
+		writes++
+		if writes > 1 {
+			return true, nil, errors.New("etcd unavailable")
+		}
+		return false, nil, nil
+	})
+
+	response, err := s.PruneTiers(context.Background(), models.PruneEmpty, false)
+	if err != nil {
+		t.Fatalf("PruneTiers() error = %v", err)
+	}
+	if writes != 1 {
+		t.Errorf("Expected both tiers to be pruned in a single write, got %d writes", writes)
+	}
+	if !reflect.DeepEqual(response.Pruned, []string{"empty-a", "empty-b"}) {
+		t.Errorf("Expected [empty-a empty-b] to be pruned, got %v", response.Pruned)
+	}
+	if tiers, _ := tierService.GetTiers(context.Background()); len(tiers) != 1 {
+		t.Errorf("Expected 1 tier to remain, got %d", len(tiers))
+	}
+}
+
+func TestReconcileTiers(t *testing.T) {
+	s := newTestLLMServiceService(t,
+		newTestLLMService("models", "llama", `["free","retired","gone"]`),
+		newTestLLMService("models", "granite", `["retired"]`),
+		newTestLLMService("models", "phi", `["empty","archived"]`),
+		newTestLLMService("models", "mistral", `["premium"]`),
+		newTestLLMService("models", "broken", `not-json`),
+		newTestLLMService("models", "bare", ""),
+	)
+	deletedAt := time.Now()
+	if err := s.tierService.storage.Save(context.Background(), &models.TierConfig{Tiers: []models.Tier{
+		{Name: "free", Description: "Free", Level: 1, Groups: []string{"free-users"}},
+		{Name: "premium", Description: "Premium", Level: 10, Groups: []string{"premium-users"}},
+		{Name: "empty", Description: "No groups", Level: 2},
+		{Name: "archived", Description: "Soft-deleted", Level: 3, Groups: []string{"free-users"}, DeletedAt: &deletedAt},
+	}}); err != nil {
+		t.Fatalf("Failed to seed tiers: %v", err)
+	}
+
+	orphans := func(response *models.ReconcileResponse) map[string][]string {
+		found := make(map[string][]string)
+		for _, result := range response.Results {
+			found[result.Name] = result.Orphaned
+		}
+		return found
+	}
+	wantOrphans := map[string][]string{"llama": {"retired", "gone"}, "granite": {"retired"}}
+
+	// A dry run reports the orphaned tiers without updating anything
+	response, err := s.ReconcileTiers(context.Background(), true, true)
+	if err != nil {
+		t.Fatalf("ReconcileTiers() dry run error = %v", err)
+	}
+	if !reflect.DeepEqual(orphans(response), wantOrphans) {
+		t.Errorf("Expected orphans %v, got %v", wantOrphans, orphans(response))
+	}
+	if response.Scanned != 6 || response.Matched != 2 || response.Reconciled != 0 || response.Skipped != 1 {
+		t.Errorf("Unexpected dry run summary: %+v", response)
+	}
+	if obj, _ := s.store.GetLLMInferenceService(context.Background(), "models", "llama"); obj.GetAnnotations()[models.TierAnnotationKey] != `["free","retired","gone"]` {
+		t.Errorf("Expected the dry run to leave llama unchanged, got %q", obj.GetAnnotations()[models.TierAnnotationKey])
+	}
+
+	// granite loses its only tier, and phi keeps only tiers without active groups
+	var warned []string
+	for _, warning := range response.Warnings {
+		warned = append(warned, warning.Name)
+	}
+	sort.Strings(warned)
+	if !reflect.DeepEqual(warned, []string{"granite", "phi"}) {
+		t.Errorf("Expected warnings for granite and phi, got %v", warned)
+	}
+
+	response, err = s.ReconcileTiers(context.Background(), false, false)
+	if err != nil {
+		t.Fatalf("ReconcileTiers() error = %v", err)
+	}
+	if response.Reconciled != 2 || response.Failed != 0 || response.Warnings != nil {
+		t.Errorf("Unexpected reconcile summary: %+v", response)
+	}
+	for name, want := range map[string][]string{"llama": {"free"}, "granite": {}, "phi": {"empty", "archived"}, "mistral": {"premium"}} {
+		obj, err := s.store.GetLLMInferenceService(context.Background(), "models", name)
+		if err != nil {
+			t.Fatalf("GetLLMInferenceService(%s) error = %v", name, err)
+		}
+		if tiers, _ := tiersFromUnstructured(obj); !reflect.DeepEqual(tiers, want) {
+			t.Errorf("Expected %s to keep %v, got %v", name, want, tiers)
+		}
+	}
+
+	// Reconciling again finds nothing to remove
+	if response, err := s.ReconcileTiers(context.Background(), false, false); err != nil || response.Matched != 0 {
+		t.Errorf("Expected a second reconcile to match nothing, got %+v, %v", response, err)
+	}
+}
+
+func TestMigrateAnnotations(t *testing.T) {
+	const newKey = "maas.opendatahub.io/tiers"
+	oldKey := models.TierAnnotationKey
+	withNewKey := func(service *unstructured.Unstructured, value string) *unstructured.Unstructured {
+		annotations := service.GetAnnotations()
+		annotations[newKey] = value
+		service.SetAnnotations(annotations)
+		return service
+	}
+
+	for _, removeOld := range []bool{false, true} {
+		t.Run(fmt.Sprintf("removeOld=%v", removeOld), func(t *testing.T) {
+			s := newTestLLMServiceService(t,
+				newTestLLMService("models", "llama", `["free"]`),
+				withNewKey(newTestLLMService("models", "granite", `["free","enterprise"]`), `["premium","free"]`),
+				newTestLLMService("models", "broken", `not-json`),
+				newTestLLMService("models", "phi", ""),
+			)
+			req := &models.MigrateAnnotationsRequest{OldKey: oldKey, NewKey: newKey, RemoveOld: removeOld}
+
+			// A dry run reports the merged tiers without updating anything
+			response, err := s.MigrateAnnotations(context.Background(), req, true)
+			if err != nil {
+				t.Fatalf("MigrateAnnotations() dry run error = %v", err)
+			}
+			if response.Matched != 3 || response.Migrated != 0 || response.Failed != 1 {
+				t.Errorf("Expected dry run to match 3, migrate 0 and fail 1, got %+v", response)
+			}
+			obj, _ := s.store.GetLLMInferenceService(context.Background(), "models", "llama")
+			if _, ok := obj.GetAnnotations()[newKey]; ok {
+				t.Error("Expected dry run not to set the new key")
+			}
+
+			response, err = s.MigrateAnnotations(context.Background(), req, false)
+			if err != nil {
+				t.Fatalf("MigrateAnnotations() error = %v", err)
+			}
+			if response.Matched != 3 || response.Migrated != 2 || response.Failed != 1 {
+				t.Errorf("Expected to match 3, migrate 2 and fail 1, got %+v", response)
+			}
+
+			// Tiers already under the new key come first, followed by old-key tiers they lack
+			want := map[string][]string{
+				"llama":   {"free"},
+				"granite": {"premium", "free", "enterprise"},
+			}
+			for name, wantTiers := range want {
+				obj, err := s.store.GetLLMInferenceService(context.Background(), "models", name)
+				if err != nil {
+					t.Fatalf("GetLLMInferenceService(%s) error = %v", name, err)
+				}
+				annotations := obj.GetAnnotations()
+				tiers, _ := models.ParseTiersFromAnnotation(annotations[newKey])
+				if !reflect.DeepEqual(tiers, wantTiers) {
+					t.Errorf("Expected %s to have tiers %v, got %v", name, wantTiers, tiers)
+				}
+				if _, ok := annotations[oldKey]; ok == removeOld {
+					t.Errorf("Expected %s old key present = %v, got %v", name, !removeOld, ok)
+				}
+			}
+
+			for _, result := range response.Results {
+				if result.Name == "broken" && (result.Status != models.AnnotateStatusFailed || !strings.Contains(result.Error, models.ErrInvalidTierAnnotation.Error())) {
+					t.Errorf("Expected broken to fail with an invalid annotation, got %+v", result)
+				}
+			}
+		})
+	}
+}
+
+func TestMigrateAnnotations_ConcurrentUpdate(t *testing.T) {
+	const oldKey = "example.com/tiers"
+	tierService := newSeededTierService(t, []models.Tier{
+		{Name: "free", Description: "Free", Level: 1, Groups: []string{"free-users"}},
+		{Name: "premium", Description: "Premium", Level: 10, Groups: []string{"premium-users"}},
+	})
+	llama := newTestLLMService("models", "llama", "")
+	llama.SetAnnotations(map[string]string{oldKey: `["premium"]`})
+	llama.SetResourceVersion("5")
+	granite := newTestLLMService("models", "granite", "")
+	granite.SetAnnotations(map[string]string{oldKey: `["premium"]`})
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		{Group: "serving.kserve.io", Version: "v1alpha1", Resource: "llminferenceservices"}: "LLMInferenceServiceList",
+	}, llama, granite)
+	// Another writer changes llama right after the list. The fake client ignores
+	// resourceVersion, so emulate the API server's optimistic concurrency check.
+	dynamicClient.PrependReactor("get", "llminferenceservices", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.(k8stesting.GetAction).GetName() != "llama" {
+			return false, nil, nil
+		}
+		changed := newTestLLMService("models", "llama", `["free"]`)
+		changed.SetResourceVersion("6")
+		return true, changed, nil
+	})
+	dynamicClient.PrependReactor("update", "llminferenceservices", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		obj := action.(k8stesting.UpdateAction).GetObject().(*unstructured.Unstructured)
+		if obj.GetName() == "llama" && obj.GetResourceVersion() != "6" {
+			return true, nil, apierrors.NewConflict(schema.GroupResource{Group: "serving.kserve.io", Resource: "llminferenceservices"}, obj.GetName(), errors.New("the object has been modified"))
+		}
+		return false, nil, nil
+	})
+	s := NewLLMInferenceServiceService(tierService, storage.NewLLMInferenceServiceStorage(dynamicClient))
+	if count, err := s.CountLLMInferenceServicesByTier(context.Background(), "premium"); err != nil || count != 0 {
+		t.Fatalf("Expected 0 premium services before migrating, got %d, %v", count, err)
+	}
+
+	req := &models.MigrateAnnotationsRequest{OldKey: oldKey, NewKey: models.TierAnnotationKey}
+	response, err := s.MigrateAnnotations(context.Background(), req, false)
+	if err != nil {
+		t.Fatalf("MigrateAnnotations() error = %v", err)
+	}
+	if response.Migrated != 1 || response.Failed != 1 {
+		t.Errorf("Expected to migrate 1 and fail 1, got %+v", response)
+	}
+	for _, result := range response.Results {
+		if result.Name == "llama" && (result.Status != models.AnnotateStatusFailed || !strings.Contains(result.Error, models.ErrConcurrentModification.Error())) {
+			t.Errorf("Expected llama to fail with a concurrent modification, got %+v", result)
+		}
+	}
+
+	// The cached usage count of the migrated tier is invalidated
+	if count, err := s.CountLLMInferenceServicesByTier(context.Background(), "premium"); err != nil || count != 1 {
+		t.Errorf("Expected 1 premium service after migrating, got %d, %v", count, err)
+	}
+}
+
+func TestMigrateAnnotations_Validation(t *testing.T) {
+	s := newTestLLMServiceService(t)
+
+	tests := []struct {
+		name    string
+		req     models.MigrateAnnotationsRequest
+		wantErr error
+	}{
+		{"missing old key", models.MigrateAnnotationsRequest{NewKey: models.TierAnnotationKey}, models.ErrAnnotationKeyRequired},
+		{"invalid new key", models.MigrateAnnotationsRequest{OldKey: "old/tiers", NewKey: "not a key"}, models.ErrInvalidAnnotationKey},
+		{"same keys", models.MigrateAnnotationsRequest{OldKey: models.TierAnnotationKey, NewKey: models.TierAnnotationKey}, models.ErrAnnotationKeysIdentical},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.MigrateAnnotations(context.Background(), &tt.req, false); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestTierReport(t *testing.T) {
+	s := newTestLLMServiceService(t,
+		newTestLLMService("models", "llama", `["free","premium"]`),
+		newTestLLMService("models", "granite", `["premium"]`),
+		newTestLLMService("other-models", "phi", `["premium"]`),
+		newTestLLMService("models", "broken", `not-json`),
+	)
+
+	report, err := s.TierReport(context.Background(), true)
+	if err != nil {
+		t.Fatalf("TierReport() error = %v", err)
+	}
+	if !report.ServicesIncluded || report.SkippedServices != 1 {
+		t.Errorf("Expected services included with 1 skipped, got %+v", report)
+	}
+	if len(report.Tiers) != 2 || report.Tiers[0].Name != "premium" || report.Tiers[1].Name != "free" {
+		t.Fatalf("Expected rows ordered premium, free, got %+v", report.Tiers)
+	}
+	premium := report.Tiers[0]
+	if premium.Level != 10 || premium.GroupCount != 1 || premium.ServiceCount == nil || *premium.ServiceCount != 3 {
+		t.Errorf("Unexpected premium row %+v", premium)
+	}
+	if !reflect.DeepEqual(premium.Namespaces, []string{"models", "other-models"}) {
+		t.Errorf("Expected premium namespaces [models other-models], got %v", premium.Namespaces)
+	}
+
+	report, err = s.TierReport(context.Background(), false)
+	if err != nil {
+		t.Fatalf("TierReport(false) error = %v", err)
+	}
+	if report.ServicesIncluded || report.Tiers[0].ServiceCount != nil || report.Tiers[0].Namespaces != nil {
+		t.Errorf("Expected no service usage without includeServices, got %+v", report)
+	}
+}
+
+func TestSummarizeLLMInferenceServicesByTier(t *testing.T) {
+	s := newTestLLMServiceService(t,
+		newTestLLMService("team-b", "llama", `["premium"]`),
+		newTestLLMService("team-a", "granite", `["premium"]`),
+		newTestLLMService("team-c", "phi", `["premium"]`),
+		newTestLLMService("team-c", "mistral", `["premium","free"]`),
+		newTestLLMService("team-d", "gemma", `["free"]`),
+	)
+
+	summary, err := s.SummarizeLLMInferenceServicesByTier(context.Background(), "premium")
+	if err != nil {
+		t.Fatalf("SummarizeLLMInferenceServicesByTier() error = %v", err)
+	}
+	want := []models.NamespaceCount{{Namespace: "team-c", Count: 2}, {Namespace: "team-a", Count: 1}, {Namespace: "team-b", Count: 1}}
+	if summary.Tier != "premium" || summary.Total != 4 || !reflect.DeepEqual(summary.Namespaces, want) {
+		t.Errorf("Expected total 4 with namespaces %v, got %+v", want, summary)
+	}
+}
+
+func TestCreateLLMInferenceService(t *testing.T) {
+	s := newTestLLMServiceService(t, newTestLLMService("models", "llama", `["free"]`))
+	if count, err := s.CountLLMInferenceServicesByTier(context.Background(), "premium"); err != nil || count != 0 {
+		t.Fatalf("Expected 0 premium services before the create, got %d, %v", count, err)
+	}
+
+	created, err := s.CreateLLMInferenceService(context.Background(), &models.CreateLLMInferenceServiceRequest{
+		Namespace: "models", Name: "mistral", ModelURI: "hf://mistralai/Mistral-7B-Instruct-v0.3", Tier: "premium",
+	})
+	if err != nil {
+		t.Fatalf("CreateLLMInferenceService() error = %v", err)
+	}
+	if created.Namespace != "models" || created.Name != "mistral" || !reflect.DeepEqual(created.Tiers, []string{"premium"}) {
+		t.Errorf("Expected models/mistral with tiers [premium], got %+v", created)
+	}
+	model, _ := created.Spec["model"].(map[string]interface{})
+	if model["uri"] != "hf://mistralai/Mistral-7B-Instruct-v0.3" {
+		t.Errorf("Expected spec.model.uri to be set, got %v", created.Spec)
+	}
+
+	// The annotated service is listed under its tier
+	services, err := s.GetLLMInferenceServicesByTier(context.Background(), "premium")
+	if err != nil {
+		t.Fatalf("GetLLMInferenceServicesByTier() error = %v", err)
+	}
+	if !reflect.DeepEqual(serviceNames(services), []string{"models/mistral"}) {
+		t.Errorf("Expected [models/mistral], got %v", serviceNames(services))
+	}
+	// The cached usage count is invalidated
+	if count, err := s.CountLLMInferenceServicesByTier(context.Background(), "premium"); err != nil || count != 1 {
+		t.Errorf("Expected 1 premium service after the create, got %d, %v", count, err)
+	}
+
+	tests := []struct {
+		name    string
+		req     models.CreateLLMInferenceServiceRequest
+		wantErr error
+	}{
+		{"existing service", models.CreateLLMInferenceServiceRequest{Namespace: "models", Name: "llama", ModelURI: "hf://x", Tier: "free"}, models.ErrLLMServiceAlreadyExists},
+		{"unknown tier", models.CreateLLMInferenceServiceRequest{Namespace: "models", Name: "phi", ModelURI: "hf://x", Tier: "gold"}, models.ErrTierNotFound},
+		{"missing model URI", models.CreateLLMInferenceServiceRequest{Namespace: "models", Name: "phi", Tier: "free"}, models.ErrModelURIRequired},
+		{"missing tier", models.CreateLLMInferenceServiceRequest{Namespace: "models", Name: "phi", ModelURI: "hf://x"}, models.ErrTierNameRequired},
+		{"invalid name", models.CreateLLMInferenceServiceRequest{Namespace: "models", Name: "Phi_3", ModelURI: "hf://x", Tier: "free"}, models.ErrInvalidKubernetesName},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.CreateLLMInferenceService(context.Background(), &tt.req)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
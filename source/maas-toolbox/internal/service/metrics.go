@@ -0,0 +1,129 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"errors"
+	"maas-toolbox/internal/models"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Tier mutation operations counted by the tier operations metric
+const (
+	OperationCreate      = "create"
+	OperationUpdate      = "update"
+	OperationDelete      = "delete"
+	OperationAddGroup    = "add_group"
+	OperationRemoveGroup = "remove_group"
+	OperationRenameGroup = "rename_group"
+	OperationEnsure      = "ensure"
+	OperationImport      = "import"
+	OperationRestore     = "restore"
+	OperationPurge       = "purge"
+)
+
+// Outcomes of a tier mutation operation
+const (
+	OutcomeSuccess         = "success"
+	OutcomeValidationError = "validation_error"
+	OutcomeConflict        = "conflict"
+	OutcomeNotFound        = "not_found"
+	OutcomeError           = "error"
+)
+
+// TierOperationsMetric is the name of the counter of tier mutations by operation and outcome
+const TierOperationsMetric = "maas_toolbox_tier_operations_total"
+
+// WithMetrics registers a counter of tier mutations, labeled by operation and outcome, with
+// registerer. A nil registerer, the default, disables the counter.
+func WithMetrics(registerer prometheus.Registerer) TierServiceOption {
+	return func(s *TierService) {
+		if registerer == nil {
+			s.operations = nil
+			return
+		}
+		operations := prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: TierOperationsMetric,
+			Help: "Tier mutation operations by operation and outcome.",
+		}, []string{"operation", "outcome"})
+		if err := registerer.Register(operations); err != nil {
+			// Share the counter already registered by another TierService
+			var registered prometheus.AlreadyRegisteredError
+			if !errors.As(err, &registered) {
+				panic(err)
+			}
+			operations = registered.ExistingCollector.(*prometheus.CounterVec)
+		}
+		s.operations = operations
+	}
+}
+
+// recordOperation counts operation with the outcome of *err. It is deferred with a pointer to
+// the method's named error result so every return path is counted.
+func (s *TierService) recordOperation(operation string, err *error) {
+	if s.operations == nil {
+		return
+	}
+	s.operations.WithLabelValues(operation, operationOutcome(*err)).Inc()
+}
+
+// Sentinels counted as conflicts and validation errors, matching their 409 and 422 responses
+var (
+	conflictErrors = []error{
+		models.ErrTierAlreadyExists,
+		models.ErrTierSoftDeleted,
+		models.ErrTierLevelConflict,
+		models.ErrGroupAlreadyExists,
+		models.ErrConcurrentModification,
+		models.ErrTierETagMismatch,
+		models.ErrTierLimitReached,
+	}
+	validationErrors = []error{
+		models.ErrTierNameImmutable,
+		models.ErrTierLevelTooHigh,
+		models.ErrTierGroupsRequired,
+		models.ErrGroupRequired,
+		models.ErrInvalidKubernetesName,
+		models.ErrTierNameReserved,
+		models.ErrInvalidGroupColon,
+		models.ErrInvalidGroupPattern,
+		models.ErrGroupNotFoundInCluster,
+	}
+)
+
+// operationOutcome classifies err as one of the Outcome values
+func operationOutcome(err error) string {
+	if err == nil {
+		return OutcomeSuccess
+	}
+	if errors.Is(err, models.ErrTierNotFound) || errors.Is(err, models.ErrGroupNotFound) {
+		return OutcomeNotFound
+	}
+	for _, sentinel := range conflictErrors {
+		if errors.Is(err, sentinel) {
+			return OutcomeConflict
+		}
+	}
+	if isValidationError(err) {
+		return OutcomeValidationError
+	}
+	for _, sentinel := range validationErrors {
+		if errors.Is(err, sentinel) {
+			return OutcomeValidationError
+		}
+	}
+	return OutcomeError
+}
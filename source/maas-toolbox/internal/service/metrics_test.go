@@ -0,0 +1,117 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"maas-toolbox/internal/models"
+	"maas-toolbox/internal/storage"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestWithMetrics_CountsCreateOutcomes(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	s := newTestTierService(WithMetrics(registry))
+	tier := models.Tier{Name: "free", Description: "Free", Level: 1, Groups: []string{storage.SystemAuthenticatedGroup}}
+
+	if err := s.CreateTier(context.Background(), &tier); err != nil {
+		t.Fatalf("CreateTier() error = %v", err)
+	}
+	duplicate := tier
+	if err := s.CreateTier(context.Background(), &duplicate); err == nil {
+		t.Fatal("Expected the duplicate create to fail")
+	}
+	if err := s.DeleteTier(context.Background(), "missing"); err == nil {
+		t.Fatal("Expected deleting a missing tier to fail")
+	}
+
+	for _, tt := range []struct {
+		operation, outcome string
+		want               float64
+	}{
+		{OperationCreate, OutcomeSuccess, 1},
+		{OperationCreate, OutcomeConflict, 1},
+		{OperationCreate, OutcomeValidationError, 0},
+		{OperationDelete, OutcomeNotFound, 1},
+	} {
+		if got := testutil.ToFloat64(s.operations.WithLabelValues(tt.operation, tt.outcome)); got != tt.want {
+			t.Errorf("Expected %s/%s count %v, got %v", tt.operation, tt.outcome, tt.want, got)
+		}
+	}
+
+	// A second service registering with the same registry shares the counter
+	other := newTestTierService(WithMetrics(registry))
+	if other.operations != s.operations {
+		t.Error("Expected services on one registry to share the counter")
+	}
+}
+
+func TestWithMetrics_CountsPatchOutcomes(t *testing.T) {
+	s := newTestTierService(WithMetrics(prometheus.NewRegistry()))
+	tier := models.Tier{Name: "free", Description: "Free", Level: 1, Groups: []string{storage.SystemAuthenticatedGroup}}
+	if err := s.CreateTier(context.Background(), &tier); err != nil {
+		t.Fatalf("CreateTier() error = %v", err)
+	}
+
+	if _, err := s.PatchTier(context.Background(), "free", PatchTypeMerge, []byte(`{"description":"Free tier"}`), ""); err != nil {
+		t.Fatalf("PatchTier() error = %v", err)
+	}
+	if _, err := s.PatchTier(context.Background(), "free", PatchTypeMerge, []byte(`{"description":""}`), ""); err == nil {
+		t.Fatal("Expected a patch clearing the description to fail")
+	}
+	if _, err := s.PatchTier(context.Background(), "missing", PatchTypeMerge, []byte(`{"description":"Missing"}`), ""); err == nil {
+		t.Fatal("Expected patching a missing tier to fail")
+	}
+
+	for _, tt := range []struct {
+		outcome string
+		want    float64
+	}{
+		{OutcomeSuccess, 1},
+		{OutcomeValidationError, 1},
+		{OutcomeNotFound, 1},
+	} {
+		if got := testutil.ToFloat64(s.operations.WithLabelValues(OperationUpdate, tt.outcome)); got != tt.want {
+			t.Errorf("Expected %s/%s count %v, got %v", OperationUpdate, tt.outcome, tt.want, got)
+		}
+	}
+}
+
+func TestOperationOutcome(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{nil, OutcomeSuccess},
+		{models.NewValidationError(models.FieldName, models.ErrTierNameRequired), OutcomeValidationError},
+		{models.ErrInvalidGroupColon, OutcomeValidationError},
+		{models.ErrTierNotFound, OutcomeNotFound},
+		{models.ErrGroupNotFound, OutcomeNotFound},
+		{fmt.Errorf("%w: %q differs only in case", models.ErrTierAlreadyExists, "Free"), OutcomeConflict},
+		{fmt.Errorf("failed to save config: %w", models.ErrConcurrentModification), OutcomeConflict},
+		{models.ErrTierETagMismatch, OutcomeConflict},
+		{fmt.Errorf("failed to load config: connection refused"), OutcomeError},
+	}
+
+	for _, tt := range tests {
+		if got := operationOutcome(tt.err); got != tt.want {
+			t.Errorf("operationOutcome(%v) = %s, want %s", tt.err, got, tt.want)
+		}
+	}
+}
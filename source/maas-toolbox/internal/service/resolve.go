@@ -0,0 +1,167 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"maas-toolbox/internal/models"
+	"maas-toolbox/internal/storage"
+	"sort"
+)
+
+// WithImplicitAuthenticatedMatch controls whether tiers containing system:authenticated
+// match every authenticated caller during resolution. It is enabled by default.
+func WithImplicitAuthenticatedMatch(enabled bool) TierServiceOption {
+	return func(s *TierService) {
+		s.implicitAuthenticated = enabled
+	}
+}
+
+// matchTier returns the caller groups that match tier, or nil if none do.
+// A caller group matches an exact tier group or a wildcard pattern it has the prefix of;
+// wildcard is true when no caller group matched exactly. When implicit matching is enabled,
+// system:authenticated matches every caller.
+func (s *TierService) matchTier(tier models.Tier, groups []string) (matched []string, implicit, wildcard bool) {
+	hasAuthenticated := false
+	exact := false
+	for _, group := range groups {
+		found := false
+		for _, entry := range tier.Groups {
+			if entry == group {
+				exact = true
+				found = true
+				break
+			}
+			found = found || models.GroupMatches(entry, group)
+		}
+		if found {
+			matched = append(matched, group)
+		}
+	}
+	for _, entry := range tier.Groups {
+		if entry == storage.SystemAuthenticatedGroup {
+			hasAuthenticated = true
+		}
+	}
+
+	if len(matched) == 0 && hasAuthenticated && s.implicitAuthenticated {
+		return []string{storage.SystemAuthenticatedGroup}, true, false
+	}
+	return matched, false, len(matched) > 0 && !exact
+}
+
+// ResolveTiers returns every tier the given groups qualify for, ordered by level
+// with the highest first. Tiers with equal levels keep their configured order.
+// Exact and wildcard matches rank the same: only the level decides precedence.
+func (s *TierService) ResolveTiers(ctx context.Context, groups []string) ([]models.TierMatch, error) {
+	config, err := s.storage.Load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	matches := []models.TierMatch{}
+	for _, tier := range activeTiers(config.Tiers) {
+		matched, implicit, wildcard := s.matchTier(tier, groups)
+		if len(matched) == 0 {
+			continue
+		}
+		matches = append(matches, models.TierMatch{Tier: tier, MatchedGroups: matched, Implicit: implicit, Wildcard: wildcard})
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Tier.Level > matches[j].Tier.Level
+	})
+	return matches, nil
+}
+
+// ExplainResolution describes how the winner of matches, as ordered by ResolveTiers, was chosen.
+// It returns nil when there are no matches.
+func ExplainResolution(matches []models.TierMatch) *models.ResolveExplanation {
+	if len(matches) == 0 {
+		return nil
+	}
+
+	winner := matches[0].Tier
+	candidates := make([]models.ResolveCandidate, 0, len(matches))
+	tied := 0
+	for i, match := range matches {
+		if match.Tier.Level == winner.Level {
+			tied++
+		}
+		candidates = append(candidates, models.ResolveCandidate{
+			Tier:          match.Tier.Name,
+			Level:         match.Tier.Level,
+			MatchedGroups: match.MatchedGroups,
+			Implicit:      match.Implicit,
+			Wildcard:      match.Wildcard,
+			Winner:        i == 0,
+		})
+	}
+
+	explanation := &models.ResolveExplanation{Candidates: candidates, Winner: winner.Name}
+	switch {
+	case len(matches) == 1:
+		explanation.Rule = models.TiebreakOnlyMatch
+		explanation.Reason = fmt.Sprintf("%s is the only matching tier", winner.Name)
+	case tied == 1:
+		explanation.Rule = models.TiebreakHighestLevel
+		explanation.Reason = fmt.Sprintf("%s has the highest level (%d) of %d candidates", winner.Name, winner.Level, len(matches))
+	default:
+		explanation.Rule = models.TiebreakConfiguredOrder
+		explanation.Reason = fmt.Sprintf("%d candidates share the highest level (%d); %s is configured first", tied, winner.Level, winner.Name)
+	}
+	return explanation
+}
+
+// GetEffectiveTiersByGroup returns the tiers a member of groupName qualifies for,
+// including tiers matched implicitly through system:authenticated
+func (s *TierService) GetEffectiveTiersByGroup(ctx context.Context, groupName string) ([]models.Tier, error) {
+	// Validate group name format
+	if err := models.ValidateGroupName(groupName); err != nil {
+		return nil, err
+	}
+
+	matches, err := s.ResolveTiers(ctx, []string{groupName})
+	if err != nil {
+		return nil, err
+	}
+
+	tiers := make([]models.Tier, 0, len(matches))
+	for _, match := range matches {
+		tiers = append(tiers, match.Tier)
+	}
+	return tiers, nil
+}
+
+// GetTiersForUser resolves the tiers a user qualifies for through their OpenShift group membership.
+// Implicit system:authenticated matching applies as in ResolveTiers.
+func (s *TierService) GetTiersForUser(ctx context.Context, username string) (*models.UserTiersResponse, error) {
+	if err := models.ValidateUsername(username); err != nil {
+		return nil, err
+	}
+
+	groups, err := s.storage.GetUserGroups(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	matches, err := s.ResolveTiers(ctx, groups)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.UserTiersResponse{User: username, Groups: groups, Matches: matches}, nil
+}
@@ -0,0 +1,270 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"maas-toolbox/internal/models"
+	"maas-toolbox/internal/storage"
+	"reflect"
+	"testing"
+)
+
+// newSeededTierService creates a TierService whose ConfigMap already holds tiers
+func newSeededTierService(t *testing.T, tiers []models.Tier, opts ...TierServiceOption) *TierService {
+	t.Helper()
+	s := newTestTierService(opts...)
+	if err := s.storage.Save(context.Background(), &models.TierConfig{Tiers: tiers}); err != nil {
+		t.Fatalf("Failed to seed tiers: %v", err)
+	}
+	return s
+}
+
+var resolveTestTiers = []models.Tier{
+	{Name: "free", Description: "Free", Level: 1, Groups: []string{storage.SystemAuthenticatedGroup}},
+	{Name: "premium", Description: "Premium", Level: 10, Groups: []string{"premium-users"}},
+	{Name: "enterprise", Description: "Enterprise", Level: 20, Groups: []string{"enterprise-users", "premium-users"}},
+}
+
+func tierNames(matches []models.TierMatch) []string {
+	names := make([]string, 0, len(matches))
+	for _, match := range matches {
+		names = append(names, match.Tier.Name)
+	}
+	return names
+}
+
+func TestResolveTiers(t *testing.T) {
+	tests := []struct {
+		name     string
+		implicit bool
+		groups   []string
+		want     []string
+	}{
+		{"no explicit groups matches via system:authenticated", true, []string{"other-users"}, []string{"free"}},
+		{"no groups at all matches via system:authenticated", true, nil, []string{"free"}},
+		{"explicit groups ordered by level", true, []string{"premium-users"}, []string{"enterprise", "premium", "free"}},
+		{"implicit matching disabled", false, []string{"other-users"}, []string{}},
+		{"implicit disabled keeps explicit matches", false, []string{"premium-users"}, []string{"enterprise", "premium"}},
+		{"explicit system:authenticated with implicit disabled", false, []string{storage.SystemAuthenticatedGroup}, []string{"free"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newSeededTierService(t, resolveTestTiers, WithImplicitAuthenticatedMatch(tt.implicit))
+			matches, err := s.ResolveTiers(context.Background(), tt.groups)
+			if err != nil {
+				t.Fatalf("ResolveTiers() error = %v", err)
+			}
+			if got := tierNames(matches); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ResolveTiers() tiers = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveTiers_MatchedGroups(t *testing.T) {
+	s := newSeededTierService(t, resolveTestTiers)
+	matches, err := s.ResolveTiers(context.Background(), []string{"other-users"})
+	if err != nil {
+		t.Fatalf("ResolveTiers() error = %v", err)
+	}
+	if len(matches) != 1 {
+		t.Fatalf("Expected 1 match, got %d", len(matches))
+	}
+	if !matches[0].Implicit {
+		t.Error("Expected match through system:authenticated to be implicit")
+	}
+	if !reflect.DeepEqual(matches[0].MatchedGroups, []string{storage.SystemAuthenticatedGroup}) {
+		t.Errorf("Expected matched group system:authenticated, got %v", matches[0].MatchedGroups)
+	}
+}
+
+func TestExplainResolution(t *testing.T) {
+	tiedTiers := []models.Tier{
+		{Name: "gold", Description: "Gold", Level: 10, Groups: []string{"vip-users"}},
+		{Name: "platinum", Description: "Platinum", Level: 10, Groups: []string{"vip-users"}},
+		{Name: "free", Description: "Free", Level: 1, Groups: []string{storage.SystemAuthenticatedGroup}},
+	}
+
+	tests := []struct {
+		name       string
+		tiers      []models.Tier
+		groups     []string
+		wantWinner string
+		wantRule   string
+		wantLevels []int
+	}{
+		{"system:authenticated only", resolveTestTiers, []string{"other-users"}, "free", models.TiebreakOnlyMatch, []int{1}},
+		{"highest level wins", resolveTestTiers, []string{"premium-users"}, "enterprise", models.TiebreakHighestLevel, []int{20, 10, 1}},
+		{"tie broken by configured order", tiedTiers, []string{"vip-users"}, "gold", models.TiebreakConfiguredOrder, []int{10, 10, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newSeededTierService(t, tt.tiers)
+			matches, err := s.ResolveTiers(context.Background(), tt.groups)
+			if err != nil {
+				t.Fatalf("ResolveTiers() error = %v", err)
+			}
+
+			why := ExplainResolution(matches)
+			if why == nil {
+				t.Fatal("Expected an explanation")
+			}
+			if why.Winner != tt.wantWinner || why.Rule != tt.wantRule {
+				t.Errorf("Expected winner %s by %s, got %s by %s", tt.wantWinner, tt.wantRule, why.Winner, why.Rule)
+			}
+			if why.Reason == "" {
+				t.Error("Expected a reason")
+			}
+
+			var levels []int
+			winners := 0
+			for _, candidate := range why.Candidates {
+				levels = append(levels, candidate.Level)
+				if candidate.Winner {
+					winners++
+					if candidate.Tier != tt.wantWinner {
+						t.Errorf("Expected %s marked as winner, got %s", tt.wantWinner, candidate.Tier)
+					}
+				}
+				if len(candidate.MatchedGroups) == 0 {
+					t.Errorf("Expected matched groups for %s", candidate.Tier)
+				}
+			}
+			if winners != 1 {
+				t.Errorf("Expected exactly one winner, got %d", winners)
+			}
+			if !reflect.DeepEqual(levels, tt.wantLevels) {
+				t.Errorf("Expected candidate levels %v, got %v", tt.wantLevels, levels)
+			}
+		})
+	}
+}
+
+func TestExplainResolution_ImplicitCandidate(t *testing.T) {
+	s := newSeededTierService(t, resolveTestTiers)
+	matches, err := s.ResolveTiers(context.Background(), []string{"other-users"})
+	if err != nil {
+		t.Fatalf("ResolveTiers() error = %v", err)
+	}
+
+	why := ExplainResolution(matches)
+	if why == nil || len(why.Candidates) != 1 {
+		t.Fatalf("Expected one candidate, got %+v", why)
+	}
+	candidate := why.Candidates[0]
+	if !candidate.Implicit || !reflect.DeepEqual(candidate.MatchedGroups, []string{storage.SystemAuthenticatedGroup}) {
+		t.Errorf("Expected implicit match through system:authenticated, got %+v", candidate)
+	}
+}
+
+func TestExplainResolution_NoMatches(t *testing.T) {
+	if why := ExplainResolution(nil); why != nil {
+		t.Errorf("Expected no explanation without matches, got %+v", why)
+	}
+}
+
+func TestGetTiersByGroup_IgnoresImplicitMatch(t *testing.T) {
+	s := newSeededTierService(t, resolveTestTiers)
+
+	tiers, err := s.GetTiersByGroup(context.Background(), "other-users")
+	if err != nil {
+		t.Fatalf("GetTiersByGroup() error = %v", err)
+	}
+	if len(tiers) != 0 {
+		t.Errorf("Expected no explicit tiers, got %v", tiers)
+	}
+
+	tiers, err = s.GetEffectiveTiersByGroup(context.Background(), "other-users")
+	if err != nil {
+		t.Fatalf("GetEffectiveTiersByGroup() error = %v", err)
+	}
+	if len(tiers) != 1 || tiers[0].Name != "free" {
+		t.Errorf("Expected effective tier free, got %v", tiers)
+	}
+}
+
+var wildcardTestTiers = []models.Tier{
+	{Name: "team", Description: "Any team", Level: 5, Groups: []string{"team:*"}},
+	{Name: "ml-team", Description: "ML team", Level: 3, Groups: []string{"team:ml"}},
+	{Name: "mixed", Description: "Mixed", Level: 1, Groups: []string{"team:*", "team:ml"}},
+}
+
+func TestResolveTiers_Wildcard(t *testing.T) {
+	s := newSeededTierService(t, wildcardTestTiers)
+
+	matches, err := s.ResolveTiers(context.Background(), []string{"team:ml"})
+	if err != nil {
+		t.Fatalf("ResolveTiers() error = %v", err)
+	}
+
+	// Exact and wildcard matches rank the same, so the wildcard tier with the higher level wins
+	if got, want := tierNames(matches), []string{"team", "ml-team", "mixed"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Expected tiers %v, got %v", want, got)
+	}
+	if !matches[0].Wildcard {
+		t.Error("Expected team to match through a wildcard")
+	}
+	if matches[1].Wildcard {
+		t.Error("Expected ml-team to match exactly")
+	}
+	// A tier matched both exactly and by wildcard reports the exact match
+	if matches[2].Wildcard {
+		t.Error("Expected mixed to report an exact match")
+	}
+	if !reflect.DeepEqual(matches[0].MatchedGroups, []string{"team:ml"}) {
+		t.Errorf("Expected matched group team:ml, got %v", matches[0].MatchedGroups)
+	}
+
+	matches, err = s.ResolveTiers(context.Background(), []string{"team:data"})
+	if err != nil {
+		t.Fatalf("ResolveTiers() error = %v", err)
+	}
+	if got, want := tierNames(matches), []string{"team", "mixed"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected tiers %v, got %v", want, got)
+	}
+}
+
+func TestGetTiersByGroup_Wildcard(t *testing.T) {
+	s := newSeededTierService(t, wildcardTestTiers)
+
+	tiers, err := s.GetTiersByGroup(context.Background(), "team:data")
+	if err != nil {
+		t.Fatalf("GetTiersByGroup() error = %v", err)
+	}
+	if len(tiers) != 2 || tiers[0].Name != "team" || tiers[1].Name != "mixed" {
+		t.Errorf("Expected tiers team and mixed, got %v", tiers)
+	}
+}
+
+func TestCreateTier_WildcardGroup(t *testing.T) {
+	s := newTestTierService()
+
+	// Patterns skip the cluster existence check
+	if err := s.CreateTier(context.Background(), &models.Tier{Name: "team", Description: "Any team", Level: 1, Groups: []string{"team:*"}}); err != nil {
+		t.Fatalf("CreateTier() error = %v", err)
+	}
+	if err := s.AddGroup(context.Background(), "team", "org-*"); err != nil {
+		t.Fatalf("AddGroup() error = %v", err)
+	}
+
+	err := s.CreateTier(context.Background(), &models.Tier{Name: "bad", Description: "Bad", Level: 1, Groups: []string{"*"}})
+	if !errors.Is(err, models.ErrInvalidGroupPattern) {
+		t.Errorf("Expected ErrInvalidGroupPattern, got %v", err)
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"maas-toolbox/internal/models"
+	"maas-toolbox/internal/webhook"
+	"time"
+)
+
+// DefaultSoftDeleteRetention is how long soft-deleted tiers are kept before a purge removes them
+const DefaultSoftDeleteRetention = 30 * 24 * time.Hour
+
+// WithSoftDelete controls whether DeleteTier marks tiers as deleted instead of removing them.
+// It is disabled by default.
+func WithSoftDelete(enabled bool) TierServiceOption {
+	return func(s *TierService) {
+		s.softDelete = enabled
+	}
+}
+
+// WithSoftDeleteRetention sets how long soft-deleted tiers are kept before PurgeDeletedTiers removes them
+func WithSoftDeleteRetention(retention time.Duration) TierServiceOption {
+	return func(s *TierService) {
+		s.softDeleteRetention = retention
+	}
+}
+
+// SoftDeleteRetention returns how long soft-deleted tiers are kept before a purge removes them
+func (s *TierService) SoftDeleteRetention() time.Duration {
+	return s.softDeleteRetention
+}
+
+// activeTiers returns the tiers that have not been soft-deleted
+func activeTiers(tiers []models.Tier) []models.Tier {
+	active := make([]models.Tier, 0, len(tiers))
+	for _, tier := range tiers {
+		if !tier.IsDeleted() {
+			active = append(active, tier)
+		}
+	}
+	return active
+}
+
+// GetAllTiers returns all tiers, including soft-deleted ones
+func (s *TierService) GetAllTiers(ctx context.Context) ([]models.Tier, error) {
+	config, err := s.storage.Load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	return config.Tiers, nil
+}
+
+// GetTierIncludingDeleted returns a tier by name whether or not it has been soft-deleted
+func (s *TierService) GetTierIncludingDeleted(ctx context.Context, name string) (*models.Tier, error) {
+	config, err := s.storage.Load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	for _, tier := range config.Tiers {
+		if tier.Name == name {
+			return &tier, nil
+		}
+	}
+
+	return nil, models.ErrTierNotFound
+}
+
+// RestoreTier clears the soft-delete mark on a tier.
+// Returns ErrTierNotDeleted if the tier exists but is not deleted.
+func (s *TierService) RestoreTier(ctx context.Context, name string) (tier *models.Tier, err error) {
+	defer s.recordOperation(OperationRestore, &err)
+
+	unlock := s.storage.LockWrites()
+	defer unlock()
+
+	// Load existing config
+	config, err := s.storage.Load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	index := -1
+	for i := range config.Tiers {
+		if config.Tiers[i].Name == name {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return nil, models.ErrTierNotFound
+	}
+	if !config.Tiers[index].IsDeleted() {
+		return nil, models.ErrTierNotDeleted
+	}
+	if err := s.checkLevelUnique(config.Tiers, name, config.Tiers[index].Level); err != nil {
+		return nil, err
+	}
+
+	before := snapshotTier(config.Tiers[index])
+	config.Tiers[index].DeletedAt = nil
+
+	// Save config
+	if err := s.storage.Save(ctx, config); err != nil {
+		return nil, fmt.Errorf("failed to save config: %w", err)
+	}
+
+	restored := config.Tiers[index]
+	s.notifyTier(webhook.ActionRestored, name, before, snapshotTier(restored))
+	return &restored, nil
+}
+
+// PurgeDeletedTiers permanently removes tiers soft-deleted longer ago than the retention period.
+// Returns the names of the purged tiers.
+func (s *TierService) PurgeDeletedTiers(ctx context.Context) (purged []string, err error) {
+	defer s.recordOperation(OperationPurge, &err)
+
+	unlock := s.storage.LockWrites()
+	defer unlock()
+
+	// Load existing config
+	config, err := s.storage.Load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	cutoff := s.now().Add(-s.softDeleteRetention)
+	purged = []string{}
+	var removed []models.Tier
+	kept := make([]models.Tier, 0, len(config.Tiers))
+	for _, tier := range config.Tiers {
+		if tier.IsDeleted() && tier.DeletedAt.Before(cutoff) {
+			purged = append(purged, tier.Name)
+			removed = append(removed, tier)
+			continue
+		}
+		kept = append(kept, tier)
+	}
+
+	if len(purged) == 0 {
+		return purged, nil
+	}
+
+	config.Tiers = kept
+	if err := s.storage.Save(ctx, config); err != nil {
+		return nil, fmt.Errorf("failed to save config: %w", err)
+	}
+
+	for _, tier := range removed {
+		s.notifyTier(webhook.ActionPurged, tier.Name, snapshotTier(tier), nil)
+	}
+	log.Printf("Purged %d soft-deleted tiers older than %s", len(purged), s.softDeleteRetention)
+	return purged, nil
+}
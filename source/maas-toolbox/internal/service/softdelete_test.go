@@ -0,0 +1,174 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"maas-toolbox/internal/models"
+	"testing"
+	"time"
+)
+
+func TestDeleteTier_SoftDelete(t *testing.T) {
+	s := newSeededTierService(t, levelTestTiers, WithSoftDelete(true))
+
+	if err := s.DeleteTier(context.Background(), "free"); err != nil {
+		t.Fatalf("DeleteTier() error = %v", err)
+	}
+
+	if _, err := s.GetTier(context.Background(), "free"); !errors.Is(err, models.ErrTierNotFound) {
+		t.Errorf("Expected soft-deleted tier to be hidden, got %v", err)
+	}
+	tiers, _ := s.GetTiers(context.Background())
+	if len(tiers) != 1 || tiers[0].Name != "premium" {
+		t.Errorf("Expected only premium in GetTiers, got %v", tiers)
+	}
+
+	deleted, err := s.GetTierIncludingDeleted(context.Background(), "free")
+	if err != nil || !deleted.IsDeleted() {
+		t.Fatalf("Expected soft-deleted tier with deletedAt, got %+v, %v", deleted, err)
+	}
+	all, _ := s.GetAllTiers(context.Background())
+	if len(all) != 2 {
+		t.Errorf("Expected 2 tiers including deleted, got %d", len(all))
+	}
+
+	// Deleted tiers cannot be modified, deleted again or recreated
+	if err := s.UpdateTier(context.Background(), "free", &models.TierUpdate{Description: "x"}, ""); !errors.Is(err, models.ErrTierNotFound) {
+		t.Errorf("Expected ErrTierNotFound on update, got %v", err)
+	}
+	if err := s.DeleteTier(context.Background(), "free"); !errors.Is(err, models.ErrTierNotFound) {
+		t.Errorf("Expected ErrTierNotFound on second delete, got %v", err)
+	}
+	err = s.CreateTier(context.Background(), &models.Tier{Name: "free", Description: "Free", Level: 1, Groups: []string{}})
+	if !errors.Is(err, models.ErrTierSoftDeleted) {
+		t.Errorf("Expected ErrTierSoftDeleted on create, got %v", err)
+	}
+}
+
+func TestDeleteTiers_SoftDelete(t *testing.T) {
+	s := newSeededTierService(t, levelTestTiers, WithSoftDelete(true))
+
+	response, err := s.DeleteTiers(context.Background(), []string{"free", "premium"}, nil)
+	if err != nil {
+		t.Fatalf("DeleteTiers() error = %v", err)
+	}
+	if response.Deleted != 2 {
+		t.Errorf("Expected 2 deleted, got %+v", response)
+	}
+
+	all, _ := s.GetAllTiers(context.Background())
+	for _, tier := range all {
+		if !tier.IsDeleted() {
+			t.Errorf("Expected %s to be soft-deleted", tier.Name)
+		}
+	}
+	if len(all) != 2 {
+		t.Errorf("Expected 2 tiers including deleted, got %d", len(all))
+	}
+
+	// Soft-deleted tiers are not found by a second batch delete
+	response, err = s.DeleteTiers(context.Background(), []string{"free"}, nil)
+	if err != nil || response.NotFound != 1 {
+		t.Errorf("Expected free to be not found, got %+v, %v", response, err)
+	}
+}
+
+func TestDeleteTier_HardDeleteByDefault(t *testing.T) {
+	s := newSeededTierService(t, levelTestTiers)
+
+	if err := s.DeleteTier(context.Background(), "free"); err != nil {
+		t.Fatalf("DeleteTier() error = %v", err)
+	}
+	if _, err := s.GetTierIncludingDeleted(context.Background(), "free"); !errors.Is(err, models.ErrTierNotFound) {
+		t.Errorf("Expected tier to be removed, got %v", err)
+	}
+}
+
+func TestRestoreTier(t *testing.T) {
+	s := newSeededTierService(t, levelTestTiers, WithSoftDelete(true))
+
+	if _, err := s.RestoreTier(context.Background(), "premium"); !errors.Is(err, models.ErrTierNotDeleted) {
+		t.Errorf("Expected ErrTierNotDeleted, got %v", err)
+	}
+	if _, err := s.RestoreTier(context.Background(), "missing"); !errors.Is(err, models.ErrTierNotFound) {
+		t.Errorf("Expected ErrTierNotFound, got %v", err)
+	}
+
+	if err := s.DeleteTier(context.Background(), "free"); err != nil {
+		t.Fatalf("DeleteTier() error = %v", err)
+	}
+	restored, err := s.RestoreTier(context.Background(), "free")
+	if err != nil {
+		t.Fatalf("RestoreTier() error = %v", err)
+	}
+	if restored.IsDeleted() {
+		t.Error("Expected restored tier to have no deletedAt")
+	}
+	if _, err := s.GetTier(context.Background(), "free"); err != nil {
+		t.Errorf("Expected restored tier to be visible, got %v", err)
+	}
+}
+
+func TestRestoreTier_LevelConflict(t *testing.T) {
+	s := newSeededTierService(t, levelTestTiers, WithSoftDelete(true), WithUniqueLevels(true))
+
+	if err := s.DeleteTier(context.Background(), "free"); err != nil {
+		t.Fatalf("DeleteTier() error = %v", err)
+	}
+	// The deleted tier's level is free to reuse while it is deleted
+	if err := s.CreateTier(context.Background(), &models.Tier{Name: "basic", Description: "Basic", Level: 1, Groups: []string{}}); err != nil {
+		t.Fatalf("CreateTier() error = %v", err)
+	}
+	if _, err := s.RestoreTier(context.Background(), "free"); !errors.Is(err, models.ErrTierLevelConflict) {
+		t.Errorf("Expected ErrTierLevelConflict, got %v", err)
+	}
+}
+
+func TestPurgeDeletedTiers(t *testing.T) {
+	s := newSeededTierService(t, levelTestTiers, WithSoftDelete(true), WithSoftDeleteRetention(time.Hour))
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+
+	if err := s.DeleteTier(context.Background(), "free"); err != nil {
+		t.Fatalf("DeleteTier() error = %v", err)
+	}
+
+	// Within retention nothing is purged
+	now = now.Add(30 * time.Minute)
+	purged, err := s.PurgeDeletedTiers(context.Background())
+	if err != nil {
+		t.Fatalf("PurgeDeletedTiers() error = %v", err)
+	}
+	if len(purged) != 0 {
+		t.Errorf("Expected nothing purged within retention, got %v", purged)
+	}
+
+	now = now.Add(time.Hour)
+	purged, err = s.PurgeDeletedTiers(context.Background())
+	if err != nil {
+		t.Fatalf("PurgeDeletedTiers() error = %v", err)
+	}
+	if len(purged) != 1 || purged[0] != "free" {
+		t.Errorf("Expected free to be purged, got %v", purged)
+	}
+	if _, err := s.GetTierIncludingDeleted(context.Background(), "free"); !errors.Is(err, models.ErrTierNotFound) {
+		t.Errorf("Expected purged tier to be gone, got %v", err)
+	}
+	if _, err := s.GetTier(context.Background(), "premium"); err != nil {
+		t.Errorf("Expected active tier to be kept, got %v", err)
+	}
+}
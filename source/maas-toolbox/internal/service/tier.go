@@ -15,93 +15,194 @@
 package service
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"log"
 	"maas-toolbox/internal/models"
 	"maas-toolbox/internal/storage"
+	"maas-toolbox/internal/webhook"
+	"maps"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	jsonpatch "gopkg.in/evanphx/json-patch.v4"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Patch document types accepted by PatchTier
+const (
+	PatchTypeJSON  = "application/json-patch+json"  // RFC 6902 JSON Patch
+	PatchTypeMerge = "application/merge-patch+json" // RFC 7386 JSON Merge Patch
 )
 
 // TierService provides business logic for tier management
 type TierService struct {
-	storage *storage.K8sTierStorage
+	storage               *storage.K8sTierStorage
+	groupMatchPolicy      GroupMatchPolicy
+	implicitAuthenticated bool
+	uniqueLevels          bool
+	maxLevel              int
+	maxTiers              int
+	requireGroups         bool
+	softDelete            bool
+	softDeleteRetention   time.Duration
+	now                   func() time.Time
+	notifier              *webhook.Notifier
+	changes               *changeBus
+	operations            *prometheus.CounterVec
 }
 
-// NewTierService creates a new TierService instance
-func NewTierService(storage *storage.K8sTierStorage) *TierService {
-	return &TierService{
-		storage: storage,
+// TierServiceOption configures optional TierService behavior
+type TierServiceOption func(*TierService)
+
+// WithGroupMatchPolicy sets how group names are compared when detecting duplicates
+func WithGroupMatchPolicy(policy GroupMatchPolicy) TierServiceOption {
+	return func(s *TierService) {
+		s.groupMatchPolicy = policy
+	}
+}
+
+// DefaultMaxTiers is the default maximum number of tiers in the ConfigMap
+const DefaultMaxTiers = 1000
+
+// WithMaxTiers rejects adding a tier once the ConfigMap holds max tiers, counting soft-deleted
+// tiers since they are stored too. Zero sets no maximum. The default is DefaultMaxTiers.
+func WithMaxTiers(max int) TierServiceOption {
+	return func(s *TierService) {
+		s.maxTiers = max
+	}
+}
+
+// checkTierCapacity returns ErrTierLimitReached when adding another tier to tiers would
+// exceed the configured maximum
+func (s *TierService) checkTierCapacity(tiers []models.Tier) error {
+	if s.maxTiers > 0 && len(tiers) >= s.maxTiers {
+		return fmt.Errorf("%w (%d)", models.ErrTierLimitReached, s.maxTiers)
 	}
+	return nil
 }
 
-// validateGroupsExist checks if all groups in the provided list exist in the cluster
-func (s *TierService) validateGroupsExist(groups []string) error {
+// NewTierService creates a new TierService instance
+func NewTierService(storage *storage.K8sTierStorage, opts ...TierServiceOption) *TierService {
+	s := &TierService{
+		storage:               storage,
+		groupMatchPolicy:      GroupMatchExact,
+		implicitAuthenticated: true,
+		changes:               newChangeBus(DefaultMaxChangeSubscribers),
+		softDeleteRetention:   DefaultSoftDeleteRetention,
+		maxTiers:              DefaultMaxTiers,
+		now:                   time.Now,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// validateGroupsExist checks if all groups in the provided list exist in the cluster.
+// Wildcard patterns are skipped because they name no single group.
+func (s *TierService) validateGroupsExist(ctx context.Context, groups []string) error {
 	for _, group := range groups {
-		exists, err := s.storage.GroupExists(group)
+		if models.IsGroupPattern(group) {
+			continue
+		}
+		exists, err := s.storage.GroupExists(ctx, group)
 		if err != nil {
 			return fmt.Errorf("failed to check if group %s exists: %w", group, err)
 		}
 		if !exists {
-			return models.ErrGroupNotFoundInCluster
+			return models.NewValueValidationError(models.FieldGroups, group, models.ErrGroupNotFoundInCluster)
 		}
 	}
 	return nil
 }
 
 // CreateTier creates a new tier
-func (s *TierService) CreateTier(tier *models.Tier) error {
+func (s *TierService) CreateTier(ctx context.Context, tier *models.Tier) (err error) {
+	defer s.recordOperation(OperationCreate, &err)
+
 	// Validate tier
 	if err := tier.Validate(); err != nil {
 		return err
 	}
+	// Names are immutable, so the name format only needs checking at creation
+	if err := models.ValidateTierName(tier.Name); err != nil {
+		return models.NewValidationError(models.FieldName, err)
+	}
+	tier.Groups = s.dedupeGroups(tier.Name, tier.Groups)
+	tier.DeletedAt = nil
+	if err := s.checkGroupsRequired(tier.Groups); err != nil {
+		return err
+	}
 
 	// Validate all groups exist in cluster
 	if len(tier.Groups) > 0 {
-		if err := s.validateGroupsExist(tier.Groups); err != nil {
+		if err := s.validateGroupsExist(ctx, tier.Groups); err != nil {
 			return err
 		}
 	}
 
+	unlock := s.storage.LockWrites()
+	defer unlock()
+
 	// Load existing config
-	config, err := s.storage.Load()
+	config, err := s.storage.Load(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
-	// Check if tier already exists
+	// Check if tier already exists. Names are compared case-insensitively so a tier
+	// stored before validation was tightened can't gain a case-variant twin.
 	for _, existingTier := range config.Tiers {
 		if existingTier.Name == tier.Name {
+			if existingTier.IsDeleted() {
+				return models.ErrTierSoftDeleted
+			}
 			return models.ErrTierAlreadyExists
 		}
+		if strings.EqualFold(existingTier.Name, tier.Name) {
+			return fmt.Errorf("%w: %q differs only in case", models.ErrTierAlreadyExists, existingTier.Name)
+		}
+	}
+	if err := s.checkLevel(config.Tiers, tier.Name, tier.Level); err != nil {
+		return err
+	}
+	if err := s.checkTierCapacity(config.Tiers); err != nil {
+		return err
 	}
 
 	// Add new tier
 	config.Tiers = append(config.Tiers, *tier)
 
 	// Save config
-	if err := s.storage.Save(config); err != nil {
+	if err := s.storage.Save(ctx, config); err != nil {
 		return fmt.Errorf("failed to save config: %w", err)
 	}
 
+	s.notifyTier(webhook.ActionCreated, tier.Name, nil, snapshotTier(*tier))
 	return nil
 }
 
-// GetTiers returns all tiers
-func (s *TierService) GetTiers() ([]models.Tier, error) {
-	config, err := s.storage.Load()
+// GetTiers returns all tiers that have not been soft-deleted
+func (s *TierService) GetTiers(ctx context.Context) ([]models.Tier, error) {
+	config, err := s.storage.Load(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load config: %w", err)
 	}
-	return config.Tiers, nil
+	return activeTiers(config.Tiers), nil
 }
 
 // GetTier returns a specific tier by name
-func (s *TierService) GetTier(name string) (*models.Tier, error) {
-	config, err := s.storage.Load()
+func (s *TierService) GetTier(ctx context.Context, name string) (*models.Tier, error) {
+	config, err := s.storage.Load(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load config: %w", err)
 	}
 
 	for _, tier := range config.Tiers {
-		if tier.Name == name {
+		if tier.Name == name && !tier.IsDeleted() {
 			return &tier, nil
 		}
 	}
@@ -110,49 +211,76 @@ func (s *TierService) GetTier(name string) (*models.Tier, error) {
 }
 
 // UpdateTier updates an existing tier
-// Name cannot be changed, but description, level, and groups can be updated
-func (s *TierService) UpdateTier(name string, updates *models.Tier) error {
+// Name cannot be changed, but description, level, and groups can be updated.
+// Only the fields present in updates are changed. A non-empty ifMatch must match the stored
+// tier's ETag, checked under the write lock before any field is applied, or
+// ErrTierETagMismatch is returned.
+func (s *TierService) UpdateTier(ctx context.Context, name string, updates *models.TierUpdate, ifMatch string) (err error) {
+	defer s.recordOperation(OperationUpdate, &err)
+
+	unlock := s.storage.LockWrites()
+	defer unlock()
+
 	// Load existing config
-	config, err := s.storage.Load()
+	config, err := s.storage.Load(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
 	// Find the tier
 	var found bool
+	var before, after *models.Tier
 	for i := range config.Tiers {
-		if config.Tiers[i].Name == name {
+		if config.Tiers[i].Name == name && !config.Tiers[i].IsDeleted() {
 			// Ensure name is not being changed
 			if updates.Name != "" && updates.Name != name {
 				return models.ErrTierNameImmutable
 			}
+			if !models.ETagMatches(ifMatch, config.Tiers[i].ETag()) {
+				return models.ErrTierETagMismatch
+			}
+			before = snapshotTier(config.Tiers[i])
 
 			// Update fields (only if provided)
 			if updates.Description != "" {
 				config.Tiers[i].Description = updates.Description
 			}
-			if updates.Level >= 0 {
-				config.Tiers[i].Level = updates.Level
+			if updates.Level != nil {
+				config.Tiers[i].Level = *updates.Level
 			}
 			if updates.Groups != nil {
 				// Validate all groups before updating
 				for _, group := range updates.Groups {
-					if err := models.ValidateGroupName(group); err != nil {
-						return err
+					if err := models.ValidateTierGroup(group); err != nil {
+						return models.NewValueValidationError(models.FieldGroups, group, err)
 					}
 				}
+				groups := s.dedupeGroups(name, updates.Groups)
 				// Validate all groups exist in cluster
-				if err := s.validateGroupsExist(updates.Groups); err != nil {
+				if err := s.validateGroupsExist(ctx, groups); err != nil {
 					return err
 				}
-				config.Tiers[i].Groups = updates.Groups
+				config.Tiers[i].Groups = groups
+			}
+			if updates.Metadata != nil {
+				config.Tiers[i].Metadata = maps.Clone(updates.Metadata)
+				if len(config.Tiers[i].Metadata) == 0 {
+					config.Tiers[i].Metadata = nil
+				}
 			}
 
 			// Validate updated tier
 			if err := config.Tiers[i].Validate(); err != nil {
 				return err
 			}
+			if err := s.checkGroupsRequired(config.Tiers[i].Groups); err != nil {
+				return err
+			}
+			if err := s.checkLevel(config.Tiers, name, config.Tiers[i].Level); err != nil {
+				return err
+			}
 
+			after = snapshotTier(config.Tiers[i])
 			found = true
 			break
 		}
@@ -163,26 +291,143 @@ func (s *TierService) UpdateTier(name string, updates *models.Tier) error {
 	}
 
 	// Save config
-	if err := s.storage.Save(config); err != nil {
+	if err := s.storage.Save(ctx, config); err != nil {
 		return fmt.Errorf("failed to save config: %w", err)
 	}
 
+	s.notifyTier(webhook.ActionUpdated, name, before, after)
 	return nil
 }
 
-// DeleteTier deletes a tier by name
-func (s *TierService) DeleteTier(name string) error {
+// PatchTier applies a patch document of the given type to an existing tier.
+// The patched tier must keep its name and pass full validation before it is saved.
+// A non-empty ifMatch must match the stored tier's ETag, as for UpdateTier.
+func (s *TierService) PatchTier(ctx context.Context, name, patchType string, patch []byte, ifMatch string) (updated *models.Tier, err error) {
+	defer s.recordOperation(OperationUpdate, &err)
+
+	unlock := s.storage.LockWrites()
+	defer unlock()
+
 	// Load existing config
-	config, err := s.storage.Load()
+	config, err := s.storage.Load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	index := -1
+	for i := range config.Tiers {
+		if config.Tiers[i].Name == name && !config.Tiers[i].IsDeleted() {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return nil, models.ErrTierNotFound
+	}
+	if !models.ETagMatches(ifMatch, config.Tiers[index].ETag()) {
+		return nil, models.ErrTierETagMismatch
+	}
+
+	original, err := json.Marshal(config.Tiers[index])
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal tier: %w", err)
+	}
+
+	patched, err := applyPatch(patchType, original, patch)
+	if err != nil {
+		return nil, err
+	}
+
+	var tier models.Tier
+	if err := json.Unmarshal(patched, &tier); err != nil {
+		return nil, fmt.Errorf("%w: %v", models.ErrInvalidPatch, err)
+	}
+	if tier.Name != name {
+		return nil, models.NewValidationError(models.FieldName, models.ErrTierNameImmutable)
+	}
+	if tier.Groups == nil {
+		tier.Groups = []string{}
+	}
+	tier.Groups = s.dedupeGroups(name, tier.Groups)
+	// Soft-deletion is only changed through DELETE and restore
+	tier.DeletedAt = nil
+
+	// Validate patched tier
+	if err := tier.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.checkGroupsRequired(tier.Groups); err != nil {
+		return nil, err
+	}
+	if err := s.validateGroupsExist(ctx, tier.Groups); err != nil {
+		return nil, err
+	}
+	if err := s.checkLevel(config.Tiers, name, tier.Level); err != nil {
+		return nil, err
+	}
+
+	before := snapshotTier(config.Tiers[index])
+	config.Tiers[index] = tier
+
+	// Save config
+	if err := s.storage.Save(ctx, config); err != nil {
+		return nil, fmt.Errorf("failed to save config: %w", err)
+	}
+
+	s.notifyTier(webhook.ActionUpdated, name, before, snapshotTier(tier))
+	return &tier, nil
+}
+
+// applyPatch applies a JSON Patch or JSON Merge Patch document to a JSON object
+func applyPatch(patchType string, original, patch []byte) ([]byte, error) {
+	switch patchType {
+	case PatchTypeJSON:
+		decoded, err := jsonpatch.DecodePatch(patch)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", models.ErrInvalidPatch, err)
+		}
+		patched, err := decoded.Apply(original)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", models.ErrInvalidPatch, err)
+		}
+		return patched, nil
+	case PatchTypeMerge:
+		patched, err := jsonpatch.MergePatch(original, patch)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", models.ErrInvalidPatch, err)
+		}
+		return patched, nil
+	default:
+		return nil, models.ErrUnsupportedPatchType
+	}
+}
+
+// DeleteTier deletes a tier by name.
+// In soft-delete mode the tier is marked with DeletedAt and kept until purged.
+func (s *TierService) DeleteTier(ctx context.Context, name string) (err error) {
+	defer s.recordOperation(OperationDelete, &err)
+
+	unlock := s.storage.LockWrites()
+	defer unlock()
+
+	// Load existing config
+	config, err := s.storage.Load(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
 	// Find and remove the tier
 	var found bool
+	var before *models.Tier
 	for i, tier := range config.Tiers {
-		if tier.Name == name {
-			config.Tiers = append(config.Tiers[:i], config.Tiers[i+1:]...)
+		if tier.Name == name && !tier.IsDeleted() {
+			before = snapshotTier(tier)
+			if s.softDelete {
+				deletedAt := s.now().UTC()
+				config.Tiers[i].DeletedAt = &deletedAt
+			} else {
+				config.Tiers = append(config.Tiers[:i], config.Tiers[i+1:]...)
+			}
 			found = true
 			break
 		}
@@ -193,48 +438,132 @@ func (s *TierService) DeleteTier(name string) error {
 	}
 
 	// Save config
-	if err := s.storage.Save(config); err != nil {
+	if err := s.storage.Save(ctx, config); err != nil {
 		return fmt.Errorf("failed to save config: %w", err)
 	}
 
+	s.notifyTier(webhook.ActionDeleted, name, before, nil)
 	return nil
 }
 
-// AddGroup adds a group to a tier
-func (s *TierService) AddGroup(tierName, groupName string) error {
+// DeleteTiers deletes the named tiers in a single save and reports each distinct name as
+// deleted or not found. Tiers in referenced, which maps tier names to their service counts,
+// are kept and reported as referenced. In soft-delete mode tiers are marked as in DeleteTier.
+func (s *TierService) DeleteTiers(ctx context.Context, names []string, referenced map[string]int) (response *models.BatchDeleteTiersResponse, err error) {
+	defer s.recordOperation(OperationDelete, &err)
+
+	unlock := s.storage.LockWrites()
+	defer unlock()
+
+	config, err := s.storage.Load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	active := make(map[string]int, len(config.Tiers))
+	for i, tier := range config.Tiers {
+		if !tier.IsDeleted() {
+			active[tier.Name] = i
+		}
+	}
+
+	response = &models.BatchDeleteTiersResponse{Results: []models.BatchDeleteTierResult{}}
+	seen := make(map[string]bool, len(names))
+	deleted := make(map[string]*models.Tier)
+	for _, name := range names {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		result := models.BatchDeleteTierResult{Name: name}
+		i, found := active[name]
+		switch {
+		case !found:
+			result.Status = models.TierStatusNotFound
+			response.NotFound++
+		case referenced[name] > 0:
+			result.Status = models.TierStatusReferenced
+			result.ServiceCount = referenced[name]
+			response.Referenced++
+		default:
+			deleted[name] = snapshotTier(config.Tiers[i])
+			result.Status = models.TierStatusDeleted
+			response.Deleted++
+		}
+		response.Results = append(response.Results, result)
+	}
+	if len(deleted) == 0 {
+		return response, nil
+	}
+
+	remaining := config.Tiers[:0]
+	for _, tier := range config.Tiers {
+		if _, ok := deleted[tier.Name]; ok && !tier.IsDeleted() {
+			if !s.softDelete {
+				continue
+			}
+			deletedAt := s.now().UTC()
+			tier.DeletedAt = &deletedAt
+		}
+		remaining = append(remaining, tier)
+	}
+	config.Tiers = remaining
+
+	if err := s.storage.Save(ctx, config); err != nil {
+		return nil, fmt.Errorf("failed to save config: %w", err)
+	}
+
+	for _, result := range response.Results {
+		if result.Status == models.TierStatusDeleted {
+			s.notifyTier(webhook.ActionDeleted, result.Name, deleted[result.Name], nil)
+		}
+	}
+	return response, nil
+}
+
+// AddGroup adds a group or wildcard group pattern to a tier
+func (s *TierService) AddGroup(ctx context.Context, tierName, groupName string) (err error) {
+	defer s.recordOperation(OperationAddGroup, &err)
+
 	// Validate group name format
-	if err := models.ValidateGroupName(groupName); err != nil {
+	if err := models.ValidateTierGroup(groupName); err != nil {
 		return err
 	}
 
 	// Validate group exists in cluster
-	exists, err := s.storage.GroupExists(groupName)
-	if err != nil {
-		return fmt.Errorf("failed to check if group exists: %w", err)
-	}
-	if !exists {
-		return models.ErrGroupNotFoundInCluster
+	if err := s.validateGroupsExist(ctx, []string{groupName}); err != nil {
+		return err
 	}
 
+	unlock := s.storage.LockWrites()
+	defer unlock()
+
 	// Load existing config
-	config, err := s.storage.Load()
+	config, err := s.storage.Load(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
 	// Find the tier
 	var found bool
+	var before, after *models.Tier
 	for i := range config.Tiers {
-		if config.Tiers[i].Name == tierName {
+		if config.Tiers[i].Name == tierName && !config.Tiers[i].IsDeleted() {
 			// Check if group already exists
 			for _, existingGroup := range config.Tiers[i].Groups {
-				if existingGroup == groupName {
+				if s.groupMatchPolicy.matches(existingGroup, groupName) {
+					if existingGroup != groupName {
+						log.Printf("Tier %s: group %q collides with existing group %q under %s matching", tierName, groupName, existingGroup, s.groupMatchPolicy)
+					}
 					return models.ErrGroupAlreadyExists
 				}
 			}
 
 			// Add the group
+			before = snapshotTier(config.Tiers[i])
 			config.Tiers[i].Groups = append(config.Tiers[i].Groups, groupName)
+			after = snapshotTier(config.Tiers[i])
 			found = true
 			break
 		}
@@ -245,22 +574,28 @@ func (s *TierService) AddGroup(tierName, groupName string) error {
 	}
 
 	// Save config
-	if err := s.storage.Save(config); err != nil {
+	if err := s.storage.Save(ctx, config); err != nil {
 		return fmt.Errorf("failed to save config: %w", err)
 	}
 
+	s.notifyTier(webhook.ActionGroupAdded, tierName, before, after)
 	return nil
 }
 
-// RemoveGroup removes a group from a tier
-func (s *TierService) RemoveGroup(tierName, groupName string) error {
+// RemoveGroup removes a group or wildcard group pattern from a tier
+func (s *TierService) RemoveGroup(ctx context.Context, tierName, groupName string) (err error) {
+	defer s.recordOperation(OperationRemoveGroup, &err)
+
 	// Validate group name format
-	if err := models.ValidateGroupName(groupName); err != nil {
+	if err := models.ValidateTierGroup(groupName); err != nil {
 		return err
 	}
 
+	unlock := s.storage.LockWrites()
+	defer unlock()
+
 	// Load existing config
-	config, err := s.storage.Load()
+	config, err := s.storage.Load(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
@@ -268,13 +603,21 @@ func (s *TierService) RemoveGroup(tierName, groupName string) error {
 	// Find the tier
 	var found bool
 	var groupFound bool
+	var before, after *models.Tier
 	for i := range config.Tiers {
-		if config.Tiers[i].Name == tierName {
+		if config.Tiers[i].Name == tierName && !config.Tiers[i].IsDeleted() {
 			found = true
 			// Find and remove the group
 			for j, group := range config.Tiers[i].Groups {
 				if group == groupName {
+					if len(config.Tiers[i].Groups) == 1 {
+						if err := s.checkGroupsRequired(nil); err != nil {
+							return err
+						}
+					}
+					before = snapshotTier(config.Tiers[i])
 					config.Tiers[i].Groups = append(config.Tiers[i].Groups[:j], config.Tiers[i].Groups[j+1:]...)
+					after = snapshotTier(config.Tiers[i])
 					groupFound = true
 					break
 				}
@@ -292,36 +635,62 @@ func (s *TierService) RemoveGroup(tierName, groupName string) error {
 	}
 
 	// Save config
-	if err := s.storage.Save(config); err != nil {
+	if err := s.storage.Save(ctx, config); err != nil {
 		return fmt.Errorf("failed to save config: %w", err)
 	}
 
+	s.notifyTier(webhook.ActionGroupRemoved, tierName, before, after)
 	return nil
 }
 
-// GetTiersByGroup returns all tiers that contain the specified group
-func (s *TierService) GetTiersByGroup(groupName string) ([]models.Tier, error) {
+// GetTiersByGroup returns all tiers that contain the specified group, either exactly
+// or through a wildcard group pattern
+func (s *TierService) GetTiersByGroup(ctx context.Context, groupName string) ([]models.Tier, error) {
 	// Validate group name format
 	if err := models.ValidateGroupName(groupName); err != nil {
 		return nil, err
 	}
 
 	// Load all tiers
-	config, err := s.storage.Load()
+	config, err := s.storage.Load(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load config: %w", err)
 	}
 
-	// Filter tiers that contain the specified group
-	var matchingTiers []models.Tier
-	for _, tier := range config.Tiers {
+	return tiersWithGroup(activeTiers(config.Tiers), groupName), nil
+}
+
+// FilterTiersByGroup returns the tiers among tiers that contain the specified group,
+// either exactly or through a wildcard group pattern
+func (s *TierService) FilterTiersByGroup(tiers []models.Tier, groupName string) ([]models.Tier, error) {
+	if err := models.ValidateGroupName(groupName); err != nil {
+		return nil, err
+	}
+	return tiersWithGroup(tiers, groupName), nil
+}
+
+// FilterTiersByMetadata returns the tiers among tiers whose metadata matches selector,
+// with metadata keys and values treated as labels
+func (s *TierService) FilterTiersByMetadata(tiers []models.Tier, selector labels.Selector) []models.Tier {
+	matchingTiers := make([]models.Tier, 0)
+	for _, tier := range tiers {
+		if selector.Matches(labels.Set(tier.Metadata)) {
+			matchingTiers = append(matchingTiers, tier)
+		}
+	}
+	return matchingTiers
+}
+
+// tiersWithGroup returns the tiers listing groupName or a pattern matching it
+func tiersWithGroup(tiers []models.Tier, groupName string) []models.Tier {
+	matchingTiers := make([]models.Tier, 0)
+	for _, tier := range tiers {
 		for _, group := range tier.Groups {
-			if group == groupName {
+			if models.GroupMatches(group, groupName) {
 				matchingTiers = append(matchingTiers, tier)
 				break
 			}
 		}
 	}
-
-	return matchingTiers, nil
+	return matchingTiers
 }
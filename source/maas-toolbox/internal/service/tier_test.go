@@ -0,0 +1,169 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maas-toolbox/internal/models"
+	"sync"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestCreateTier_InvalidName(t *testing.T) {
+	tests := []struct {
+		name     string
+		tierName string
+	}{
+		{"spaces", "my tier"},
+		{"uppercase", "Premium"},
+		{"punctuation", "my-tier!"},
+		{"leading hyphen", "-premium"},
+		{"trailing hyphen", "premium-"},
+		{"leading dot", ".premium"},
+		{"trailing underscore", "premium_"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestTierService()
+			err := s.CreateTier(context.Background(), &models.Tier{Name: tt.tierName, Description: "d"})
+			if !errors.Is(err, models.ErrInvalidKubernetesName) {
+				t.Fatalf("CreateTier(%q) error = %v, want %v", tt.tierName, err, models.ErrInvalidKubernetesName)
+			}
+
+			var validationErr *models.ValidationError
+			if !errors.As(err, &validationErr) || validationErr.Field != models.FieldName {
+				t.Errorf("Expected validation error on field %q, got %v", models.FieldName, err)
+			}
+		})
+	}
+}
+
+func TestCreateTier_ValidName(t *testing.T) {
+	s := newTestTierService()
+	if err := s.CreateTier(context.Background(), &models.Tier{Name: "acme-dev.tier_1", Description: "d"}); err != nil {
+		t.Errorf("CreateTier() error = %v", err)
+	}
+}
+
+func TestCreateTier_CaseVariantDuplicate(t *testing.T) {
+	// Seed a mixed-case name directly, as if stored before name validation was tightened
+	s := newSeededTierService(t, []models.Tier{{Name: "Premium", Description: "Premium", Level: 10, Groups: []string{}}})
+
+	err := s.CreateTier(context.Background(), &models.Tier{Name: "premium", Description: "Premium again", Level: 11})
+	if !errors.Is(err, models.ErrTierAlreadyExists) {
+		t.Fatalf("CreateTier() error = %v, want %v", err, models.ErrTierAlreadyExists)
+	}
+
+	tiers, err := s.GetTiers(context.Background())
+	if err != nil {
+		t.Fatalf("GetTiers() error = %v", err)
+	}
+	if len(tiers) != 1 || tiers[0].Name != "Premium" {
+		t.Errorf("Expected only the original Premium tier, got %+v", tiers)
+	}
+}
+
+func TestCreateTier_MaxTiers(t *testing.T) {
+	s := newTestTierService(WithMaxTiers(3))
+	for i := 1; i <= 3; i++ {
+		if err := s.CreateTier(context.Background(), &models.Tier{Name: fmt.Sprintf("tier-%d", i), Description: "d", Level: i}); err != nil {
+			t.Fatalf("CreateTier(tier-%d) error = %v", i, err)
+		}
+	}
+
+	err := s.CreateTier(context.Background(), &models.Tier{Name: "tier-4", Description: "d", Level: 4})
+	if !errors.Is(err, models.ErrTierLimitReached) {
+		t.Fatalf("CreateTier() past the cap error = %v, want %v", err, models.ErrTierLimitReached)
+	}
+
+	// Updating a tier at the cap is still allowed
+	level := 5
+	if err := s.UpdateTier(context.Background(), "tier-1", &models.TierUpdate{Level: &level}, ""); err != nil {
+		t.Errorf("UpdateTier() at the cap error = %v", err)
+	}
+}
+
+func TestAddGroup_Concurrent(t *testing.T) {
+	s, client := newTestTierServiceWithClient()
+	if err := s.CreateTier(context.Background(), &models.Tier{Name: "premium", Description: "Premium", Level: 10}); err != nil {
+		t.Fatalf("CreateTier() error = %v", err)
+	}
+	// Slow down reads so unserialized Load-modify-Save cycles would interleave
+	client.PrependReactor("get", "configmaps", func(k8stesting.Action) (bool, runtime.Object, error) {
+		time.Sleep(time.Millisecond)
+		return false, nil, nil
+	})
+
+	// Wildcard patterns skip the cluster lookup, so many distinct groups can be added
+	const writers = 50
+	var wg sync.WaitGroup
+	errs := make(chan error, writers)
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs <- s.AddGroup(context.Background(), "premium", fmt.Sprintf("team-%d:*", i))
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Errorf("AddGroup() error = %v", err)
+		}
+	}
+
+	tier, err := s.GetTier(context.Background(), "premium")
+	if err != nil {
+		t.Fatalf("GetTier() error = %v", err)
+	}
+	if len(tier.Groups) != writers {
+		t.Errorf("Expected %d groups after concurrent adds, got %d: %v", writers, len(tier.Groups), tier.Groups)
+	}
+}
+
+func TestUpdateTier_OmittedLevelPreserved(t *testing.T) {
+	s := newSeededTierService(t, levelTestTiers)
+
+	if err := s.UpdateTier(context.Background(), "premium", &models.TierUpdate{Description: "Premium plus"}, ""); err != nil {
+		t.Fatalf("UpdateTier() error = %v", err)
+	}
+	tier, err := s.GetTier(context.Background(), "premium")
+	if err != nil {
+		t.Fatalf("GetTier() error = %v", err)
+	}
+	if tier.Description != "Premium plus" || tier.Level != 10 {
+		t.Errorf("Expected description update with level 10 preserved, got %+v", tier)
+	}
+
+	// An explicit zero is applied
+	if err := s.UpdateTier(context.Background(), "premium", &models.TierUpdate{Level: levelPtr(0)}, ""); err != nil {
+		t.Fatalf("UpdateTier() error = %v", err)
+	}
+	if tier, _ := s.GetTier(context.Background(), "premium"); tier.Level != 0 {
+		t.Errorf("Expected explicit level 0 to be applied, got %d", tier.Level)
+	}
+
+	if err := s.UpdateTier(context.Background(), "premium", &models.TierUpdate{Level: levelPtr(-1)}, ""); !errors.Is(err, models.ErrTierLevelInvalid) {
+		t.Errorf("Expected %v for a negative level, got %v", models.ErrTierLevelInvalid, err)
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// DefaultConfigMapCacheResync is the default full resync period of the tier ConfigMap informer
+const DefaultConfigMapCacheResync = 10 * time.Minute
+
+// ConfigMapCache keeps an in-memory copy of the tier ConfigMap, maintained by an informer that
+// lists and watches only that ConfigMap, so external edits reach the cache through the watch.
+// Until the informer has synced, and after a list or watch failure until the informer receives
+// an event or lists again, Get reports the cache as unusable so Load falls back to a live Get.
+type ConfigMapCache struct {
+	informer cache.SharedIndexInformer
+	lister   corelisters.ConfigMapNamespaceLister
+	name     string
+
+	mu      sync.Mutex
+	lastErr error
+	// errVersion is the informer's last synced resourceVersion when lastErr was recorded
+	errVersion string
+}
+
+// NewConfigMapCache creates a ConfigMapCache of the named ConfigMap over client. Call Start to
+// begin listing and watching.
+func NewConfigMapCache(client kubernetes.Interface, namespace, name string, resync time.Duration) *ConfigMapCache {
+	factory := informers.NewSharedInformerFactoryWithOptions(client, resync,
+		informers.WithNamespace(namespace),
+		informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.FieldSelector = fields.OneTermEqualSelector("metadata.name", name).String()
+		}),
+	)
+	configMaps := factory.Core().V1().ConfigMaps()
+	c := &ConfigMapCache{
+		informer: configMaps.Informer(),
+		lister:   configMaps.Lister().ConfigMaps(namespace),
+		name:     name,
+	}
+	if err := c.informer.SetWatchErrorHandler(c.watchError); err != nil {
+		log.Printf("Failed to set ConfigMap informer watch error handler: %v", err)
+	}
+	if _, err := c.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(interface{}) { c.recovered() },
+		UpdateFunc: func(interface{}, interface{}) { c.recovered() },
+		DeleteFunc: func(interface{}) { c.recovered() },
+	}); err != nil {
+		log.Printf("Failed to add ConfigMap informer event handler: %v", err)
+	}
+	return c
+}
+
+// Start runs the informer until stop is closed. It returns immediately.
+func (c *ConfigMapCache) Start(stop <-chan struct{}) {
+	go c.informer.Run(stop)
+}
+
+// watchError records list and watch failures so Get stops serving the possibly stale copy and
+// Check can report why
+func (c *ConfigMapCache) watchError(_ *cache.Reflector, err error) {
+	c.mu.Lock()
+	c.lastErr = err
+	c.errVersion = c.informer.LastSyncResourceVersion()
+	c.mu.Unlock()
+
+	if errors.IsForbidden(err) {
+		log.Printf("ConfigMap informer denied, tier loads use live Gets: %v", err)
+		return
+	}
+	log.Printf("ConfigMap informer list/watch failed: %v", err)
+}
+
+// recovered clears the last list or watch failure once the informer delivers an event again
+func (c *ConfigMapCache) recovered() {
+	c.mu.Lock()
+	c.lastErr = nil
+	c.mu.Unlock()
+}
+
+// failing returns the last list or watch failure, unless the informer has received an event or
+// listed at a new resourceVersion since
+func (c *ConfigMapCache) failing() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.lastErr != nil && c.informer.LastSyncResourceVersion() != c.errVersion {
+		c.lastErr = nil
+	}
+	return c.lastErr
+}
+
+// Get returns the cached ConfigMap, or nil when it does not exist. ok is false when the cache
+// has not synced or list and watch have failed since the last event, in which case the caller
+// should query the API. The returned ConfigMap is shared with the cache and must not be modified.
+func (c *ConfigMapCache) Get() (cm *corev1.ConfigMap, ok bool) {
+	if !c.informer.HasSynced() || c.failing() != nil {
+		return nil, false
+	}
+	cm, err := c.lister.Get(c.name)
+	if errors.IsNotFound(err) {
+		return nil, true
+	}
+	if err != nil {
+		return nil, false
+	}
+	return cm, true
+}
+
+// observe stores cm, just written by Save, so reads see the write before its watch event
+// arrives. It does nothing on a nil cache.
+func (c *ConfigMapCache) observe(cm *corev1.ConfigMap) {
+	if c == nil || !c.informer.HasSynced() {
+		return
+	}
+	if err := c.informer.GetStore().Update(cm); err != nil {
+		log.Printf("Failed to update the ConfigMap cache after a save: %v", err)
+	}
+}
+
+// Check returns an error until the informer has synced and while Get reports a list or watch
+// failure, for use as a readiness check
+func (c *ConfigMapCache) Check() error {
+	err := c.failing()
+	if c.informer.HasSynced() {
+		if err != nil {
+			return fmt.Errorf("ConfigMap cache watch failing: %v", err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("ConfigMap cache not synced: %v", err)
+	}
+	return fmt.Errorf("ConfigMap cache not synced yet")
+}
@@ -0,0 +1,203 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"maas-toolbox/internal/models"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+)
+
+// countConfigMapGets returns the number of live Gets on ConfigMaps made through client
+func countConfigMapGets(client *fake.Clientset) int {
+	count := 0
+	for _, action := range client.Actions() {
+		if action.GetVerb() == "get" && action.GetResource().Resource == "configmaps" {
+			count++
+		}
+	}
+	return count
+}
+
+// startConfigMapCache starts k's ConfigMapCache and waits for it to sync
+func startConfigMapCache(t *testing.T, k *K8sTierStorage) {
+	t.Helper()
+	stop := make(chan struct{})
+	t.Cleanup(func() { close(stop) })
+	k.ConfigMapCache.Start(stop)
+	if !cache.WaitForCacheSync(stop, k.ConfigMapCache.informer.HasSynced) {
+		t.Fatal("ConfigMap cache did not sync")
+	}
+}
+
+func TestLoad_ConfigMapCache(t *testing.T) {
+	client := fake.NewSimpleClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "tier-to-group-mapping", Namespace: "test"},
+		Data:       map[string]string{"tiers": "- name: free\n  description: Free\n  level: 1\n  groups: []"},
+	})
+	k := NewK8sTierStorage(client, nil, "test", "tier-to-group-mapping")
+	k.ConfigMapCache = NewConfigMapCache(client, "test", "tier-to-group-mapping", 0)
+
+	// Before the informer has synced, loads fall back to a live Get
+	if config, err := k.Load(context.Background()); err != nil || len(config.Tiers) != 1 {
+		t.Fatalf("Load() before sync = %+v, %v", config, err)
+	}
+	if countConfigMapGets(client) != 1 {
+		t.Errorf("Expected a live Get before sync, got %d", countConfigMapGets(client))
+	}
+	if err := k.ConfigMapCache.Check(); err == nil {
+		t.Error("Expected Check to fail before sync")
+	}
+
+	startConfigMapCache(t, k)
+	if err := k.ConfigMapCache.Check(); err != nil {
+		t.Errorf("Expected Check to pass after sync, got %v", err)
+	}
+
+	// Cached loads make no API call
+	for i := 0; i < 3; i++ {
+		config, err := k.Load(context.Background())
+		if err != nil || len(config.Tiers) != 1 || config.Tiers[0].Name != "free" {
+			t.Fatalf("Load() after sync = %+v, %v", config, err)
+		}
+	}
+	if countConfigMapGets(client) != 1 {
+		t.Errorf("Expected cached loads to make no Get, got %d", countConfigMapGets(client))
+	}
+
+	// A save is read back immediately
+	config := &models.TierConfig{Tiers: []models.Tier{
+		{Name: "free", Description: "Free", Level: 1, Groups: []string{}},
+		{Name: "premium", Description: "Premium", Level: 10, Groups: []string{}},
+	}}
+	if err := k.Save(context.Background(), config); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	gets := countConfigMapGets(client)
+	if loaded, err := k.Load(context.Background()); err != nil || len(loaded.Tiers) != 2 {
+		t.Errorf("Expected the saved tiers from the cache, got %+v, %v", loaded, err)
+	}
+	if countConfigMapGets(client) != gets {
+		t.Errorf("Expected the load after a save to make no Get, got %d more", countConfigMapGets(client)-gets)
+	}
+}
+
+func TestLoad_ConfigMapCacheExternalEdit(t *testing.T) {
+	client := fake.NewSimpleClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "tier-to-group-mapping", Namespace: "test"},
+		Data:       map[string]string{"tiers": "[]"},
+	})
+	k := NewK8sTierStorage(client, nil, "test", "tier-to-group-mapping")
+	k.ConfigMapCache = NewConfigMapCache(client, "test", "tier-to-group-mapping", 0)
+	startConfigMapCache(t, k)
+
+	// An edit made outside the server reaches the cache through the watch
+	edited := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "tier-to-group-mapping", Namespace: "test"},
+		Data:       map[string]string{"tiers": "- name: free\n  description: Free\n  level: 1"},
+	}
+	if _, err := client.CoreV1().ConfigMaps("test").Update(context.Background(), edited, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("Failed to update ConfigMap: %v", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		config, err := k.Load(context.Background())
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if len(config.Tiers) == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected the external edit to reach the cache, got %+v", config.Tiers)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if countConfigMapGets(client) != 0 {
+		t.Errorf("Expected cached loads to make no Get, got %d", countConfigMapGets(client))
+	}
+}
+
+func TestLoad_ConfigMapCacheWatchFailure(t *testing.T) {
+	client := fake.NewSimpleClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "tier-to-group-mapping", Namespace: "test"},
+		Data:       map[string]string{"tiers": "[]"},
+	})
+	k := NewK8sTierStorage(client, nil, "test", "tier-to-group-mapping")
+	k.ConfigMapCache = NewConfigMapCache(client, "test", "tier-to-group-mapping", 0)
+	startConfigMapCache(t, k)
+
+	// After a watch failure the cached copy may be stale, so loads use a live Get and
+	// readiness fails
+	k.ConfigMapCache.watchError(nil, errors.New("watch closed"))
+	if _, ok := k.ConfigMapCache.Get(); ok {
+		t.Error("Expected the cache to be unusable after a watch failure")
+	}
+	if _, err := k.Load(context.Background()); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if countConfigMapGets(client) != 1 {
+		t.Errorf("Expected a live Get after a watch failure, got %d", countConfigMapGets(client))
+	}
+	if err := k.ConfigMapCache.Check(); err == nil {
+		t.Error("Expected Check to fail after a watch failure")
+	}
+
+	// The next event shows the watch is delivering again
+	edited := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "tier-to-group-mapping", Namespace: "test"},
+		Data:       map[string]string{"tiers": "- name: free\n  description: Free\n  level: 1"},
+	}
+	if _, err := client.CoreV1().ConfigMaps("test").Update(context.Background(), edited, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("Failed to update ConfigMap: %v", err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for k.ConfigMapCache.Check() != nil {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected Check to pass once an event arrives, got %v", k.ConfigMapCache.Check())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	client.ClearActions()
+	if config, err := k.Load(context.Background()); err != nil || len(config.Tiers) != 1 {
+		t.Fatalf("Load() after recovery = %+v, %v", config, err)
+	}
+	if countConfigMapGets(client) != 0 {
+		t.Errorf("Expected cached loads after recovery, got %d Gets", countConfigMapGets(client))
+	}
+}
+
+func TestLoad_ConfigMapCacheMissing(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	k := NewK8sTierStorage(client, nil, "test", "tier-to-group-mapping")
+	k.ConfigMapCache = NewConfigMapCache(client, "test", "tier-to-group-mapping", 0)
+	startConfigMapCache(t, k)
+
+	config, err := k.Load(context.Background())
+	if err != nil || len(config.Tiers) != 0 {
+		t.Fatalf("Load() of a missing ConfigMap = %+v, %v, want empty", config, err)
+	}
+	if countConfigMapGets(client) != 0 {
+		t.Errorf("Expected a cached missing ConfigMap to make no Get, got %d", countConfigMapGets(client))
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"io"
+)
+
+// TiersEncodingKey is the ConfigMap data key recording how the tiers key is encoded.
+// It is absent for plain YAML, the default.
+const TiersEncodingKey = "tiers-encoding"
+
+// TiersEncodingGzipBase64 marks a tiers key holding base64-encoded, gzip-compressed YAML
+const TiersEncodingGzipBase64 = "gzip+base64"
+
+// compressTiers gzips tiersYAML and encodes the result as standard base64
+func compressTiers(tiersYAML string) (string, error) {
+	var buffer bytes.Buffer
+	writer := gzip.NewWriter(&buffer)
+	if _, err := writer.Write([]byte(tiersYAML)); err != nil {
+		return "", err
+	}
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(buffer.Bytes()), nil
+}
+
+// decodeTiers returns the plain YAML of a tiers value stored with encoding.
+// An empty encoding means the value is already plain YAML.
+func decodeTiers(value, encoding string) (string, error) {
+	switch encoding {
+	case "":
+		return value, nil
+	case TiersEncodingGzipBase64:
+		compressed, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return "", fmt.Errorf("failed to decode base64 tiers: %w", err)
+		}
+		reader, err := gzip.NewReader(bytes.NewReader(compressed))
+		if err != nil {
+			return "", fmt.Errorf("failed to decompress tiers: %w", err)
+		}
+		defer reader.Close()
+		plain, err := io.ReadAll(reader)
+		if err != nil {
+			return "", fmt.Errorf("failed to decompress tiers: %w", err)
+		}
+		return string(plain), nil
+	default:
+		return "", fmt.Errorf("unsupported tiers encoding %q", encoding)
+	}
+}
@@ -0,0 +1,97 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+)
+
+// DefaultGroupCacheResync is the default full resync period of the group informer
+const DefaultGroupCacheResync = 10 * time.Minute
+
+// GroupCache keeps an in-memory copy of OpenShift groups, maintained by an informer that lists
+// and watches the group resource (by default user.openshift.io groups). It is eventually consistent with the cluster; until the
+// informer has synced, or while list and watch are failing (e.g. RBAC denies watch), lookups
+// report the cache as unusable so callers fall back to a live Get.
+type GroupCache struct {
+	informer cache.SharedIndexInformer
+
+	mu      sync.Mutex
+	lastErr error
+}
+
+// NewGroupCache creates a GroupCache of resource over client. Call Start to begin listing and watching.
+func NewGroupCache(client dynamic.Interface, resource schema.GroupVersionResource, resync time.Duration) *GroupCache {
+	g := &GroupCache{
+		informer: dynamicinformer.NewFilteredDynamicInformer(client, resource, "", resync, cache.Indexers{}, nil).Informer(),
+	}
+	if err := g.informer.SetWatchErrorHandler(g.watchError); err != nil {
+		log.Printf("Failed to set group informer watch error handler: %v", err)
+	}
+	return g
+}
+
+// Start runs the informer until stop is closed. It returns immediately.
+func (g *GroupCache) Start(stop <-chan struct{}) {
+	go g.informer.Run(stop)
+}
+
+// watchError records list and watch failures so Check can report why the cache is not synced
+func (g *GroupCache) watchError(_ *cache.Reflector, err error) {
+	g.mu.Lock()
+	g.lastErr = err
+	g.mu.Unlock()
+
+	if errors.IsForbidden(err) {
+		log.Printf("Group informer denied, group lookups use live Gets: %v", err)
+		return
+	}
+	log.Printf("Group informer list/watch failed: %v", err)
+}
+
+// Lookup reports whether the group is in the cache. ok is false when the cache has not
+// synced, in which case exists is meaningless and the caller should query the API.
+func (g *GroupCache) Lookup(groupName string) (exists, ok bool) {
+	if !g.informer.HasSynced() {
+		return false, false
+	}
+	_, exists, err := g.informer.GetStore().GetByKey(groupName)
+	if err != nil {
+		return false, false
+	}
+	return exists, true
+}
+
+// Check returns an error until the informer has synced, for use as a readiness check
+func (g *GroupCache) Check() error {
+	if g.informer.HasSynced() {
+		return nil
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.lastErr != nil {
+		return fmt.Errorf("group cache not synced: %v", g.lastErr)
+	}
+	return fmt.Errorf("group cache not synced yet")
+}
@@ -0,0 +1,111 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+)
+
+// countGroupGets returns the number of live Gets on groups made through k's dynamic client
+func countGroupGets(k *K8sTierStorage) int {
+	count := 0
+	for _, action := range k.DynamicClient.(*dynamicfake.FakeDynamicClient).Actions() {
+		if action.GetVerb() == "get" && action.GetResource().Resource == "groups" {
+			count++
+		}
+	}
+	return count
+}
+
+func TestGroupExists_GroupCache(t *testing.T) {
+	k := newUserTestStorage(nil, newTestGroup("premium-users", "alice"))
+	k.GroupCache = NewGroupCache(k.DynamicClient, k.GroupResource, 0)
+
+	// Before the informer has synced, lookups fall back to a live Get
+	if exists, err := k.GroupExists(context.Background(), "premium-users"); err != nil || !exists {
+		t.Fatalf("GroupExists() before sync = %v, %v, want true", exists, err)
+	}
+	if countGroupGets(k) != 1 {
+		t.Errorf("Expected a live Get before sync, got %d", countGroupGets(k))
+	}
+	if err := k.GroupCache.Check(); err == nil {
+		t.Error("Expected Check to fail before sync")
+	}
+
+	stop := make(chan struct{})
+	defer close(stop)
+	k.GroupCache.Start(stop)
+	if !cache.WaitForCacheSync(stop, k.GroupCache.informer.HasSynced) {
+		t.Fatal("Group cache did not sync")
+	}
+	if err := k.GroupCache.Check(); err != nil {
+		t.Errorf("Expected Check to pass after sync, got %v", err)
+	}
+
+	// Cached groups are answered in memory
+	if exists, err := k.GroupExists(context.Background(), "premium-users"); err != nil || !exists {
+		t.Fatalf("GroupExists() after sync = %v, %v, want true", exists, err)
+	}
+	if countGroupGets(k) != 1 {
+		t.Errorf("Expected no further live Get for a cached group, got %d", countGroupGets(k))
+	}
+
+	// Groups missing from the cache are confirmed live
+	if exists, err := k.GroupExists(context.Background(), "new-group"); err != nil || exists {
+		t.Fatalf("GroupExists(new-group) = %v, %v, want false", exists, err)
+	}
+	if countGroupGets(k) != 2 {
+		t.Errorf("Expected a live Get for an uncached group, got %d", countGroupGets(k))
+	}
+}
+
+func TestGroupCache_WatchDenied(t *testing.T) {
+	k := newUserTestStorage(nil, newTestGroup("premium-users", "alice"))
+	k.DynamicClient.(*dynamicfake.FakeDynamicClient).PrependReactor("list", "groups", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewForbidden(schema.GroupResource{Group: "user.openshift.io", Resource: "groups"}, "", nil)
+	})
+	k.GroupCache = NewGroupCache(k.DynamicClient, k.GroupResource, 0)
+
+	stop := make(chan struct{})
+	defer close(stop)
+	k.GroupCache.Start(stop)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		err := k.GroupCache.Check()
+		if err != nil && strings.Contains(err.Error(), "forbidden") {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected Check to report the denied list, got %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// Lookups keep working through live Gets
+	if exists, err := k.GroupExists(context.Background(), "premium-users"); err != nil || !exists {
+		t.Errorf("GroupExists() with a denied informer = %v, %v, want true", exists, err)
+	}
+}
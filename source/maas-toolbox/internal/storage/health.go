@@ -0,0 +1,103 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"maas-toolbox/internal/models"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// configMapWriteVerbs are the verbs Save needs on the tier ConfigMap
+var configMapWriteVerbs = []string{"create", "patch"}
+
+// CheckConfigMapAccess verifies the tier ConfigMap can be read.
+// A missing ConfigMap is not an error because Save creates it on first write.
+func (k *K8sTierStorage) CheckConfigMapAccess() error {
+	_, err := k.Client.CoreV1().ConfigMaps(k.Namespace).Get(context.Background(), k.ConfigMap, metav1.GetOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to get ConfigMap %s/%s: %w", k.Namespace, k.ConfigMap, err)
+	}
+	return nil
+}
+
+// CheckWriteAccess verifies the service account may create and patch the tier ConfigMap.
+// It issues a SelfSubjectAccessReview per verb, so no data is modified.
+func (k *K8sTierStorage) CheckWriteAccess() error {
+	ctx := context.Background()
+
+	for _, verb := range configMapWriteVerbs {
+		attributes := &authorizationv1.ResourceAttributes{
+			Namespace: k.Namespace,
+			Verb:      verb,
+			Resource:  "configmaps",
+		}
+		// Creation is authorized without a resource name
+		if verb != "create" {
+			attributes.Name = k.ConfigMap
+		}
+
+		review := &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{ResourceAttributes: attributes},
+		}
+		result, err := k.Client.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, review, metav1.CreateOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to review %s access on ConfigMap %s/%s: %w", verb, k.Namespace, k.ConfigMap, err)
+		}
+		if !result.Status.Allowed {
+			return fmt.Errorf("%w: %s denied on ConfigMap %s/%s", models.ErrConfigMapWriteDenied, verb, k.Namespace, k.ConfigMap)
+		}
+	}
+	return nil
+}
+
+// CheckGroupsAPI verifies the group resource (by default user.openshift.io groups) is served
+func (k *K8sTierStorage) CheckGroupsAPI() error {
+	return k.checkResourceServed(k.GroupResource)
+}
+
+// CheckUsersAPI verifies the OpenShift users API (user.openshift.io) is served
+func (k *K8sTierStorage) CheckUsersAPI() error {
+	return k.checkResourceServed(openShiftUserResource)
+}
+
+// CheckLLMInferenceServiceAPI verifies the KServe LLMInferenceService CRD (serving.kserve.io) is served
+func (k *K8sTierStorage) CheckLLMInferenceServiceAPI() error {
+	return k.checkResourceServed(llmInferenceServiceResource)
+}
+
+// checkResourceServed uses discovery to verify the API server serves gvr,
+// so a missing CRD or a non-OpenShift cluster is reported by name
+func (k *K8sTierStorage) checkResourceServed(gvr schema.GroupVersionResource) error {
+	groupVersion := gvr.GroupVersion().String()
+	resources, err := k.Client.Discovery().ServerResourcesForGroupVersion(groupVersion)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return fmt.Errorf("%s is not served: API %s not found", gvr.Resource, groupVersion)
+		}
+		return fmt.Errorf("failed to discover %s: %w", groupVersion, err)
+	}
+	for _, resource := range resources.APIResources {
+		if resource.Name == gvr.Resource {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s is not served by API %s", gvr.Resource, groupVersion)
+}
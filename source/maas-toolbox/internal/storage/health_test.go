@@ -0,0 +1,95 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"errors"
+	"maas-toolbox/internal/models"
+	"strings"
+	"testing"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// newAccessReviewClient returns a fake clientset whose access reviews allow only the given verbs
+func newAccessReviewClient(allowed ...string) *fake.Clientset {
+	client := fake.NewSimpleClientset()
+	client.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		for _, verb := range allowed {
+			if review.Spec.ResourceAttributes.Verb == verb {
+				review.Status.Allowed = true
+			}
+		}
+		return true, review, nil
+	})
+	return client
+}
+
+func TestCheckWriteAccess(t *testing.T) {
+	tests := []struct {
+		name    string
+		allowed []string
+		wantErr bool
+	}{
+		{"create and patch allowed", []string{"create", "patch"}, false},
+		{"patch denied", []string{"create", "update"}, true},
+		{"all denied", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k := NewK8sTierStorage(newAccessReviewClient(tt.allowed...), nil, "test", "tier-to-group-mapping")
+			err := k.CheckWriteAccess()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CheckWriteAccess() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !errors.Is(err, models.ErrConfigMapWriteDenied) {
+				t.Errorf("CheckWriteAccess() error = %v, want %v", err, models.ErrConfigMapWriteDenied)
+			}
+		})
+	}
+}
+
+func TestCheckConfigMapAccess_MissingConfigMap(t *testing.T) {
+	k := NewK8sTierStorage(fake.NewSimpleClientset(), nil, "test", "tier-to-group-mapping")
+	if err := k.CheckConfigMapAccess(); err != nil {
+		t.Errorf("CheckConfigMapAccess() error = %v, want nil", err)
+	}
+}
+
+func TestCheckResourceServed(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	client.Resources = []*metav1.APIResourceList{
+		{GroupVersion: "user.openshift.io/v1", APIResources: []metav1.APIResource{{Name: "groups"}}},
+	}
+	k := NewK8sTierStorage(client, nil, "test", "tier-to-group-mapping")
+
+	if err := k.CheckGroupsAPI(); err != nil {
+		t.Errorf("CheckGroupsAPI() error = %v, want nil", err)
+	}
+	// The group version is served but without users
+	if err := k.CheckUsersAPI(); err == nil || !strings.Contains(err.Error(), "users") {
+		t.Errorf("CheckUsersAPI() error = %v, want users reported as not served", err)
+	}
+	// No serving.kserve.io API at all, as on a cluster without KServe
+	if err := k.CheckLLMInferenceServiceAPI(); err == nil || !strings.Contains(err.Error(), "serving.kserve.io") {
+		t.Errorf("CheckLLMInferenceServiceAPI() error = %v, want the missing API named", err)
+	}
+}
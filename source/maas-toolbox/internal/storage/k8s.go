@@ -17,56 +17,121 @@ package storage
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"maas-toolbox/internal/models"
-	"os"
 	"strings"
+	"sync"
 
 	"gopkg.in/yaml.v3"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/rest"
-	"k8s.io/client-go/tools/clientcmd"
 )
 
 // SystemAuthenticatedGroup is the special built-in Kubernetes group that
 // always exists but is not returned by the API, so it requires special handling.
 const SystemAuthenticatedGroup = "system:authenticated"
 
-// K8sTierStorage implements TierStorage using Kubernetes ConfigMap
+// DefaultSpecialGroups are the built-in Kubernetes and OpenShift groups that always exist
+// but are not returned by the user.openshift.io API, so GroupExists accepts them without a lookup
+var DefaultSpecialGroups = []string{
+	SystemAuthenticatedGroup,
+	"system:authenticated:oauth",
+	"system:unauthenticated",
+	"system:serviceaccounts",
+	"system:masters",
+	"system:cluster-admins",
+	"system:cluster-readers",
+}
+
+// K8sTierStorage implements TierStorage using Kubernetes ConfigMap.
+// Client is used for the ConfigMap and access reviews; DynamicClient, built from the same
+// rest.Config, is used for OpenShift groups and users.
+// SpecialGroups are always treated as existing; it defaults to DefaultSpecialGroups.
+// GroupResource is the cluster-scoped resource groups are looked up in; it defaults to
+// DefaultGroupResource, and can point at an identity system other than user.openshift.io.
+// With Compress set, Save stores the tiers gzip-compressed and base64-encoded; Load
+// detects the encoding from TiersEncodingKey either way.
+// With PerTierKeys set, Save stores each tier under its own TierKey instead of the single
+// tiers key; Load reads both layouts, and saving in one layout removes the other's keys.
+// Liveness, when set, records the outcome of every ConfigMap API call.
+// SyncMetrics, when set, records the time of every successful Load and Save.
+// GroupCache, when set, answers GroupExists for groups it holds without an API call.
+// ConfigMapCache, when set and synced, serves Load without an API call; Save still reads and
+// writes the ConfigMap through the API.
+// Writers hold LockWrites around Load-modify-Save; reads take no lock.
 type K8sTierStorage struct {
-	Client    kubernetes.Interface
-	Namespace string
-	ConfigMap string
+	Client         kubernetes.Interface
+	DynamicClient  dynamic.Interface
+	Namespace      string
+	ConfigMap      string
+	SpecialGroups  []string
+	GroupResource  schema.GroupVersionResource
+	Compress       bool
+	PerTierKeys    bool
+	Liveness       *FailureTracker
+	SyncMetrics    *SyncMetrics
+	GroupCache     *GroupCache
+	ConfigMapCache *ConfigMapCache
+
+	writeMu sync.Mutex
 }
 
 // NewK8sTierStorage creates a new K8sTierStorage instance
-func NewK8sTierStorage(client kubernetes.Interface, namespace, configMap string) *K8sTierStorage {
+func NewK8sTierStorage(client kubernetes.Interface, dynamicClient dynamic.Interface, namespace, configMap string) *K8sTierStorage {
 	return &K8sTierStorage{
-		Client:    client,
-		Namespace: namespace,
-		ConfigMap: configMap,
+		Client:        client,
+		DynamicClient: dynamicClient,
+		Namespace:     namespace,
+		ConfigMap:     configMap,
+		SpecialGroups: DefaultSpecialGroups,
+		GroupResource: DefaultGroupResource,
+	}
+}
+
+// ValidateConfigMapRef checks that namespace is a valid namespace name (a DNS-1123 label) and
+// configMap a valid ConfigMap name (a DNS-1123 subdomain), so a misconfigured location fails at
+// startup instead of on the first API call
+func ValidateConfigMapRef(namespace, configMap string) error {
+	if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+		return fmt.Errorf("invalid namespace %q: %s", namespace, strings.Join(errs, "; "))
+	}
+	if errs := validation.IsDNS1123Subdomain(configMap); len(errs) > 0 {
+		return fmt.Errorf("invalid ConfigMap name %q: %s", configMap, strings.Join(errs, "; "))
 	}
+	return nil
+}
+
+// LockWrites serializes writers within this process and returns the function releasing the lock.
+// Hold it from Load through Save so concurrent mutations can't overwrite each other; Save's
+// resourceVersion check covers writers in other processes.
+func (k *K8sTierStorage) LockWrites() func() {
+	k.writeMu.Lock()
+	return k.writeMu.Unlock
 }
 
 // Load retrieves the tier configuration from Kubernetes ConfigMap
-func (k *K8sTierStorage) Load() (*models.TierConfig, error) {
-	ctx := context.Background()
+func (k *K8sTierStorage) Load(ctx context.Context) (*models.TierConfig, error) {
 	log.Printf("Loading ConfigMap: namespace=%s, name=%s", k.Namespace, k.ConfigMap)
 
-	// Get ConfigMap from Kubernetes API
-	cm, err := k.Client.CoreV1().ConfigMaps(k.Namespace).Get(ctx, k.ConfigMap, metav1.GetOptions{})
+	cm, err := k.getConfigMap(ctx)
 	if err != nil {
+		if isNamespaceNotFound(err) {
+			log.Printf("Namespace %s of ConfigMap %s not found", k.Namespace, k.ConfigMap)
+			return nil, fmt.Errorf("%w: %s", models.ErrNamespaceNotFound, k.Namespace)
+		}
 		// If ConfigMap doesn't exist, return empty config
 		if errors.IsNotFound(err) {
 			log.Printf("ConfigMap %s/%s not found, returning empty config", k.Namespace, k.ConfigMap)
-			return &models.TierConfig{Tiers: []models.Tier{}}, nil
+			k.SyncMetrics.record(SyncOperationLoad)
+			return &models.TierConfig{Tiers: []models.Tier{}, Missing: true}, nil
 		}
 		log.Printf("Error getting ConfigMap %s/%s: %v", k.Namespace, k.ConfigMap, err)
 		return nil, fmt.Errorf("failed to get ConfigMap %s/%s: %w", k.Namespace, k.ConfigMap, err)
@@ -75,14 +140,72 @@ func (k *K8sTierStorage) Load() (*models.TierConfig, error) {
 	log.Printf("ConfigMap retrieved successfully")
 
 	// Extract the "tiers" field from data
-	tiersYAML, exists := cm.Data["tiers"]
+	tiers, err := parseTiersKey(cm.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	// Tiers stored one per key, aggregated in key order
+	if hasTierKeys(cm.Data) {
+		keyed, err := loadTierKeys(cm.Data)
+		if err != nil {
+			log.Printf("Failed to parse per-tier keys: %v", err)
+			return nil, err
+		}
+		tiers = mergeTiers(tiers, keyed)
+	} else if _, exists := cm.Data["tiers"]; !exists {
+		log.Printf("ConfigMap %s/%s does not have 'tiers' or '%s*' keys. Available keys: %v", k.Namespace, k.ConfigMap, TierKeyPrefix, getMapKeys(cm.Data))
+	}
+
+	// YAML without a groups field (or a null tiers document) unmarshals to nil slices;
+	// normalize them so every tier serializes groups as []
+	if tiers == nil {
+		tiers = []models.Tier{}
+	}
+	for i := range tiers {
+		if tiers[i].Groups == nil {
+			tiers[i].Groups = []string{}
+		}
+	}
+
+	log.Printf("Successfully loaded %d tiers from ConfigMap", len(tiers))
+	k.SyncMetrics.record(SyncOperationLoad)
+	return &models.TierConfig{Tiers: tiers, ResourceVersion: cm.ResourceVersion}, nil
+}
+
+// getConfigMap returns the tier ConfigMap from ConfigMapCache once it has synced, or from the
+// Kubernetes API otherwise. A ConfigMap missing from the cache is reported as NotFound.
+func (k *K8sTierStorage) getConfigMap(ctx context.Context) (*corev1.ConfigMap, error) {
+	if k.ConfigMapCache != nil {
+		if cm, ok := k.ConfigMapCache.Get(); ok {
+			if cm == nil {
+				return nil, errors.NewNotFound(corev1.Resource("configmaps"), k.ConfigMap)
+			}
+			return cm, nil
+		}
+	}
+	cm, err := k.Client.CoreV1().ConfigMaps(k.Namespace).Get(ctx, k.ConfigMap, metav1.GetOptions{})
+	k.Liveness.Record(err)
+	return cm, err
+}
+
+// parseTiersKey parses the single "tiers" key of data, decoding it per TiersEncodingKey.
+// It returns no tiers when the key is absent or empty.
+func parseTiersKey(data map[string]string) ([]models.Tier, error) {
+	tiersYAML, exists := data["tiers"]
 	if !exists {
-		log.Printf("ConfigMap %s/%s does not have 'tiers' key. Available keys: %v", k.Namespace, k.ConfigMap, getMapKeys(cm.Data))
-		return &models.TierConfig{Tiers: []models.Tier{}}, nil
+		return nil, nil
+	}
+	if encoding := data[TiersEncodingKey]; encoding != "" {
+		log.Printf("Decoding %s tiers (length: %d chars)", encoding, len(tiersYAML))
+		var err error
+		if tiersYAML, err = decodeTiers(tiersYAML, encoding); err != nil {
+			return nil, err
+		}
 	}
 	if tiersYAML == "" || tiersYAML == "[]" {
-		log.Printf("ConfigMap %s/%s has empty 'tiers' field", k.Namespace, k.ConfigMap)
-		return &models.TierConfig{Tiers: []models.Tier{}}, nil
+		log.Printf("ConfigMap has empty 'tiers' field")
+		return nil, nil
 	}
 
 	log.Printf("Parsing tiers YAML (length: %d chars)", len(tiersYAML))
@@ -93,9 +216,21 @@ func (k *K8sTierStorage) Load() (*models.TierConfig, error) {
 		log.Printf("Failed to parse tiers YAML: %v", err)
 		return nil, fmt.Errorf("failed to parse tiers YAML: %w", err)
 	}
+	return tiers, nil
+}
+
+// MarshalYAML encodes v as YAML using the same settings as the stored ConfigMap
+// data: 2-space indentation and no leading document separator.
+func MarshalYAML(v interface{}) ([]byte, error) {
+	var buffer bytes.Buffer
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+	encoder.Close()
 
-	log.Printf("Successfully loaded %d tiers from ConfigMap", len(tiers))
-	return &models.TierConfig{Tiers: tiers}, nil
+	return bytes.TrimPrefix(buffer.Bytes(), []byte("---\n")), nil
 }
 
 // Helper function to get keys from a map for logging
@@ -107,227 +242,182 @@ func getMapKeys(m map[string]string) []string {
 	return keys
 }
 
-// Save persists the tier configuration to Kubernetes ConfigMap
-func (k *K8sTierStorage) Save(config *models.TierConfig) error {
-	ctx := context.Background()
-
-	// Marshal tiers to YAML string with 2-space indentation
-	var tiersBuffer bytes.Buffer
-	tiersEncoder := yaml.NewEncoder(&tiersBuffer)
-	tiersEncoder.SetIndent(2)
-	if err := tiersEncoder.Encode(config.Tiers); err != nil {
-		return fmt.Errorf("failed to marshal tiers: %w", err)
+// Save persists the tier configuration to Kubernetes ConfigMap.
+// When config has a ResourceVersion, the write is conditional on the ConfigMap still being at
+// that version and fails with ErrConcurrentModification otherwise. A config loaded while the
+// ConfigMap did not exist fails the same way if the ConfigMap has been created since. On success
+// config.ResourceVersion is advanced so the same config can be saved again.
+func (k *K8sTierStorage) Save(ctx context.Context, config *models.TierConfig) error {
+
+	// Check whether the ConfigMap exists
+	existing, err := k.Client.CoreV1().ConfigMaps(k.Namespace).Get(ctx, k.ConfigMap, metav1.GetOptions{})
+	k.Liveness.Record(err)
+	var existingData map[string]string
+	if err == nil {
+		existingData = existing.Data
 	}
-	tiersEncoder.Close()
-
-	// Remove document separator and trailing newline if present
-	tiersYAML := tiersBuffer.String()
-	tiersYAML = strings.TrimPrefix(tiersYAML, "---\n")
-	tiersYAML = strings.TrimSuffix(tiersYAML, "\n")
 
-	// Try to get existing ConfigMap
-	cm, err := k.Client.CoreV1().ConfigMaps(k.Namespace).Get(ctx, k.ConfigMap, metav1.GetOptions{})
-	if err != nil {
-		if errors.IsNotFound(err) {
-			// ConfigMap doesn't exist, create it
-			newCM := &corev1.ConfigMap{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      k.ConfigMap,
-					Namespace: k.Namespace,
-					Labels: map[string]string{
-						"app": "tier-to-group-admin",
-					},
-				},
-				Data: map[string]string{
-					"tiers": tiersYAML,
-				},
-			}
+	data, dataErr := k.saveData(config.Tiers, existingData)
+	if dataErr != nil {
+		return dataErr
+	}
 
-			_, err := k.Client.CoreV1().ConfigMaps(k.Namespace).Create(ctx, newCM, metav1.CreateOptions{})
-			if err != nil {
-				return fmt.Errorf("failed to create ConfigMap: %w", err)
+	if err == nil {
+		if config.Missing {
+			return k.concurrentModification()
+		}
+		// Patch only the tier keys so other data keys, labels and annotations added by
+		// operators are left untouched
+		if err := k.patchTiers(ctx, config, data); err != nil {
+			if errors.IsConflict(err) {
+				return k.concurrentModification()
 			}
-			return nil
+			return fmt.Errorf("failed to patch ConfigMap: %w", err)
 		}
+		k.SyncMetrics.record(SyncOperationSave)
+		return nil
+	}
+	if !errors.IsNotFound(err) {
 		return fmt.Errorf("failed to get ConfigMap: %w", err)
 	}
 
-	// Update existing ConfigMap
-	cm.Data["tiers"] = tiersYAML
-	_, err = k.Client.CoreV1().ConfigMaps(k.Namespace).Update(ctx, cm, metav1.UpdateOptions{})
-	if err != nil {
-		return fmt.Errorf("failed to update ConfigMap: %w", err)
+	// ConfigMap doesn't exist, create it
+	newCM := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      k.ConfigMap,
+			Namespace: k.Namespace,
+			Labels: map[string]string{
+				"app": "tier-to-group-admin",
+			},
+		},
+		Data: map[string]string{},
 	}
-
-	return nil
-}
-
-// getRESTConfig creates a REST config for accessing OpenShift resources
-// This uses the same logic as NewKubernetesClient to get the config
-func getRESTConfig() (*rest.Config, error) {
-	// Try in-cluster config first (when running in pod)
-	config, err := rest.InClusterConfig()
-	if err != nil {
-		// Fall back to kubeconfig file (for local development)
-		kubeconfig := os.Getenv("KUBECONFIG")
-		if kubeconfig == "" {
-			kubeconfig = os.Getenv("HOME") + "/.kube/config"
+	for key, value := range data {
+		if value != nil {
+			newCM.Data[key] = *value
 		}
+	}
 
-		config, err = clientcmd.BuildConfigFromFlags("", kubeconfig)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create Kubernetes config: %w", err)
+	created, err := k.Client.CoreV1().ConfigMaps(k.Namespace).Create(ctx, newCM, metav1.CreateOptions{})
+	k.Liveness.Record(err)
+	if err == nil {
+		config.ResourceVersion = created.ResourceVersion
+		config.Missing = false
+		k.ConfigMapCache.observe(created)
+	}
+	if errors.IsAlreadyExists(err) {
+		// Created by someone else since the Get: a config loaded without the ConfigMap would
+		// overwrite their tiers, any other is merged into theirs rather than replacing it
+		if config.Missing {
+			return k.concurrentModification()
 		}
+		err = k.patchTiers(ctx, config, data)
 	}
-	return config, nil
-}
-
-// GroupExists checks if a Group exists in the OpenShift cluster.
-// Groups are cluster-scoped resources in the user.openshift.io/v1 API group.
-// Note: system:authenticated is a special built-in Kubernetes group that
-// always exists but is not returned by the API, so it's handled as a special case.
-func (k *K8sTierStorage) GroupExists(groupName string) (bool, error) {
-	if groupName == SystemAuthenticatedGroup {
-		return true, nil
+	if isNamespaceNotFound(err) {
+		return fmt.Errorf("failed to create ConfigMap: %w: %s", models.ErrNamespaceNotFound, k.Namespace)
 	}
-
-	ctx := context.Background()
-
-	// Get REST config
-	config, err := getRESTConfig()
 	if err != nil {
-		return false, fmt.Errorf("failed to get REST config: %w", err)
+		return fmt.Errorf("failed to create ConfigMap: %w", err)
 	}
 
-	// Create dynamic client for OpenShift resources
-	dynamicClient, err := dynamic.NewForConfig(config)
-	if err != nil {
-		return false, fmt.Errorf("failed to create dynamic client: %w", err)
-	}
+	k.SyncMetrics.record(SyncOperationSave)
+	return nil
+}
 
-	// Define Group resource
-	groupResource := schema.GroupVersionResource{
-		Group:    "user.openshift.io",
-		Version:  "v1",
-		Resource: "groups",
+// isNamespaceNotFound reports whether err is a NotFound for the namespace itself rather than
+// for the ConfigMap, as returned when the namespace is deleted while the server runs
+func isNamespaceNotFound(err error) bool {
+	if !errors.IsNotFound(err) {
+		return false
 	}
-
-	// Try to get the group
-	_, err = dynamicClient.Resource(groupResource).Get(ctx, groupName, metav1.GetOptions{})
-	if err != nil {
-		if errors.IsNotFound(err) {
-			log.Printf("Group %s not found in cluster", groupName)
-			return false, nil
-		}
-		// For other errors (permission denied, etc.), return the error
-		log.Printf("Error checking if group %s exists: %v", groupName, err)
-		return false, fmt.Errorf("failed to check if group exists: %w", err)
+	status, ok := err.(errors.APIStatus)
+	if !ok {
+		return false
 	}
-
-	log.Printf("Group %s exists in cluster", groupName)
-	return true, nil
+	details := status.Status().Details
+	return details != nil && details.Kind == "namespaces"
 }
 
-// ListLLMInferenceServices lists all LLMInferenceService resources across all namespaces
-func ListLLMInferenceServices() ([]*unstructured.Unstructured, error) {
-	ctx := context.Background()
-
-	// Get REST config
-	config, err := getRESTConfig()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get REST config: %w", err)
+// tiersData returns the ConfigMap data keys to write for tiersYAML. A nil value removes the
+// key, so switching back to plain YAML drops the encoding marker.
+func (k *K8sTierStorage) tiersData(tiersYAML string) (map[string]*string, error) {
+	if !k.Compress {
+		return map[string]*string{"tiers": &tiersYAML, TiersEncodingKey: nil}, nil
 	}
-
-	// Create dynamic client
-	dynamicClient, err := dynamic.NewForConfig(config)
+	compressed, err := compressTiers(tiersYAML)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create dynamic client: %w", err)
+		return nil, err
 	}
+	encoding := TiersEncodingGzipBase64
+	return map[string]*string{"tiers": &compressed, TiersEncodingKey: &encoding}, nil
+}
 
-	// Define LLMInferenceService resource
-	llmResource := schema.GroupVersionResource{
-		Group:    "serving.kserve.io",
-		Version:  "v1alpha1",
-		Resource: "llminferenceservices",
+// patchTiers sets the tiers keys of the ConfigMap with a JSON merge patch. A resourceVersion
+// in config makes the patch a precondition the API server rejects with a Conflict if the
+// ConfigMap has changed; the patched version is recorded back into config.
+func (k *K8sTierStorage) patchTiers(ctx context.Context, config *models.TierConfig, data map[string]*string) error {
+	body := map[string]interface{}{"data": data}
+	if config.ResourceVersion != "" {
+		body["metadata"] = map[string]string{"resourceVersion": config.ResourceVersion}
 	}
-
-	// List all LLMInferenceServices across all namespaces
-	list, err := dynamicClient.Resource(llmResource).List(ctx, metav1.ListOptions{})
+	patch, err := json.Marshal(body)
 	if err != nil {
-		log.Printf("Error listing LLMInferenceServices: %v", err)
-		return nil, fmt.Errorf("failed to list LLMInferenceServices: %w", err)
+		return err
 	}
-
-	log.Printf("Found %d LLMInferenceService resources", len(list.Items))
-
-	// Convert items to slice of pointers
-	items := make([]*unstructured.Unstructured, len(list.Items))
-	for i := range list.Items {
-		items[i] = &list.Items[i]
-	}
-
-	return items, nil
-}
-
-// GetLLMInferenceServicesByTier filters LLMInferenceServices by tier annotation
-func GetLLMInferenceServicesByTier(tierName string) ([]*unstructured.Unstructured, error) {
-	// List all LLMInferenceServices
-	allServices, err := ListLLMInferenceServices()
+	patched, err := k.Client.CoreV1().ConfigMaps(k.Namespace).Patch(ctx, k.ConfigMap, types.MergePatchType, patch, metav1.PatchOptions{})
+	k.Liveness.Record(err)
 	if err != nil {
-		return nil, err
+		return err
 	}
+	config.ResourceVersion = patched.ResourceVersion
+	config.Missing = false
+	k.ConfigMapCache.observe(patched)
+	return nil
+}
 
-	var matchingServices []*unstructured.Unstructured
-
-	for _, service := range allServices {
-		// Extract annotations
-		annotations, found, err := unstructured.NestedStringMap(service.Object, "metadata", "annotations")
-		if err != nil {
-			log.Printf("Error extracting annotations from LLMInferenceService %s/%s: %v",
-				getNamespace(service), getName(service), err)
-			continue
-		}
-
-		if !found || annotations == nil {
-			// No annotations, skip
-			continue
-		}
+// concurrentModification reports that the ConfigMap changed since the config was loaded
+func (k *K8sTierStorage) concurrentModification() error {
+	return fmt.Errorf("%w: ConfigMap %s/%s changed since it was loaded", models.ErrConcurrentModification, k.Namespace, k.ConfigMap)
+}
 
-		// Get tiers annotation
-		tiersAnnotation, exists := annotations[models.TierAnnotationKey]
-		if !exists || tiersAnnotation == "" {
-			// No tiers annotation, skip
-			continue
+// IsSpecialGroup returns true if groupName is one of the configured always-valid special groups
+func (k *K8sTierStorage) IsSpecialGroup(groupName string) bool {
+	for _, group := range k.SpecialGroups {
+		if group == groupName {
+			return true
 		}
+	}
+	return false
+}
 
-		// Parse tiers from annotation
-		tiers, err := models.ParseTiersFromAnnotation(tiersAnnotation)
-		if err != nil {
-			log.Printf("Error parsing tiers annotation for LLMInferenceService %s/%s: %v",
-				getNamespace(service), getName(service), err)
-			continue
+// GroupExists checks if a Group exists in the OpenShift cluster.
+// Groups are cluster-scoped GroupResource resources, by default in the user.openshift.io/v1 API group.
+// Note: special groups such as system:authenticated always exist but are not
+// returned by the API, so they are accepted without an API call.
+// With a synced GroupCache, groups in the cache are accepted without an API call too; a group
+// missing from the cache is confirmed with a live Get, since it may have just been created.
+func (k *K8sTierStorage) GroupExists(ctx context.Context, groupName string) (bool, error) {
+	if k.IsSpecialGroup(groupName) {
+		return true, nil
+	}
+	if k.GroupCache != nil {
+		if exists, ok := k.GroupCache.Lookup(groupName); ok && exists {
+			return true, nil
 		}
+	}
 
-		// Check if tier is in the list
-		for _, tier := range tiers {
-			if tier == tierName {
-				matchingServices = append(matchingServices, service)
-				break
-			}
+	// Try to get the group
+	_, err := k.DynamicClient.Resource(k.GroupResource).Get(ctx, groupName, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			log.Printf("Group %s not found in cluster", groupName)
+			return false, nil
 		}
+		// For other errors (permission denied, etc.), return the error
+		log.Printf("Error checking if group %s exists: %v", groupName, err)
+		return false, fmt.Errorf("failed to check if group exists: %w", err)
 	}
 
-	log.Printf("Found %d LLMInferenceService resources with tier %s", len(matchingServices), tierName)
-	return matchingServices, nil
-}
-
-// Helper functions to extract name and namespace from unstructured object
-func getName(obj *unstructured.Unstructured) string {
-	name, _, _ := unstructured.NestedString(obj.Object, "metadata", "name")
-	return name
-}
-
-func getNamespace(obj *unstructured.Unstructured) string {
-	namespace, _, _ := unstructured.NestedString(obj.Object, "metadata", "namespace")
-	return namespace
+	log.Printf("Group %s exists in cluster", groupName)
+	return true, nil
 }
@@ -18,29 +18,51 @@ import (
 	"fmt"
 	"os"
 
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 )
 
-// NewKubernetesClient creates a Kubernetes client using client-go's automatic
-// service account token management. When running in-cluster, it automatically
-// reads and refreshes the service account token from the mounted secret volume.
+// NewKubernetesClients creates a typed Kubernetes clientset and a dynamic client from a
+// single rest.Config, using client-go's automatic service account token management.
+// When running in-cluster, it automatically reads and refreshes the service account
+// token from the mounted secret volume. Sharing one config keeps both clients on the
+// same credentials.
 //
 // Priority order:
 // 1. In-cluster config (automatic service account token management)
 // 2. Kubeconfig file (for local development)
-func NewKubernetesClient() (kubernetes.Interface, error) {
-	var config *rest.Config
-	var err error
+func NewKubernetesClients() (kubernetes.Interface, dynamic.Interface, error) {
+	config, err := newRESTConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Create the clientset
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create Kubernetes client: %w", err)
+	}
+
+	// Create the dynamic client for OpenShift and KServe resources
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create dynamic client: %w", err)
+	}
+
+	return clientset, dynamicClient, nil
+}
 
+// newRESTConfig returns the in-cluster config, falling back to the kubeconfig file
+func newRESTConfig() (*rest.Config, error) {
 	// Try in-cluster config first (when running in pod)
 	// rest.InClusterConfig() automatically:
 	// - Reads service account token from /var/run/secrets/kubernetes.io/serviceaccount/token
 	// - Handles token refresh for projected service account tokens
 	// - Reads CA certificate from /var/run/secrets/kubernetes.io/serviceaccount/ca.crt
 	// - Uses the service account's namespace
-	config, err = rest.InClusterConfig()
+	config, err := rest.InClusterConfig()
 	if err != nil {
 		// Fall back to kubeconfig file (for local development)
 		kubeconfig := os.Getenv("KUBECONFIG")
@@ -53,12 +75,5 @@ func NewKubernetesClient() (kubernetes.Interface, error) {
 			return nil, fmt.Errorf("failed to create Kubernetes config: %w", err)
 		}
 	}
-
-	// Create the clientset
-	clientset, err := kubernetes.NewForConfig(config)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create Kubernetes client: %w", err)
-	}
-
-	return clientset, nil
+	return config, nil
 }
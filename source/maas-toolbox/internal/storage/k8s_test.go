@@ -0,0 +1,529 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maas-toolbox/internal/models"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestSave_PreservesOtherKeysAndLabels(t *testing.T) {
+	client := fake.NewSimpleClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "tier-to-group-mapping",
+			Namespace:   "test",
+			Labels:      map[string]string{"owner": "platform-team"},
+			Annotations: map[string]string{"note": "managed by hand"},
+		},
+		Data: map[string]string{
+			"tiers":    "[]",
+			"settings": "rateLimit: 100",
+		},
+	})
+	k := NewK8sTierStorage(client, nil, "test", "tier-to-group-mapping")
+
+	config := &models.TierConfig{Tiers: []models.Tier{{Name: "free", Description: "Free", Level: 1, Groups: []string{SystemAuthenticatedGroup}}}}
+	if err := k.Save(context.Background(), config); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	cm, err := client.CoreV1().ConfigMaps("test").Get(context.Background(), "tier-to-group-mapping", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get ConfigMap: %v", err)
+	}
+	if cm.Data["settings"] != "rateLimit: 100" {
+		t.Errorf("Expected the settings key to be preserved, got data %v", cm.Data)
+	}
+	if cm.Labels["owner"] != "platform-team" || cm.Annotations["note"] != "managed by hand" {
+		t.Errorf("Expected labels and annotations to be preserved, got %v and %v", cm.Labels, cm.Annotations)
+	}
+
+	loaded, err := k.Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(loaded.Tiers) != 1 || loaded.Tiers[0].Name != "free" {
+		t.Errorf("Expected the saved tier to load back, got %+v", loaded.Tiers)
+	}
+}
+
+func TestSave_NoData(t *testing.T) {
+	// A ConfigMap created without any data must not break the patch
+	client := fake.NewSimpleClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "tier-to-group-mapping", Namespace: "test"},
+	})
+	k := NewK8sTierStorage(client, nil, "test", "tier-to-group-mapping")
+
+	if err := k.Save(context.Background(), &models.TierConfig{Tiers: []models.Tier{{Name: "free", Description: "Free"}}}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	loaded, err := k.Load(context.Background())
+	if err != nil || len(loaded.Tiers) != 1 {
+		t.Errorf("Expected 1 tier after Save, got %+v, %v", loaded, err)
+	}
+}
+
+func TestSave_CreatesConfigMap(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	k := NewK8sTierStorage(client, nil, "test", "tier-to-group-mapping")
+
+	if err := k.Save(context.Background(), &models.TierConfig{Tiers: []models.Tier{{Name: "free", Description: "Free"}}}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	cm, err := client.CoreV1().ConfigMaps("test").Get(context.Background(), "tier-to-group-mapping", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected the ConfigMap to be created: %v", err)
+	}
+	if cm.Labels["app"] != "tier-to-group-admin" || cm.Data["tiers"] == "" {
+		t.Errorf("Unexpected created ConfigMap: labels %v, data %v", cm.Labels, cm.Data)
+	}
+}
+
+func TestSave_ResourceVersionPrecondition(t *testing.T) {
+	client := fake.NewSimpleClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "tier-to-group-mapping", Namespace: "test", ResourceVersion: "7"},
+		Data:       map[string]string{"tiers": "[]"},
+	})
+	// The fake clientset ignores resourceVersion, so emulate the API server's precondition
+	serverVersion := "7"
+	var patchedVersions []string
+	client.PrependReactor("patch", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		var patch struct {
+			Metadata struct {
+				ResourceVersion string `json:"resourceVersion"`
+			} `json:"metadata"`
+		}
+		if err := json.Unmarshal(action.(k8stesting.PatchAction).GetPatch(), &patch); err != nil {
+			return true, nil, err
+		}
+		patchedVersions = append(patchedVersions, patch.Metadata.ResourceVersion)
+		if version := patch.Metadata.ResourceVersion; version != "" && version != serverVersion {
+			return true, nil, apierrors.NewConflict(schema.GroupResource{Resource: "configmaps"}, "tier-to-group-mapping", fmt.Errorf("the object has been modified"))
+		}
+		return false, nil, nil
+	})
+	k := NewK8sTierStorage(client, nil, "test", "tier-to-group-mapping")
+
+	config, err := k.Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if config.ResourceVersion != "7" {
+		t.Fatalf("Expected resourceVersion 7 from Load, got %q", config.ResourceVersion)
+	}
+	config.Tiers = append(config.Tiers, models.Tier{Name: "free", Description: "Free", Groups: []string{}})
+	if err := k.Save(context.Background(), config); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	// Another process writes in between: the stale save is rejected
+	serverVersion = "8"
+	config.Tiers[0].Level = 2
+	if err := k.Save(context.Background(), config); !errors.Is(err, models.ErrConcurrentModification) {
+		t.Errorf("Save() error = %v, want %v", err, models.ErrConcurrentModification)
+	}
+
+	// A config not loaded from the ConfigMap is written unconditionally
+	if err := k.Save(context.Background(), &models.TierConfig{Tiers: []models.Tier{}}); err != nil {
+		t.Errorf("Save() without resourceVersion error = %v", err)
+	}
+
+	if want := []string{"7", "7", ""}; !reflect.DeepEqual(patchedVersions, want) {
+		t.Errorf("Patched resourceVersions = %q, want %q", patchedVersions, want)
+	}
+}
+
+func TestSave_ConfigMapCreatedSinceLoad(t *testing.T) {
+	// Neither case may overwrite the other writer's tiers
+	assertTheirTiers := func(t *testing.T, k *K8sTierStorage) {
+		t.Helper()
+		loaded, err := k.Load(context.Background())
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if len(loaded.Tiers) != 1 || loaded.Tiers[0].Name != "theirs" {
+			t.Errorf("Expected the other writer's tiers to be kept, got %+v", loaded.Tiers)
+		}
+	}
+	theirs := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "tier-to-group-mapping", Namespace: "test"},
+		Data:       map[string]string{"tiers": `[{"name":"theirs","description":"Theirs","level":1,"groups":[]}]`},
+	}
+
+	t.Run("created before the Get", func(t *testing.T) {
+		client := fake.NewSimpleClientset()
+		k := NewK8sTierStorage(client, nil, "test", "tier-to-group-mapping")
+		config, err := k.Load(context.Background())
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+
+		if err := client.Tracker().Add(theirs.DeepCopy()); err != nil {
+			t.Fatalf("Tracker().Add() error = %v", err)
+		}
+		config.Tiers = append(config.Tiers, models.Tier{Name: "free", Description: "Free", Groups: []string{}})
+		if err := k.Save(context.Background(), config); !errors.Is(err, models.ErrConcurrentModification) {
+			t.Errorf("Save() error = %v, want %v", err, models.ErrConcurrentModification)
+		}
+		assertTheirTiers(t, k)
+	})
+
+	t.Run("created between the Get and the Create", func(t *testing.T) {
+		client := fake.NewSimpleClientset()
+		var created bool
+		client.PrependReactor("create", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			if created {
+				return false, nil, nil
+			}
+			created = true
+			if err := client.Tracker().Add(theirs.DeepCopy()); err != nil {
+				return true, nil, err
+			}
+			return true, nil, apierrors.NewAlreadyExists(schema.GroupResource{Resource: "configmaps"}, "tier-to-group-mapping")
+		})
+		k := NewK8sTierStorage(client, nil, "test", "tier-to-group-mapping")
+		config, err := k.Load(context.Background())
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+
+		config.Tiers = append(config.Tiers, models.Tier{Name: "free", Description: "Free", Groups: []string{}})
+		if err := k.Save(context.Background(), config); !errors.Is(err, models.ErrConcurrentModification) {
+			t.Errorf("Save() error = %v, want %v", err, models.ErrConcurrentModification)
+		}
+		assertTheirTiers(t, k)
+	})
+}
+
+func TestNamespaceNotFound(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	namespaceGone := func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewNotFound(schema.GroupResource{Resource: "namespaces"}, "test")
+	}
+	client.PrependReactor("create", "configmaps", namespaceGone)
+	k := NewK8sTierStorage(client, nil, "test", "tier-to-group-mapping")
+
+	// A missing ConfigMap in an existing namespace is an empty config
+	if config, err := k.Load(context.Background()); err != nil || len(config.Tiers) != 0 {
+		t.Fatalf("Load() = %v, %v, want empty config", config, err)
+	}
+	if err := k.Save(context.Background(), &models.TierConfig{Tiers: []models.Tier{{Name: "free"}}}); !errors.Is(err, models.ErrNamespaceNotFound) {
+		t.Errorf("Save() error = %v, want %v", err, models.ErrNamespaceNotFound)
+	}
+
+	client.PrependReactor("get", "configmaps", namespaceGone)
+	if _, err := k.Load(context.Background()); !errors.Is(err, models.ErrNamespaceNotFound) {
+		t.Errorf("Load() error = %v, want %v", err, models.ErrNamespaceNotFound)
+	}
+}
+
+func TestSave_CompressedRoundTrip(t *testing.T) {
+	tiers := make([]models.Tier, 0, 2000)
+	for i := 0; i < 2000; i++ {
+		groups := make([]string, 0, 20)
+		for j := 0; j < 20; j++ {
+			groups = append(groups, fmt.Sprintf("team-%d-group-%d", i, j))
+		}
+		tiers = append(tiers, models.Tier{Name: fmt.Sprintf("tier-%d", i), Description: "Generated tier", Level: i, Groups: groups})
+	}
+	config := &models.TierConfig{Tiers: tiers}
+
+	client := fake.NewSimpleClientset()
+	k := NewK8sTierStorage(client, nil, "test", "tier-to-group-mapping")
+	k.Compress = true
+	if err := k.Save(context.Background(), config); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	cm, err := client.CoreV1().ConfigMaps("test").Get(context.Background(), "tier-to-group-mapping", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get ConfigMap: %v", err)
+	}
+	if cm.Data[TiersEncodingKey] != TiersEncodingGzipBase64 {
+		t.Errorf("Expected encoding marker %q, got data keys %v", TiersEncodingGzipBase64, getMapKeys(cm.Data))
+	}
+	plain, _ := MarshalYAML(tiers)
+	if len(cm.Data["tiers"]) >= len(plain) || len(cm.Data["tiers"]) > 1<<20 {
+		t.Errorf("Expected compressed tiers (%d bytes) to be smaller than plain YAML (%d bytes) and under 1 MiB", len(cm.Data["tiers"]), len(plain))
+	}
+
+	// Load detects the encoding regardless of the Compress setting
+	reader := NewK8sTierStorage(client, nil, "test", "tier-to-group-mapping")
+	loaded, err := reader.Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !reflect.DeepEqual(loaded.Tiers, tiers) {
+		t.Error("Expected compressed tiers to load back identically")
+	}
+
+	// Saving uncompressed again drops the marker
+	if err := reader.Save(context.Background(), config); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	cm, _ = client.CoreV1().ConfigMaps("test").Get(context.Background(), "tier-to-group-mapping", metav1.GetOptions{})
+	if _, ok := cm.Data[TiersEncodingKey]; ok || !strings.HasPrefix(cm.Data["tiers"], "- name: tier-0") {
+		t.Errorf("Expected plain YAML without an encoding marker, got keys %v", getMapKeys(cm.Data))
+	}
+}
+
+func TestLoad_UnsupportedEncoding(t *testing.T) {
+	client := fake.NewSimpleClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "tier-to-group-mapping", Namespace: "test"},
+		Data:       map[string]string{"tiers": "anything", TiersEncodingKey: "zstd"},
+	})
+	k := NewK8sTierStorage(client, nil, "test", "tier-to-group-mapping")
+
+	if _, err := k.Load(context.Background()); err == nil || !strings.Contains(err.Error(), "zstd") {
+		t.Errorf("Expected an unsupported encoding error, got %v", err)
+	}
+}
+
+func TestLoad_NormalizesNilSlices(t *testing.T) {
+	tests := []struct {
+		name      string
+		tiersYAML string
+		wantJSON  string
+	}{
+		{"group-less tier", "- name: free\n  description: Free\n  level: 1", `[{"name":"free","description":"Free","level":1,"groups":[]}]`},
+		{"null tiers", "null", `[]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := fake.NewSimpleClientset(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "tier-to-group-mapping", Namespace: "test"},
+				Data:       map[string]string{"tiers": tt.tiersYAML},
+			})
+			k := NewK8sTierStorage(client, nil, "test", "tier-to-group-mapping")
+
+			config, err := k.Load(context.Background())
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			data, err := json.Marshal(config.Tiers)
+			if err != nil {
+				t.Fatalf("Failed to marshal tiers: %v", err)
+			}
+			if string(data) != tt.wantJSON {
+				t.Errorf("Expected %s, got %s", tt.wantJSON, data)
+			}
+		})
+	}
+}
+
+func TestSave_PerTierKeys(t *testing.T) {
+	client := fake.NewSimpleClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "tier-to-group-mapping", Namespace: "test"},
+		Data:       map[string]string{"other": "keep"},
+	})
+	k := NewK8sTierStorage(client, nil, "test", "tier-to-group-mapping")
+	k.PerTierKeys = true
+
+	config := &models.TierConfig{Tiers: []models.Tier{
+		{Name: "free", Description: "Free", Level: 1, Groups: []string{"system:authenticated"}},
+		{Name: "team:gold", Description: "Gold", Level: 10, Groups: []string{"gold-users"}},
+	}}
+	if err := k.Save(context.Background(), config); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	cm, _ := client.CoreV1().ConfigMaps("test").Get(context.Background(), "tier-to-group-mapping", metav1.GetOptions{})
+	want := map[string]string{
+		"other":          "keep",
+		"tier.free":      "name: free\ndescription: Free\nlevel: 1\ngroups:\n  - system:authenticated",
+		"tier.team_gold": "name: team:gold\ndescription: Gold\nlevel: 10\ngroups:\n  - gold-users",
+	}
+	if !reflect.DeepEqual(cm.Data, want) {
+		t.Errorf("Expected one key per tier, got %#v", cm.Data)
+	}
+
+	// A hand edit to one key is picked up, and removing a tier removes its key
+	cm.Data["tier.free"] = "name: free\ndescription: Free for all\nlevel: 1\ngroups: []"
+	if _, err := client.CoreV1().ConfigMaps("test").Update(context.Background(), cm, metav1.UpdateOptions{}); err != nil {
+		t.Fatalf("Failed to update ConfigMap: %v", err)
+	}
+	loaded, err := k.Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(loaded.Tiers) != 2 || loaded.Tiers[0].Description != "Free for all" || loaded.Tiers[1].Name != "team:gold" {
+		t.Fatalf("Expected the edited free tier and team:gold, got %+v", loaded.Tiers)
+	}
+	loaded.Tiers = loaded.Tiers[:1]
+	if err := k.Save(context.Background(), loaded); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	cm, _ = client.CoreV1().ConfigMaps("test").Get(context.Background(), "tier-to-group-mapping", metav1.GetOptions{})
+	if _, ok := cm.Data["tier.team_gold"]; ok {
+		t.Errorf("Expected the removed tier's key to be deleted, got keys %v", getMapKeys(cm.Data))
+	}
+}
+
+func TestSave_PerTierKeyCollision(t *testing.T) {
+	k := NewK8sTierStorage(fake.NewSimpleClientset(), nil, "test", "tier-to-group-mapping")
+	k.PerTierKeys = true
+
+	config := &models.TierConfig{Tiers: []models.Tier{
+		{Name: "team:gold", Description: "Gold", Level: 1, Groups: []string{}},
+		{Name: "team_gold", Description: "Gold", Level: 2, Groups: []string{}},
+	}}
+	if err := k.Save(context.Background(), config); err == nil || !strings.Contains(err.Error(), "tier.team_gold") {
+		t.Errorf("Expected a key collision error, got %v", err)
+	}
+}
+
+func TestMigrateLayout_RoundTrip(t *testing.T) {
+	tiersYAML := "- name: free\n  description: Free\n  level: 1\n  groups:\n    - system:authenticated\n- name: premium\n  description: Premium\n  level: 10\n  groups:\n    - premium-users"
+	client := fake.NewSimpleClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "tier-to-group-mapping", Namespace: "test"},
+		Data:       map[string]string{"tiers": tiersYAML, "other": "keep"},
+	})
+	k := NewK8sTierStorage(client, nil, "test", "tier-to-group-mapping")
+	before, err := k.Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	// Already in the configured layout: nothing to do
+	if migrated, err := k.MigrateLayout(context.Background()); err != nil || migrated {
+		t.Fatalf("Expected no migration for the single-key layout, got %t, %v", migrated, err)
+	}
+
+	k.PerTierKeys = true
+	if migrated, err := k.MigrateLayout(context.Background()); err != nil || !migrated {
+		t.Fatalf("Expected migration to per-tier keys, got %t, %v", migrated, err)
+	}
+	cm, _ := client.CoreV1().ConfigMaps("test").Get(context.Background(), "tier-to-group-mapping", metav1.GetOptions{})
+	keys := getMapKeys(cm.Data)
+	sort.Strings(keys)
+	if !reflect.DeepEqual(keys, []string{"other", "tier.free", "tier.premium"}) {
+		t.Errorf("Expected per-tier keys replacing tiers, got %v", keys)
+	}
+	if migrated, err := k.MigrateLayout(context.Background()); err != nil || migrated {
+		t.Errorf("Expected a second migration to be a no-op, got %t, %v", migrated, err)
+	}
+
+	k.PerTierKeys = false
+	if migrated, err := k.MigrateLayout(context.Background()); err != nil || !migrated {
+		t.Fatalf("Expected migration back to the tiers key, got %t, %v", migrated, err)
+	}
+	cm, _ = client.CoreV1().ConfigMaps("test").Get(context.Background(), "tier-to-group-mapping", metav1.GetOptions{})
+	if !reflect.DeepEqual(cm.Data, map[string]string{"tiers": tiersYAML, "other": "keep"}) {
+		t.Errorf("Expected the original single-key data back, got %#v", cm.Data)
+	}
+	after, err := k.Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !reflect.DeepEqual(after.Tiers, before.Tiers) {
+		t.Errorf("Expected tiers unchanged by the round trip, got %+v", after.Tiers)
+	}
+}
+
+func TestValidateConfigMapRef(t *testing.T) {
+	tests := []struct {
+		namespace, configMap string
+		wantErr              string
+	}{
+		{"maas-api", "tier-to-group-mapping", ""},
+		{"maas-api", "tiers.v1", ""},
+		{"Maas-API", "tier-to-group-mapping", `invalid namespace "Maas-API"`},
+		{"maas.api", "tier-to-group-mapping", `invalid namespace "maas.api"`},
+		{"", "tier-to-group-mapping", `invalid namespace ""`},
+		{"maas-api", "Tier-To-Group-Mapping", `invalid ConfigMap name "Tier-To-Group-Mapping"`},
+		{"maas-api", "tier_mapping", `invalid ConfigMap name "tier_mapping"`},
+	}
+
+	for _, tt := range tests {
+		err := ValidateConfigMapRef(tt.namespace, tt.configMap)
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("ValidateConfigMapRef(%q, %q) error = %v", tt.namespace, tt.configMap, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("ValidateConfigMapRef(%q, %q) error = %v, want %s", tt.namespace, tt.configMap, err, tt.wantErr)
+		}
+	}
+}
+
+func TestSyncMetrics_RecordsSuccessfulLoadAndSave(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	client := fake.NewSimpleClientset()
+	k := NewK8sTierStorage(client, nil, "test", "tier-to-group-mapping")
+	k.SyncMetrics = NewSyncMetrics(registry)
+	now := time.Unix(1700000000, 0)
+	k.SyncMetrics.now = func() time.Time { return now }
+
+	lastSuccess := func(operation string) float64 {
+		return testutil.ToFloat64(k.SyncMetrics.lastSuccess.WithLabelValues(operation))
+	}
+
+	config, err := k.Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := lastSuccess(SyncOperationLoad); got != 1700000000 {
+		t.Errorf("Expected load timestamp 1700000000, got %v", got)
+	}
+	if got := lastSuccess(SyncOperationSave); got != 0 {
+		t.Errorf("Expected no save timestamp before a save, got %v", got)
+	}
+
+	now = now.Add(time.Minute)
+	config.Tiers = []models.Tier{{Name: "free", Description: "Free", Level: 1, Groups: []string{}}}
+	if err := k.Save(context.Background(), config); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	now = now.Add(time.Minute)
+	if err := k.Save(context.Background(), config); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if got := lastSuccess(SyncOperationSave); got != 1700000120 {
+		t.Errorf("Expected save timestamp to advance to 1700000120, got %v", got)
+	}
+
+	// Failed calls leave the timestamps where they were
+	client.PrependReactor("get", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, fmt.Errorf("connection refused")
+	})
+	now = now.Add(time.Minute)
+	if _, err := k.Load(context.Background()); err == nil {
+		t.Fatal("Expected Load to fail")
+	}
+	if got := lastSuccess(SyncOperationLoad); got != 1700000000 {
+		t.Errorf("Expected a failed load to keep timestamp 1700000000, got %v", got)
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"maas-toolbox/internal/models"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// TierKeyPrefix prefixes the data key of each tier in the per-tier layout, e.g. tier.premium
+const TierKeyPrefix = "tier."
+
+// TierKey returns the data key holding the named tier in the per-tier layout.
+// Colons, valid in tier names but not in ConfigMap keys, become underscores.
+func TierKey(name string) string {
+	return TierKeyPrefix + strings.ReplaceAll(name, ":", "_")
+}
+
+// isTierKey reports whether key holds a tier in the per-tier layout
+func isTierKey(key string) bool {
+	return strings.HasPrefix(key, TierKeyPrefix)
+}
+
+// hasTierKeys reports whether data holds any per-tier keys
+func hasTierKeys(data map[string]string) bool {
+	for key := range data {
+		if isTierKey(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// loadTierKeys parses every per-tier key of data in key order, decoding each value with
+// the ConfigMap's tiers encoding
+func loadTierKeys(data map[string]string) ([]models.Tier, error) {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		if isTierKey(key) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	tiers := make([]models.Tier, 0, len(keys))
+	for _, key := range keys {
+		tierYAML, err := decodeTiers(data[key], data[TiersEncodingKey])
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", key, err)
+		}
+		var tier models.Tier
+		if err := yaml.Unmarshal([]byte(tierYAML), &tier); err != nil {
+			return nil, fmt.Errorf("failed to parse %s YAML: %w", key, err)
+		}
+		tiers = append(tiers, tier)
+	}
+	return tiers, nil
+}
+
+// mergeTiers appends keyed to tiers, with a keyed tier replacing a tiers entry of the same name
+func mergeTiers(tiers, keyed []models.Tier) []models.Tier {
+	if len(tiers) == 0 {
+		return keyed
+	}
+	index := make(map[string]int, len(tiers))
+	for i, tier := range tiers {
+		index[tier.Name] = i
+	}
+	for _, tier := range keyed {
+		if i, ok := index[tier.Name]; ok {
+			tiers[i] = tier
+			continue
+		}
+		tiers = append(tiers, tier)
+	}
+	return tiers
+}
+
+// saveData returns the ConfigMap data keys to write for tiers in the configured layout.
+// Keys of the other layout found in existing are removed, so a save also migrates between layouts.
+func (k *K8sTierStorage) saveData(tiers []models.Tier, existing map[string]string) (map[string]*string, error) {
+	if k.PerTierKeys {
+		return k.perTierData(tiers, existing)
+	}
+
+	// Marshal tiers to YAML string with 2-space indentation
+	tiersBytes, err := MarshalYAML(tiers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal tiers: %w", err)
+	}
+
+	// Remove trailing newline if present
+	data, err := k.tiersData(strings.TrimSuffix(string(tiersBytes), "\n"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode tiers: %w", err)
+	}
+	for key := range existing {
+		if isTierKey(key) {
+			data[key] = nil
+		}
+	}
+	return data, nil
+}
+
+// perTierData returns one data key per tier, removing the tiers key and per-tier keys in
+// existing that no longer hold a tier. The encoding marker applies to every tier key.
+func (k *K8sTierStorage) perTierData(tiers []models.Tier, existing map[string]string) (map[string]*string, error) {
+	data := map[string]*string{"tiers": nil, TiersEncodingKey: nil}
+	if k.Compress {
+		encoding := TiersEncodingGzipBase64
+		data[TiersEncodingKey] = &encoding
+	}
+
+	owners := make(map[string]string, len(tiers))
+	for _, tier := range tiers {
+		key := TierKey(tier.Name)
+		if errs := validation.IsConfigMapKey(key); len(errs) > 0 {
+			return nil, fmt.Errorf("tier %q cannot be stored as ConfigMap key %s: %s", tier.Name, key, strings.Join(errs, "; "))
+		}
+		if owner, ok := owners[key]; ok {
+			return nil, fmt.Errorf("tiers %q and %q both map to ConfigMap key %s", owner, tier.Name, key)
+		}
+		owners[key] = tier.Name
+
+		tierBytes, err := MarshalYAML(tier)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal tier %s: %w", tier.Name, err)
+		}
+		value := strings.TrimSuffix(string(tierBytes), "\n")
+		if k.Compress {
+			if value, err = compressTiers(value); err != nil {
+				return nil, fmt.Errorf("failed to encode tier %s: %w", tier.Name, err)
+			}
+		}
+		data[key] = &value
+	}
+
+	for key := range existing {
+		if _, ok := data[key]; !ok && isTierKey(key) {
+			data[key] = nil
+		}
+	}
+	return data, nil
+}
+
+// MigrateLayout rewrites the ConfigMap in the configured layout when it is stored in the
+// other one, and reports whether it did. A missing ConfigMap, or one already in the
+// configured layout, is left untouched.
+func (k *K8sTierStorage) MigrateLayout(ctx context.Context) (bool, error) {
+	unlock := k.LockWrites()
+	defer unlock()
+
+	cm, err := k.Client.CoreV1().ConfigMaps(k.Namespace).Get(ctx, k.ConfigMap, metav1.GetOptions{})
+	k.Liveness.Record(err)
+	if errors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get ConfigMap %s/%s: %w", k.Namespace, k.ConfigMap, err)
+	}
+
+	_, hasTiersKey := cm.Data["tiers"]
+	if k.PerTierKeys && !hasTiersKey || !k.PerTierKeys && !hasTierKeys(cm.Data) {
+		return false, nil
+	}
+
+	config, err := k.Load(ctx)
+	if err != nil {
+		return false, err
+	}
+	if err := k.Save(ctx, config); err != nil {
+		return false, err
+	}
+	log.Printf("Migrated %d tiers in ConfigMap %s/%s to the %s layout", len(config.Tiers), k.Namespace, k.ConfigMap, k.layoutName())
+	return true, nil
+}
+
+// layoutName describes the configured layout for logging
+func (k *K8sTierStorage) layoutName() string {
+	if k.PerTierKeys {
+		return "per-tier key"
+	}
+	return "single tiers key"
+}
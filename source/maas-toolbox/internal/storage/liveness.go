@@ -0,0 +1,89 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// Defaults for NewFailureTracker
+const (
+	DefaultLivenessFailureThreshold = 5
+	DefaultLivenessFailureWindow    = 2 * time.Minute
+)
+
+// FailureTracker counts consecutive failed Kubernetes API calls so a wedged client, such as one
+// holding a stale token or cut off from the API server, can be reported to the liveness probe.
+// Failures are sustained once at least Threshold have occurred in a row and the first of them
+// is at least Window old; any success resets the count, so transient failures stay live.
+type FailureTracker struct {
+	threshold int
+	window    time.Duration
+	now       func() time.Time
+
+	mu          sync.Mutex
+	consecutive int
+	since       time.Time
+	lastErr     error
+}
+
+// NewFailureTracker creates a FailureTracker reporting failures sustained for threshold
+// consecutive calls over at least window
+func NewFailureTracker(threshold int, window time.Duration) *FailureTracker {
+	return &FailureTracker{threshold: threshold, window: window, now: time.Now}
+}
+
+// Record records the outcome of a Kubernetes API call. Errors that are definitive answers
+// from the API server (not found, conflict, already exists, invalid) count as successes,
+// since they show the client is working. A call cut short by its context, because the client
+// disconnected or the request timed out, says nothing about the client and is ignored.
+func (t *FailureTracker) Record(err error) {
+	if t == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if err == nil || apierrors.IsNotFound(err) || apierrors.IsConflict(err) || apierrors.IsAlreadyExists(err) || apierrors.IsInvalid(err) {
+		t.consecutive = 0
+		t.lastErr = nil
+		return
+	}
+	if t.consecutive == 0 {
+		t.since = t.now()
+	}
+	t.consecutive++
+	t.lastErr = err
+}
+
+// Check returns an error once failures are sustained, for use as a liveness check
+func (t *FailureTracker) Check() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.consecutive < t.threshold {
+		return nil
+	}
+	if elapsed := t.now().Sub(t.since); elapsed < t.window {
+		return nil
+	}
+	return fmt.Errorf("%d consecutive Kubernetes API failures since %s: %v", t.consecutive, t.since.Format(time.RFC3339), t.lastErr)
+}
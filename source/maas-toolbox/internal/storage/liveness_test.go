@@ -0,0 +1,109 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestFailureTracker(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	tracker := NewFailureTracker(3, time.Minute)
+	tracker.now = func() time.Time { return now }
+	unreachable := errors.New("dial tcp 10.0.0.1:443: connect: connection refused")
+
+	// A single transient failure stays live
+	tracker.Record(unreachable)
+	now = now.Add(5 * time.Minute)
+	if err := tracker.Check(); err != nil {
+		t.Errorf("Expected a single failure to stay live, got %v", err)
+	}
+
+	// Enough failures in a row, but not yet over the window, stay live
+	tracker.Record(nil)
+	for i := 0; i < 3; i++ {
+		tracker.Record(unreachable)
+	}
+	if err := tracker.Check(); err != nil {
+		t.Errorf("Expected failures within the window to stay live, got %v", err)
+	}
+
+	// Sustained past the window fails
+	now = now.Add(time.Minute)
+	if err := tracker.Check(); err == nil {
+		t.Error("Expected sustained failures to fail the check")
+	}
+
+	// Definitive API answers show the client works and reset the count
+	tracker.Record(apierrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, "tiers"))
+	if err := tracker.Check(); err != nil {
+		t.Errorf("Expected a NotFound answer to reset liveness, got %v", err)
+	}
+}
+
+func TestFailureTracker_CancelledCalls(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	tracker := NewFailureTracker(3, time.Minute)
+	tracker.now = func() time.Time { return now }
+	unreachable := errors.New("dial tcp 10.0.0.1:443: connect: connection refused")
+
+	// Calls cut short by a disconnected client or a request timeout are not failures
+	for i := 0; i < 5; i++ {
+		tracker.Record(fmt.Errorf("failed to get ConfigMap: %w", context.Canceled))
+		tracker.Record(fmt.Errorf("failed to get ConfigMap: %w", context.DeadlineExceeded))
+	}
+	now = now.Add(5 * time.Minute)
+	if err := tracker.Check(); err != nil {
+		t.Errorf("Expected cancelled calls to stay live, got %v", err)
+	}
+
+	// Nor do they reset sustained failures
+	for i := 0; i < 3; i++ {
+		tracker.Record(unreachable)
+	}
+	now = now.Add(time.Minute)
+	tracker.Record(context.Canceled)
+	if err := tracker.Check(); err == nil {
+		t.Error("Expected a cancelled call not to reset sustained failures")
+	}
+}
+
+func TestK8sTierStorage_RecordsLiveness(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	client.PrependReactor("get", "configmaps", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewUnauthorized("token expired")
+	})
+	k := NewK8sTierStorage(client, nil, "test", "tier-to-group-mapping")
+	k.Liveness = NewFailureTracker(2, 0)
+
+	for i := 0; i < 2; i++ {
+		if _, err := k.Load(context.Background()); err == nil {
+			t.Fatal("Expected Load to fail")
+		}
+	}
+	if err := k.Liveness.Check(); err == nil {
+		t.Error("Expected repeated Load failures to fail liveness")
+	}
+}
@@ -0,0 +1,392 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"maas-toolbox/internal/models"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+// DefaultNamespaceListConcurrency bounds how many namespaces are listed at once when
+// LLMInferenceServiceStorage.Namespaces is set
+const DefaultNamespaceListConcurrency = 4
+
+// LLMInferenceServiceStorage reads and annotates KServe LLMInferenceService resources.
+// With Namespaces set, listing is bounded to those namespaces: each is listed separately, at most
+// ListConcurrency at a time, and the results are merged in Namespaces order, so only namespaced
+// list access is needed. Listing in that mode returns every match as a single page.
+type LLMInferenceServiceStorage struct {
+	Client          dynamic.Interface
+	Namespaces      []string
+	ListConcurrency int
+}
+
+// NewLLMInferenceServiceStorage creates a new LLMInferenceServiceStorage using the given dynamic client
+func NewLLMInferenceServiceStorage(client dynamic.Interface) *LLMInferenceServiceStorage {
+	return &LLMInferenceServiceStorage{Client: client, ListConcurrency: DefaultNamespaceListConcurrency}
+}
+
+// ListLLMInferenceServices lists all LLMInferenceService resources across all namespaces,
+// or across Namespaces when it is set
+func (s *LLMInferenceServiceStorage) ListLLMInferenceServices(ctx context.Context) ([]*unstructured.Unstructured, error) {
+	if len(s.Namespaces) > 0 {
+		return listNamespacedLLMInferenceServices(ctx, s.Client, s.Namespaces, s.ListConcurrency)
+	}
+	items, _, err := listLLMInferenceServices(ctx, s.Client, 0, "")
+	return items, err
+}
+
+// listNamespacedLLMInferenceServices lists the LLMInferenceServices of each namespace, running at most
+// concurrency lists at once, and merges them in namespaces order. Any failed list fails the whole listing.
+func listNamespacedLLMInferenceServices(ctx context.Context, dynamicClient dynamic.Interface, namespaces []string, concurrency int) ([]*unstructured.Unstructured, error) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	results := make([][]*unstructured.Unstructured, len(namespaces))
+	errs := make([]error, len(namespaces))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, namespace := range namespaces {
+		wg.Add(1)
+		go func(i int, namespace string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			list, err := dynamicClient.Resource(llmInferenceServiceResource).Namespace(namespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				log.Printf("Error listing LLMInferenceServices in namespace %s: %v", namespace, err)
+				errs[i] = fmt.Errorf("failed to list LLMInferenceServices in namespace %s: %w", namespace, err)
+				return
+			}
+			items := make([]*unstructured.Unstructured, len(list.Items))
+			for j := range list.Items {
+				items[j] = &list.Items[j]
+			}
+			results[i] = items
+		}(i, namespace)
+	}
+	wg.Wait()
+
+	var items []*unstructured.Unstructured
+	for i := range namespaces {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		items = append(items, results[i]...)
+	}
+	log.Printf("Found %d LLMInferenceService resources in %d namespaces", len(items), len(namespaces))
+	return items, nil
+}
+
+// listLLMInferenceServices lists one page of LLMInferenceService resources across all namespaces.
+// A limit of 0 returns every resource. The returned continue token is empty on the last page.
+func listLLMInferenceServices(ctx context.Context, dynamicClient dynamic.Interface, limit int64, continueToken string) ([]*unstructured.Unstructured, string, error) {
+	list, err := dynamicClient.Resource(llmInferenceServiceResource).List(ctx, metav1.ListOptions{
+		Limit:    limit,
+		Continue: continueToken,
+	})
+	if err != nil {
+		log.Printf("Error listing LLMInferenceServices: %v", err)
+		if continueToken != "" && (errors.IsResourceExpired(err) || errors.IsGone(err) || errors.IsBadRequest(err)) {
+			return nil, "", fmt.Errorf("%w: %v", models.ErrInvalidContinueToken, err)
+		}
+		return nil, "", fmt.Errorf("failed to list LLMInferenceServices: %w", err)
+	}
+
+	log.Printf("Found %d LLMInferenceService resources", len(list.Items))
+
+	// Convert items to slice of pointers
+	items := make([]*unstructured.Unstructured, len(list.Items))
+	for i := range list.Items {
+		items[i] = &list.Items[i]
+	}
+
+	return items, list.GetContinue(), nil
+}
+
+// GetLLMInferenceServicesByTier filters LLMInferenceServices by tier annotation
+func (s *LLMInferenceServiceStorage) GetLLMInferenceServicesByTier(ctx context.Context, tierName string) ([]*unstructured.Unstructured, error) {
+	// List all LLMInferenceServices
+	allServices, err := s.ListLLMInferenceServices(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	matchingServices := filterLLMInferenceServicesByTier(allServices, tierName)
+	log.Printf("Found %d LLMInferenceService resources with tier %s", len(matchingServices), tierName)
+	return matchingServices, nil
+}
+
+// GetLLMInferenceServicesByTierPage lists one page of LLMInferenceServices and filters it by tier annotation.
+// Filtering happens after the API returns the page, so a page may hold fewer than limit matches
+// (even none) while more pages remain; callers should keep going until the continue token is empty.
+// With Namespaces set, every match is returned with no continue token, and a continue token is rejected.
+func (s *LLMInferenceServiceStorage) GetLLMInferenceServicesByTierPage(ctx context.Context, tierName string, limit int64, continueToken string) ([]*unstructured.Unstructured, string, error) {
+	if len(s.Namespaces) > 0 {
+		if continueToken != "" {
+			return nil, "", models.ErrInvalidContinueToken
+		}
+		services, err := s.GetLLMInferenceServicesByTier(ctx, tierName)
+		return services, "", err
+	}
+	return getLLMInferenceServicesByTierPage(ctx, s.Client, tierName, limit, continueToken)
+}
+
+// getLLMInferenceServicesByTierPage lists and filters one page using the given dynamic client
+func getLLMInferenceServicesByTierPage(ctx context.Context, dynamicClient dynamic.Interface, tierName string, limit int64, continueToken string) ([]*unstructured.Unstructured, string, error) {
+	page, next, err := listLLMInferenceServices(ctx, dynamicClient, limit, continueToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	matchingServices := filterLLMInferenceServicesByTier(page, tierName)
+	log.Printf("Found %d LLMInferenceService resources with tier %s in page of %d", len(matchingServices), tierName, len(page))
+	return matchingServices, next, nil
+}
+
+// filterLLMInferenceServicesByTier returns the services whose tier annotation includes tierName
+func filterLLMInferenceServicesByTier(services []*unstructured.Unstructured, tierName string) []*unstructured.Unstructured {
+	var matchingServices []*unstructured.Unstructured
+
+	for _, service := range services {
+		// Extract annotations
+		annotations, found, err := unstructured.NestedStringMap(service.Object, "metadata", "annotations")
+		if err != nil {
+			log.Printf("Error extracting annotations from LLMInferenceService %s/%s: %v",
+				getNamespace(service), getName(service), err)
+			continue
+		}
+
+		if !found || annotations == nil {
+			// No annotations, skip
+			continue
+		}
+
+		// Get tiers annotation
+		tiersAnnotation, exists := annotations[models.TierAnnotationKey]
+		if !exists || tiersAnnotation == "" {
+			// No tiers annotation, skip
+			continue
+		}
+
+		// Parse tiers from annotation
+		tiers, err := models.ParseTiersFromAnnotation(tiersAnnotation)
+		if err != nil {
+			log.Printf("Error parsing tiers annotation for LLMInferenceService %s/%s: %v",
+				getNamespace(service), getName(service), err)
+			continue
+		}
+
+		// Check if tier is in the list
+		for _, tier := range tiers {
+			if tier == tierName {
+				matchingServices = append(matchingServices, service)
+				break
+			}
+		}
+	}
+
+	return matchingServices
+}
+
+// llmInferenceServiceResource is the GVR for LLMInferenceService resources
+var llmInferenceServiceResource = schema.GroupVersionResource{
+	Group:    "serving.kserve.io",
+	Version:  "v1alpha1",
+	Resource: "llminferenceservices",
+}
+
+// GetLLMInferenceService retrieves a single LLMInferenceService by namespace and name.
+// Returns models.ErrLLMServiceNotFound if it does not exist.
+func (s *LLMInferenceServiceStorage) GetLLMInferenceService(ctx context.Context, namespace, name string) (*unstructured.Unstructured, error) {
+	service, err := s.Client.Resource(llmInferenceServiceResource).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			log.Printf("LLMInferenceService %s/%s not found", namespace, name)
+			return nil, models.ErrLLMServiceNotFound
+		}
+		log.Printf("Error getting LLMInferenceService %s/%s: %v", namespace, name, err)
+		return nil, fmt.Errorf("failed to get LLMInferenceService: %w", err)
+	}
+
+	return service, nil
+}
+
+// CreateLLMInferenceService creates a minimal LLMInferenceService serving modelURI, with the
+// tiers annotation set to tiers. Returns models.ErrLLMServiceAlreadyExists if the name is taken
+// and models.ErrLLMNamespaceNotFound if the namespace does not exist.
+func (s *LLMInferenceServiceStorage) CreateLLMInferenceService(ctx context.Context, namespace, name, modelURI string, tiers []string) (*unstructured.Unstructured, error) {
+	annotationValue, err := models.FormatTiersAnnotation(tiers)
+	if err != nil {
+		return nil, err
+	}
+
+	service := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": llmInferenceServiceResource.GroupVersion().String(),
+		"kind":       "LLMInferenceService",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": namespace,
+			"annotations": map[string]interface{}{
+				models.TierAnnotationKey: annotationValue,
+			},
+		},
+		"spec": map[string]interface{}{
+			"model": map[string]interface{}{
+				"uri":  modelURI,
+				"name": name,
+			},
+		},
+	}}
+
+	created, err := s.Client.Resource(llmInferenceServiceResource).Namespace(namespace).Create(ctx, service, metav1.CreateOptions{})
+	if err != nil {
+		switch {
+		case errors.IsAlreadyExists(err):
+			return nil, models.ErrLLMServiceAlreadyExists
+		case errors.IsNotFound(err):
+			return nil, models.ErrLLMNamespaceNotFound
+		}
+		log.Printf("Error creating LLMInferenceService %s/%s: %v", namespace, name, err)
+		return nil, fmt.Errorf("failed to create LLMInferenceService: %w", err)
+	}
+
+	log.Printf("Created LLMInferenceService %s/%s serving %s with tiers annotation %s", namespace, name, modelURI, annotationValue)
+	return created, nil
+}
+
+// UpdateLLMInferenceServiceAnnotation sets the tiers annotation on an LLMInferenceService
+// to the given tier list, preserving all other annotations
+func (s *LLMInferenceServiceStorage) UpdateLLMInferenceServiceAnnotation(ctx context.Context, namespace, name string, tiers []string) (*unstructured.Unstructured, error) {
+	service, err := s.GetLLMInferenceService(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	return s.SetLLMInferenceServiceTiers(ctx, service, tiers)
+}
+
+// SetLLMInferenceServiceTiers sets the tiers annotation on service, as previously read, to the
+// given tier list, preserving all other annotations. The update carries the resourceVersion it
+// was read at, so it fails with models.ErrConcurrentModification if the service changed since.
+func (s *LLMInferenceServiceStorage) SetLLMInferenceServiceTiers(ctx context.Context, service *unstructured.Unstructured, tiers []string) (*unstructured.Unstructured, error) {
+	namespace, name := service.GetNamespace(), service.GetName()
+
+	annotationValue, err := models.FormatTiersAnnotation(tiers)
+	if err != nil {
+		return nil, err
+	}
+
+	service = service.DeepCopy()
+	annotations := service.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[models.TierAnnotationKey] = annotationValue
+	service.SetAnnotations(annotations)
+
+	updated, err := s.Client.Resource(llmInferenceServiceResource).Namespace(namespace).Update(ctx, service, metav1.UpdateOptions{})
+	if err != nil {
+		if errors.IsConflict(err) {
+			return nil, fmt.Errorf("%w: LLMInferenceService %s/%s changed since it was read", models.ErrConcurrentModification, namespace, name)
+		}
+		log.Printf("Error updating LLMInferenceService %s/%s: %v", namespace, name, err)
+		return nil, fmt.Errorf("failed to update LLMInferenceService: %w", err)
+	}
+
+	log.Printf("Updated tiers annotation on LLMInferenceService %s/%s: %s", namespace, name, annotationValue)
+	return updated, nil
+}
+
+// RemoveLLMInferenceServiceAnnotation removes a tier from an LLMInferenceService's tiers annotation,
+// preserving the remaining tiers and all other annotations.
+// Returns models.ErrTierNotInAnnotation if the service does not carry the tier.
+func (s *LLMInferenceServiceStorage) RemoveLLMInferenceServiceAnnotation(ctx context.Context, namespace, name, tierName string) (*unstructured.Unstructured, error) {
+	service, err := s.GetLLMInferenceService(ctx, namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	tiers, err := models.ParseTiersFromAnnotation(service.GetAnnotations()[models.TierAnnotationKey])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", models.ErrInvalidTierAnnotation, err)
+	}
+
+	remaining := make([]string, 0, len(tiers))
+	for _, tier := range tiers {
+		if tier != tierName {
+			remaining = append(remaining, tier)
+		}
+	}
+	if len(remaining) == len(tiers) {
+		return nil, models.ErrTierNotInAnnotation
+	}
+
+	return s.SetLLMInferenceServiceTiers(ctx, service, remaining)
+}
+
+// SetLLMInferenceServiceAnnotations sets the annotations in set and deletes the keys in remove
+// on service, as previously read, preserving all other annotations. The update carries the
+// resourceVersion it was read at, so it fails with models.ErrConcurrentModification if the
+// service changed since.
+func (s *LLMInferenceServiceStorage) SetLLMInferenceServiceAnnotations(ctx context.Context, service *unstructured.Unstructured, set map[string]string, remove []string) (*unstructured.Unstructured, error) {
+	namespace, name := service.GetNamespace(), service.GetName()
+
+	service = service.DeepCopy()
+	annotations := service.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	for key, value := range set {
+		annotations[key] = value
+	}
+	for _, key := range remove {
+		delete(annotations, key)
+	}
+	service.SetAnnotations(annotations)
+
+	updated, err := s.Client.Resource(llmInferenceServiceResource).Namespace(namespace).Update(ctx, service, metav1.UpdateOptions{})
+	if err != nil {
+		if errors.IsConflict(err) {
+			return nil, fmt.Errorf("%w: LLMInferenceService %s/%s changed since it was read", models.ErrConcurrentModification, namespace, name)
+		}
+		log.Printf("Error updating LLMInferenceService %s/%s: %v", namespace, name, err)
+		return nil, fmt.Errorf("failed to update LLMInferenceService: %w", err)
+	}
+
+	log.Printf("Updated annotations on LLMInferenceService %s/%s: set %v, removed %v", namespace, name, set, remove)
+	return updated, nil
+}
+
+// Helper functions to extract name and namespace from unstructured object
+func getName(obj *unstructured.Unstructured) string {
+	name, _, _ := unstructured.NestedString(obj.Object, "metadata", "name")
+	return name
+}
+
+func getNamespace(obj *unstructured.Unstructured) string {
+	namespace, _, _ := unstructured.NestedString(obj.Object, "metadata", "namespace")
+	return namespace
+}
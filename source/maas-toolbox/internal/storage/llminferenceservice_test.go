@@ -0,0 +1,288 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"maas-toolbox/internal/models"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// newTestLLMInferenceService builds an unstructured LLMInferenceService annotated with tiersAnnotation
+func newTestLLMInferenceService(namespace, name, tiersAnnotation string) unstructured.Unstructured {
+	metadata := map[string]interface{}{"name": name, "namespace": namespace}
+	if tiersAnnotation != "" {
+		metadata["annotations"] = map[string]interface{}{models.TierAnnotationKey: tiersAnnotation}
+	}
+	return unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "serving.kserve.io/v1alpha1",
+		"kind":       "LLMInferenceService",
+		"metadata":   metadata,
+	}}
+}
+
+// recordingDynamicClient wraps a dynamic client and records the options of every List call.
+// The fake dynamic client drops Limit and Continue before reactors see them.
+type recordingDynamicClient struct {
+	dynamic.Interface
+	listOptions []metav1.ListOptions
+}
+
+func (c *recordingDynamicClient) Resource(resource schema.GroupVersionResource) dynamic.NamespaceableResourceInterface {
+	return recordingResource{NamespaceableResourceInterface: c.Interface.Resource(resource), client: c}
+}
+
+type recordingResource struct {
+	dynamic.NamespaceableResourceInterface
+	client *recordingDynamicClient
+}
+
+func (r recordingResource) List(ctx context.Context, opts metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+	r.client.listOptions = append(r.client.listOptions, opts)
+	return r.NamespaceableResourceInterface.List(ctx, opts)
+}
+
+// newPagingDynamicClient returns a dynamic client that serves the given pages in order,
+// returning "page-N" as the continue token for every page but the last
+func newPagingDynamicClient(pages [][]unstructured.Unstructured) *recordingDynamicClient {
+	fakeClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{llmInferenceServiceResource: "LLMInferenceServiceList"})
+	index := 0
+	fakeClient.PrependReactor("list", "llminferenceservices", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		list := &unstructured.UnstructuredList{Items: pages[index]}
+		list.SetAPIVersion("serving.kserve.io/v1alpha1")
+		list.SetKind("LLMInferenceServiceList")
+		if index+1 < len(pages) {
+			list.SetContinue("page-" + strconv.Itoa(index+1))
+		}
+		index++
+		return true, list, nil
+	})
+	return &recordingDynamicClient{Interface: fakeClient}
+}
+
+func TestGetLLMInferenceServicesByTierPage(t *testing.T) {
+	pages := [][]unstructured.Unstructured{
+		{
+			newTestLLMInferenceService("ns", "a", `["free"]`),
+			newTestLLMInferenceService("ns", "b", `["premium"]`),
+		},
+		{
+			newTestLLMInferenceService("ns", "c", `["premium"]`),
+			newTestLLMInferenceService("ns", "d", ""),
+		},
+		{
+			newTestLLMInferenceService("ns", "e", `["free","premium"]`),
+		},
+	}
+	client := newPagingDynamicClient(pages)
+
+	var names []string
+	var pageSizes []int
+	continueToken := ""
+	for range pages {
+		services, next, err := getLLMInferenceServicesByTierPage(context.Background(), client, "free", 2, continueToken)
+		if err != nil {
+			t.Fatalf("getLLMInferenceServicesByTierPage() error = %v", err)
+		}
+		pageSizes = append(pageSizes, len(services))
+		for _, service := range services {
+			names = append(names, service.GetName())
+		}
+		if next == "" {
+			break
+		}
+		continueToken = next
+	}
+
+	if want := []string{"a", "e"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("Expected services %v, got %v", want, names)
+	}
+	// The middle page has no matches but still returns a continue token
+	if want := []int{1, 0, 1}; !reflect.DeepEqual(pageSizes, want) {
+		t.Errorf("Expected page sizes %v, got %v", want, pageSizes)
+	}
+
+	// Limit and each continue token must be passed through to the API
+	wantOptions := []metav1.ListOptions{
+		{Limit: 2},
+		{Limit: 2, Continue: "page-1"},
+		{Limit: 2, Continue: "page-2"},
+	}
+	if !reflect.DeepEqual(client.listOptions, wantOptions) {
+		t.Errorf("Expected list options %+v, got %+v", wantOptions, client.listOptions)
+	}
+}
+
+// concurrencyDynamicClient wraps a dynamic client and records the most namespaced List calls in
+// flight at once. Each call is held briefly so overlapping calls are observed.
+type concurrencyDynamicClient struct {
+	dynamic.Interface
+	inFlight, maxInFlight int32
+}
+
+func (c *concurrencyDynamicClient) Resource(resource schema.GroupVersionResource) dynamic.NamespaceableResourceInterface {
+	return concurrencyResource{NamespaceableResourceInterface: c.Interface.Resource(resource), client: c}
+}
+
+type concurrencyResource struct {
+	dynamic.NamespaceableResourceInterface
+	client *concurrencyDynamicClient
+}
+
+func (r concurrencyResource) Namespace(namespace string) dynamic.ResourceInterface {
+	return concurrencyNamespacedResource{ResourceInterface: r.NamespaceableResourceInterface.Namespace(namespace), client: r.client}
+}
+
+type concurrencyNamespacedResource struct {
+	dynamic.ResourceInterface
+	client *concurrencyDynamicClient
+}
+
+func (r concurrencyNamespacedResource) List(ctx context.Context, opts metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+	inFlight := atomic.AddInt32(&r.client.inFlight, 1)
+	defer atomic.AddInt32(&r.client.inFlight, -1)
+	for {
+		highest := atomic.LoadInt32(&r.client.maxInFlight)
+		if inFlight <= highest || atomic.CompareAndSwapInt32(&r.client.maxInFlight, highest, inFlight) {
+			break
+		}
+	}
+	time.Sleep(10 * time.Millisecond)
+	return r.ResourceInterface.List(ctx, opts)
+}
+
+func TestListLLMInferenceServices_Namespaces(t *testing.T) {
+	var objects []runtime.Object
+	for _, namespace := range []string{"team-a", "team-b", "team-c", "team-d", "other"} {
+		for _, name := range []string{"llama", "mistral"} {
+			service := newTestLLMInferenceService(namespace, name, `["free"]`)
+			objects = append(objects, &service)
+		}
+	}
+	fakeClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{llmInferenceServiceResource: "LLMInferenceServiceList"}, objects...)
+	// Without cluster-wide RBAC, only namespaced lists are allowed
+	fakeClient.PrependReactor("list", "llminferenceservices", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetNamespace() == "" {
+			return true, nil, apierrors.NewForbidden(llmInferenceServiceResource.GroupResource(), "", nil)
+		}
+		return false, nil, nil
+	})
+	client := &concurrencyDynamicClient{Interface: fakeClient}
+
+	s := NewLLMInferenceServiceStorage(client)
+	s.Namespaces = []string{"team-d", "team-a", "team-c", "team-b"}
+	s.ListConcurrency = 2
+
+	services, err := s.ListLLMInferenceServices(context.Background())
+	if err != nil {
+		t.Fatalf("ListLLMInferenceServices() error = %v", err)
+	}
+	var got []string
+	for _, service := range services {
+		got = append(got, service.GetNamespace()+"/"+service.GetName())
+	}
+	want := []string{
+		"team-d/llama", "team-d/mistral",
+		"team-a/llama", "team-a/mistral",
+		"team-c/llama", "team-c/mistral",
+		"team-b/llama", "team-b/mistral",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected services %v, got %v", want, got)
+	}
+	if highest := atomic.LoadInt32(&client.maxInFlight); highest < 1 || highest > 2 {
+		t.Errorf("Expected at most 2 concurrent namespace lists, got %d", highest)
+	}
+
+	// Tier pages hold every match at once in namespaced mode
+	page, next, err := s.GetLLMInferenceServicesByTierPage(context.Background(), "free", 1, "")
+	if err != nil || len(page) != len(want) || next != "" {
+		t.Errorf("Expected all %d matches in one page, got %d with continue %q, %v", len(want), len(page), next, err)
+	}
+	if _, _, err := s.GetLLMInferenceServicesByTierPage(context.Background(), "free", 1, "page-1"); !errors.Is(err, models.ErrInvalidContinueToken) {
+		t.Errorf("Expected ErrInvalidContinueToken for a continue token, got %v", err)
+	}
+
+	// Without Namespaces the cluster-wide list is used
+	s.Namespaces = nil
+	if _, err := s.ListLLMInferenceServices(context.Background()); !apierrors.IsForbidden(err) {
+		t.Errorf("Expected the cluster-wide list to be attempted, got %v", err)
+	}
+}
+
+func TestListLLMInferenceServices_NamespaceListFails(t *testing.T) {
+	fakeClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{llmInferenceServiceResource: "LLMInferenceServiceList"})
+	fakeClient.PrependReactor("list", "llminferenceservices", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetNamespace() == "denied" {
+			return true, nil, apierrors.NewForbidden(llmInferenceServiceResource.GroupResource(), "", nil)
+		}
+		return false, nil, nil
+	})
+
+	s := NewLLMInferenceServiceStorage(fakeClient)
+	s.Namespaces = []string{"team-a", "denied"}
+	if _, err := s.ListLLMInferenceServices(context.Background()); !apierrors.IsForbidden(err) || !strings.Contains(err.Error(), "denied") {
+		t.Errorf("Expected the failed namespace's error, got %v", err)
+	}
+}
+
+func TestRemoveLLMInferenceServiceAnnotation_ConcurrentUpdate(t *testing.T) {
+	service := newTestLLMInferenceService("team-a", "llama", `["free","premium"]`)
+	service.SetResourceVersion("5")
+	fakeClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), &service)
+
+	// Another writer adds a tier right after the first read. The fake client ignores
+	// resourceVersion, so emulate the API server's optimistic concurrency check.
+	serverVersion := "5"
+	fakeClient.PrependReactor("get", "llminferenceservices", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if serverVersion == "5" {
+			serverVersion = "6"
+			return false, nil, nil
+		}
+		changed := newTestLLMInferenceService("team-a", "llama", `["free","premium","enterprise"]`)
+		changed.SetResourceVersion(serverVersion)
+		return true, &changed, nil
+	})
+	fakeClient.PrependReactor("update", "llminferenceservices", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		obj := action.(k8stesting.UpdateAction).GetObject().(*unstructured.Unstructured)
+		if obj.GetResourceVersion() != serverVersion {
+			return true, nil, apierrors.NewConflict(llmInferenceServiceResource.GroupResource(), obj.GetName(), errors.New("the object has been modified"))
+		}
+		return false, nil, nil
+	})
+
+	s := NewLLMInferenceServiceStorage(fakeClient)
+	if _, err := s.RemoveLLMInferenceServiceAnnotation(context.Background(), "team-a", "llama", "premium"); !errors.Is(err, models.ErrConcurrentModification) {
+		t.Errorf("RemoveLLMInferenceServiceAnnotation() error = %v, want %v", err, models.ErrConcurrentModification)
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ConfigMapLastSuccessMetric is the name of the gauge holding the Unix time of the last
+// successful ConfigMap load and save, labeled by operation
+const ConfigMapLastSuccessMetric = "maas_toolbox_configmap_last_success_timestamp_seconds"
+
+// Operations labeling the ConfigMap last success gauge
+const (
+	SyncOperationLoad = "load"
+	SyncOperationSave = "save"
+)
+
+// SyncMetrics exports when the tier ConfigMap was last loaded and saved successfully,
+// so an alert can fire when either timestamp goes stale
+type SyncMetrics struct {
+	lastSuccess *prometheus.GaugeVec
+	now         func() time.Time
+}
+
+// NewSyncMetrics creates SyncMetrics and registers its gauge with registerer
+func NewSyncMetrics(registerer prometheus.Registerer) *SyncMetrics {
+	lastSuccess := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: ConfigMapLastSuccessMetric,
+		Help: "Unix time of the last successful tier ConfigMap load or save.",
+	}, []string{"operation"})
+	registerer.MustRegister(lastSuccess)
+	return &SyncMetrics{lastSuccess: lastSuccess, now: time.Now}
+}
+
+// record sets the last success time of operation to now. A nil SyncMetrics records nothing.
+func (m *SyncMetrics) record(operation string) {
+	if m == nil {
+		return
+	}
+	m.lastSuccess.WithLabelValues(operation).Set(float64(m.now().UnixNano()) / float64(time.Second))
+}
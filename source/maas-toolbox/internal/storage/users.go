@@ -0,0 +1,130 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"maas-toolbox/internal/models"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// DefaultGroupResource is the GVR for cluster-scoped OpenShift Group resources, the default
+// K8sTierStorage.GroupResource
+var DefaultGroupResource = schema.GroupVersionResource{
+	Group:    "user.openshift.io",
+	Version:  "v1",
+	Resource: "groups",
+}
+
+// openShiftUserResource is the GVR for cluster-scoped OpenShift User resources
+var openShiftUserResource = schema.GroupVersionResource{
+	Group:    "user.openshift.io",
+	Version:  "v1",
+	Resource: "users",
+}
+
+// ParseGroupResource builds the GVR of the cluster-scoped group resource from its API group,
+// version and plural resource name. The API group may be empty for the core group.
+func ParseGroupResource(group, version, resource string) (schema.GroupVersionResource, error) {
+	if group != "" {
+		if errs := validation.IsDNS1123Subdomain(group); len(errs) > 0 {
+			return schema.GroupVersionResource{}, fmt.Errorf("invalid API group %q: %s", group, strings.Join(errs, "; "))
+		}
+	}
+	if errs := validation.IsDNS1123Label(version); len(errs) > 0 {
+		return schema.GroupVersionResource{}, fmt.Errorf("invalid API version %q: %s", version, strings.Join(errs, "; "))
+	}
+	if errs := validation.IsDNS1123Label(resource); len(errs) > 0 {
+		return schema.GroupVersionResource{}, fmt.Errorf("invalid resource %q: %s", resource, strings.Join(errs, "; "))
+	}
+	return schema.GroupVersionResource{Group: group, Version: version, Resource: resource}, nil
+}
+
+// GetUserGroups returns the names of the OpenShift groups whose users include username.
+// Returns models.ErrUserNotFound if the user does not exist and models.ErrGroupLookupDenied
+// if RBAC forbids reading users or groups.
+func (k *K8sTierStorage) GetUserGroups(ctx context.Context, username string) ([]string, error) {
+	// Confirm the user exists so an unknown user is not reported as having no groups
+	if _, err := k.DynamicClient.Resource(openShiftUserResource).Get(ctx, username, metav1.GetOptions{}); err != nil {
+		if errors.IsNotFound(err) {
+			log.Printf("User %s not found in cluster", username)
+			return nil, models.ErrUserNotFound
+		}
+		if errors.IsForbidden(err) {
+			return nil, fmt.Errorf("%w: %v", models.ErrGroupLookupDenied, err)
+		}
+		return nil, fmt.Errorf("failed to get user %s: %w", username, err)
+	}
+
+	list, err := k.DynamicClient.Resource(k.GroupResource).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		if errors.IsForbidden(err) {
+			return nil, fmt.Errorf("%w: %v", models.ErrGroupLookupDenied, err)
+		}
+		return nil, fmt.Errorf("failed to list groups: %w", err)
+	}
+
+	groups := groupsContainingUser(list.Items, username)
+	log.Printf("User %s is a member of %d groups", username, len(groups))
+	return groups, nil
+}
+
+// ListGroups returns the names of all groups in the cluster, sorted. Special groups
+// are not returned by the API and are not included. Returns models.ErrGroupLookupDenied if RBAC
+// forbids listing groups.
+func (k *K8sTierStorage) ListGroups(ctx context.Context) ([]string, error) {
+	list, err := k.DynamicClient.Resource(k.GroupResource).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		if errors.IsForbidden(err) {
+			return nil, fmt.Errorf("%w: %v", models.ErrGroupLookupDenied, err)
+		}
+		return nil, fmt.Errorf("failed to list groups: %w", err)
+	}
+
+	names := make([]string, 0, len(list.Items))
+	for _, group := range list.Items {
+		names = append(names, group.GetName())
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// groupsContainingUser returns the names of the groups whose users array contains username
+func groupsContainingUser(groups []unstructured.Unstructured, username string) []string {
+	names := []string{}
+	for _, group := range groups {
+		users, _, err := unstructured.NestedStringSlice(group.Object, "users")
+		if err != nil {
+			log.Printf("Error reading users of group %s: %v", group.GetName(), err)
+			continue
+		}
+		for _, user := range users {
+			if user == username {
+				names = append(names, group.GetName())
+				break
+			}
+		}
+	}
+	return names
+}
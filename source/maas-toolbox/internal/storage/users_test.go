@@ -0,0 +1,281 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"maas-toolbox/internal/models"
+	"reflect"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// newTestGroup builds an unstructured OpenShift Group with the given users
+func newTestGroup(name string, users ...interface{}) unstructured.Unstructured {
+	return unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "user.openshift.io/v1",
+		"kind":       "Group",
+		"metadata":   map[string]interface{}{"name": name},
+		"users":      users,
+	}}
+}
+
+func TestGroupsContainingUser(t *testing.T) {
+	groups := []unstructured.Unstructured{
+		newTestGroup("premium-users", "alice", "bob"),
+		newTestGroup("enterprise-users", "bob"),
+		newTestGroup("empty-group"),
+	}
+
+	tests := []struct {
+		user string
+		want []string
+	}{
+		{"alice", []string{"premium-users"}},
+		{"bob", []string{"premium-users", "enterprise-users"}},
+		{"carol", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.user, func(t *testing.T) {
+			if got := groupsContainingUser(groups, tt.user); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("groupsContainingUser(%q) = %v, want %v", tt.user, got, tt.want)
+			}
+		})
+	}
+}
+
+// newUserTestStorage returns storage whose dynamic client holds the given users and groups
+func newUserTestStorage(users []string, groups ...unstructured.Unstructured) *K8sTierStorage {
+	objects := make([]runtime.Object, 0, len(users)+len(groups))
+	for _, user := range users {
+		objects = append(objects, &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "user.openshift.io/v1",
+			"kind":       "User",
+			"metadata":   map[string]interface{}{"name": user},
+		}})
+	}
+	for i := range groups {
+		objects = append(objects, &groups[i])
+	}
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		DefaultGroupResource:  "GroupList",
+		openShiftUserResource: "UserList",
+	}, objects...)
+	return NewK8sTierStorage(fake.NewSimpleClientset(), dynamicClient, "test", "tier-to-group-mapping")
+}
+
+func TestGetUserGroups(t *testing.T) {
+	k := newUserTestStorage([]string{"alice", "carol"},
+		newTestGroup("premium-users", "alice", "bob"),
+		newTestGroup("enterprise-users", "bob"),
+	)
+
+	groups, err := k.GetUserGroups(context.Background(), "alice")
+	if err != nil {
+		t.Fatalf("GetUserGroups() error = %v", err)
+	}
+	if !reflect.DeepEqual(groups, []string{"premium-users"}) {
+		t.Errorf("GetUserGroups(alice) = %v, want [premium-users]", groups)
+	}
+
+	groups, err = k.GetUserGroups(context.Background(), "carol")
+	if err != nil || len(groups) != 0 {
+		t.Errorf("GetUserGroups(carol) = %v, %v, want no groups", groups, err)
+	}
+
+	if _, err := k.GetUserGroups(context.Background(), "dave"); !errors.Is(err, models.ErrUserNotFound) {
+		t.Errorf("GetUserGroups(dave) error = %v, want %v", err, models.ErrUserNotFound)
+	}
+}
+
+func TestGetUserGroups_Forbidden(t *testing.T) {
+	k := newUserTestStorage([]string{"alice"}, newTestGroup("premium-users", "alice"))
+	k.DynamicClient.(*dynamicfake.FakeDynamicClient).PrependReactor("list", "groups", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewForbidden(DefaultGroupResource.GroupResource(), "", errors.New("RBAC: access denied"))
+	})
+
+	if _, err := k.GetUserGroups(context.Background(), "alice"); !errors.Is(err, models.ErrGroupLookupDenied) {
+		t.Errorf("GetUserGroups() error = %v, want %v", err, models.ErrGroupLookupDenied)
+	}
+}
+
+func TestListGroups(t *testing.T) {
+	k := newUserTestStorage(nil, newTestGroup("vip-users"), newTestGroup("premium-users"))
+
+	groups, err := k.ListGroups(context.Background())
+	if err != nil {
+		t.Fatalf("ListGroups() error = %v", err)
+	}
+	if !reflect.DeepEqual(groups, []string{"premium-users", "vip-users"}) {
+		t.Errorf("ListGroups() = %v, want [premium-users vip-users]", groups)
+	}
+
+	k.DynamicClient.(*dynamicfake.FakeDynamicClient).PrependReactor("list", "groups", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewForbidden(DefaultGroupResource.GroupResource(), "", errors.New("RBAC: access denied"))
+	})
+	if _, err := k.ListGroups(context.Background()); !errors.Is(err, models.ErrGroupLookupDenied) {
+		t.Errorf("ListGroups() error = %v, want %v", err, models.ErrGroupLookupDenied)
+	}
+}
+
+func TestGroupExists(t *testing.T) {
+	k := newUserTestStorage(nil, newTestGroup("premium-users"))
+
+	tests := []struct {
+		group string
+		want  bool
+	}{
+		{"premium-users", true},
+		{"missing-group", false},
+		{SystemAuthenticatedGroup, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.group, func(t *testing.T) {
+			got, err := k.GroupExists(context.Background(), tt.group)
+			if err != nil {
+				t.Fatalf("GroupExists() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("GroupExists(%q) = %v, want %v", tt.group, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGroupExists_DefaultSpecialGroups(t *testing.T) {
+	// A nil dynamic client proves special groups never reach the API
+	k := NewK8sTierStorage(fake.NewSimpleClientset(), nil, "test", "tier-to-group-mapping")
+
+	for _, group := range []string{"system:authenticated", "system:unauthenticated", "system:serviceaccounts", "system:masters"} {
+		exists, err := k.GroupExists(context.Background(), group)
+		if err != nil || !exists {
+			t.Errorf("GroupExists(%q) = %v, %v, want true", group, exists, err)
+		}
+	}
+}
+
+func TestGroupExists_CustomSpecialGroups(t *testing.T) {
+	k := newUserTestStorage(nil, newTestGroup("premium-users"))
+	k.SpecialGroups = []string{"system:serviceaccounts:models"}
+
+	tests := []struct {
+		group string
+		want  bool
+	}{
+		{"system:serviceaccounts:models", true},
+		{"premium-users", true},
+		// No longer special, and not in the fake cluster
+		{"system:unauthenticated", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.group, func(t *testing.T) {
+			got, err := k.GroupExists(context.Background(), tt.group)
+			if err != nil {
+				t.Fatalf("GroupExists() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("GroupExists(%q) = %v, want %v", tt.group, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseGroupResource(t *testing.T) {
+	tests := []struct {
+		name                     string
+		group, version, resource string
+		wantErr                  bool
+	}{
+		{"default", "user.openshift.io", "v1", "groups", false},
+		{"custom", "identity.example.com", "v1beta1", "teams", false},
+		{"core group", "", "v1", "groups", false},
+		{"invalid group", "Identity_Example", "v1", "groups", true},
+		{"missing version", "identity.example.com", "", "teams", true},
+		{"invalid resource", "identity.example.com", "v1", "Teams", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gvr, err := ParseGroupResource(tt.group, tt.version, tt.resource)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseGroupResource() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			want := schema.GroupVersionResource{Group: tt.group, Version: tt.version, Resource: tt.resource}
+			if !tt.wantErr && gvr != want {
+				t.Errorf("ParseGroupResource() = %v, want %v", gvr, want)
+			}
+		})
+	}
+}
+
+func TestCustomGroupResource(t *testing.T) {
+	teams := schema.GroupVersionResource{Group: "identity.example.com", Version: "v1beta1", Resource: "teams"}
+	team := func(name string) runtime.Object {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "identity.example.com/v1beta1",
+			"kind":       "Team",
+			"metadata":   map[string]interface{}{"name": name},
+		}}
+	}
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		teams:                "TeamList",
+		DefaultGroupResource: "GroupList",
+	}, team("ml-team"), team("data-team"), &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "user.openshift.io/v1",
+		"kind":       "Group",
+		"metadata":   map[string]interface{}{"name": "openshift-only"},
+	}})
+	client := fake.NewSimpleClientset()
+	client.Resources = []*metav1.APIResourceList{
+		{GroupVersion: "identity.example.com/v1beta1", APIResources: []metav1.APIResource{{Name: "teams"}}},
+	}
+	k := NewK8sTierStorage(client, dynamicClient, "test", "tier-to-group-mapping")
+	k.GroupResource = teams
+
+	if exists, err := k.GroupExists(context.Background(), "ml-team"); err != nil || !exists {
+		t.Errorf("GroupExists(ml-team) = %v, %v, want true", exists, err)
+	}
+	// Groups are no longer looked up in user.openshift.io
+	if exists, err := k.GroupExists(context.Background(), "openshift-only"); err != nil || exists {
+		t.Errorf("GroupExists(openshift-only) = %v, %v, want false", exists, err)
+	}
+	groups, err := k.ListGroups(context.Background())
+	if err != nil {
+		t.Fatalf("ListGroups() error = %v", err)
+	}
+	if !reflect.DeepEqual(groups, []string{"data-team", "ml-team"}) {
+		t.Errorf("ListGroups() = %v, want [data-team ml-team]", groups)
+	}
+	if err := k.CheckGroupsAPI(); err != nil {
+		t.Errorf("CheckGroupsAPI() error = %v, want nil", err)
+	}
+
+	k.GroupResource = DefaultGroupResource
+	if err := k.CheckGroupsAPI(); err == nil {
+		t.Error("CheckGroupsAPI() error = nil, want user.openshift.io reported as not served")
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package webhook delivers best-effort change notifications to an external URL.
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+)
+
+// Event actions
+const (
+	ActionCreated           = "created"
+	ActionUpdated           = "updated"
+	ActionDeleted           = "deleted"
+	ActionGroupAdded        = "group-added"
+	ActionGroupRemoved      = "group-removed"
+	ActionTierAnnotated     = "tier-annotated"
+	ActionTierRemoved       = "tier-removed"
+	ActionTiersCleared      = "tiers-cleared"
+	ActionAllTiersAnnotated = "all-tiers-annotated"
+	ActionRestored          = "restored"
+	ActionPurged            = "purged"
+)
+
+// Event resource kinds
+const (
+	ResourceTier                = "tier"
+	ResourceLLMInferenceService = "llminferenceservice"
+)
+
+// SignatureHeader carries the HMAC-SHA256 signature of the request body when a secret is configured
+const SignatureHeader = "X-MaaS-Signature"
+
+// Event describes a change to a tier or an LLMInferenceService tier annotation
+type Event struct {
+	Action    string      `json:"action"`              // What happened, e.g. "created"
+	Resource  string      `json:"resource"`            // "tier" or "llminferenceservice"
+	Name      string      `json:"name"`                // Name of the changed resource
+	Namespace string      `json:"namespace,omitempty"` // Namespace of the changed resource, if namespaced
+	Tier      string      `json:"tier,omitempty"`      // Tier added or removed, for annotation events
+	Before    interface{} `json:"before,omitempty"`    // State before the change, if any
+	After     interface{} `json:"after,omitempty"`     // State after the change, if any
+	Timestamp time.Time   `json:"timestamp"`           // When the change was made
+}
+
+// Notifier posts events to a webhook URL. A nil Notifier is valid and discards events.
+type Notifier struct {
+	url         string
+	secret      string
+	client      *http.Client
+	maxAttempts int
+	backoff     time.Duration
+}
+
+// NewNotifier returns a Notifier for url, or nil when url is empty so notifications are a no-op.
+// When secret is non-empty each request is signed with HMAC-SHA256.
+func NewNotifier(url, secret string) *Notifier {
+	if url == "" {
+		return nil
+	}
+	return &Notifier{
+		url:         url,
+		secret:      secret,
+		client:      &http.Client{Timeout: 5 * time.Second},
+		maxAttempts: 3,
+		backoff:     500 * time.Millisecond,
+	}
+}
+
+// Notify delivers event in the background so callers are never blocked or failed by delivery
+func (n *Notifier) Notify(event Event) {
+	if n == nil {
+		return
+	}
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now().UTC()
+	}
+
+	go func() {
+		if err := n.deliver(event); err != nil {
+			log.Printf("Webhook delivery failed for %s %s %s: %v", event.Resource, event.Name, event.Action, err)
+		}
+	}()
+}
+
+// deliver posts event, retrying with exponential backoff up to maxAttempts times
+func (n *Notifier) deliver(event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	backoff := n.backoff
+	for attempt := 1; ; attempt++ {
+		err = n.post(body)
+		if err == nil {
+			return nil
+		}
+		if attempt >= n.maxAttempts {
+			return fmt.Errorf("giving up after %d attempts: %w", attempt, err)
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// post sends a single delivery attempt
+func (n *Notifier) post(body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if n.secret != "" {
+		req.Header.Set(SignatureHeader, Sign(n.secret, body))
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Sign returns the signature header value for body: "sha256=" followed by the hex HMAC-SHA256
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,104 @@
+// Copyright 2025 Bryon Baker
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestNewNotifier_EmptyURLIsNoOp(t *testing.T) {
+	n := NewNotifier("", "secret")
+	if n != nil {
+		t.Fatal("Expected nil Notifier for empty URL")
+	}
+	// Must not panic
+	n.Notify(Event{Action: ActionCreated})
+}
+
+func TestNotify_SignsAndDelivers(t *testing.T) {
+	received := make(chan *http.Request, 1)
+	bodies := make(chan []byte, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- r
+		bodies <- body
+	}))
+	defer server.Close()
+
+	n := NewNotifier(server.URL, "secret")
+	n.Notify(Event{Action: ActionCreated, Resource: ResourceTier, Name: "free"})
+
+	select {
+	case r := <-received:
+		body := <-bodies
+		if got, want := r.Header.Get(SignatureHeader), Sign("secret", body); got != want {
+			t.Errorf("Expected signature %s, got %s", want, got)
+		}
+		var event Event
+		if err := json.Unmarshal(body, &event); err != nil {
+			t.Fatalf("Failed to unmarshal event: %v", err)
+		}
+		if event.Action != ActionCreated || event.Name != "free" || event.Timestamp.IsZero() {
+			t.Errorf("Unexpected event: %+v", event)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for webhook delivery")
+	}
+}
+
+func TestDeliver_RetriesThenSucceeds(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	n := NewNotifier(server.URL, "")
+	n.backoff = time.Millisecond
+
+	if err := n.deliver(Event{Action: ActionDeleted}); err != nil {
+		t.Fatalf("deliver() error = %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 attempts, got %d", calls)
+	}
+}
+
+func TestDeliver_GivesUpAfterMaxAttempts(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	n := NewNotifier(server.URL, "")
+	n.backoff = time.Millisecond
+
+	if err := n.deliver(Event{Action: ActionDeleted}); err == nil {
+		t.Fatal("Expected deliver() to fail")
+	}
+	if int(calls) != n.maxAttempts {
+		t.Errorf("Expected %d attempts, got %d", n.maxAttempts, calls)
+	}
+}